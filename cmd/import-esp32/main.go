@@ -0,0 +1,91 @@
+// import-esp32 importa un manifiesto de fábrica (CSV o JSON) de ESP32 y sus sensores.
+//
+// Uso:
+//
+//	go run ./cmd/import-esp32 -file manifiesto.csv           # solo valida (dry run)
+//	go run ./cmd/import-esp32 -file manifiesto.csv -apply    # crea los ESP32
+//
+// El reporte se escribe en JSON por la salida estándar e incluye, para cada ESP32 creado,
+// el código de reclamo y el token de firmware, que no se pueden volver a consultar.
+// La conexión a la base de datos usa las mismas variables de entorno que la API.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"hex_go/src/config"
+	"hex_go/src/esp32/application/services"
+	esp32Infrastructure "hex_go/src/esp32/infrastructure"
+	"hex_go/src/esp32/infrastructure/repositories"
+)
+
+func main() {
+	file := flag.String("file", "", "manifest file to import")
+	format := flag.String("format", "", "manifest format: csv or json (default: file extension)")
+	apply := flag.Bool("apply", false, "create the ESP32s; without this flag the manifest is only validated")
+	flag.Parse()
+
+	if *file == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(*file)), ".")
+	}
+
+	// Cargar variables de entorno
+	if err := godotenv.Load(); err != nil {
+		log.Println("Warning: .env file not found")
+	}
+
+	manifest, err := os.Open(*file)
+	if err != nil {
+		log.Fatalf("Failed to open manifest: %v", err)
+	}
+	defer manifest.Close()
+
+	rows, err := services.ParseManifest(*format, manifest)
+	if err != nil {
+		log.Fatalf("Failed to parse manifest: %v", err)
+	}
+
+	db, err := config.InitDB()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	esp32Infrastructure.Migrate(db)
+
+	importUseCase := services.NewImportESP32sUseCase(
+		repositories.NewMySQLESP32Repository(db),
+		repositories.NewMySQLSensorTypeRepository(db),
+	)
+	report, err := importUseCase.Execute(context.Background(), rows, !*apply)
+	if err != nil {
+		log.Fatalf("Import failed: %v", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		log.Fatalf("Failed to write report: %v", err)
+	}
+
+	switch {
+	case report.Invalid > 0:
+		log.Printf("%d of %d rows are invalid, nothing was created", report.Invalid, report.Total)
+		os.Exit(1)
+	case report.DryRun:
+		log.Printf("%d rows are valid, run again with -apply to create them", report.Valid)
+	default:
+		log.Printf("%d ESP32 created", report.Created)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"hash/fnv"
+	"math/rand"
+	"time"
+
+	"hex_go/src/telemetry/application/services"
+)
+
+// Operaciones que se miden en las estadísticas
+const (
+	opHeartbeat = "heartbeat"
+	opReadings  = "readings"
+	opBatch     = "batch"
+)
+
+// deviceRunner reproduce el comportamiento del firmware de un ESP32 virtual
+type deviceRunner struct {
+	device            *virtualDevice
+	transport         Transport
+	stats             *stats
+	readingInterval   time.Duration
+	heartbeatInterval time.Duration
+	incident          *incident
+	// Lecturas acumuladas mientras el ESP32 está sin conexión o sin confirmar por la API
+	buffer []services.ReadingInput
+}
+
+// Run envía heartbeats y lecturas hasta que se cancele el contexto
+func (r *deviceRunner) Run(ctx context.Context) {
+	seed := fnv.New64a()
+	seed.Write([]byte(r.device.NumeroSerie))
+	rng := rand.New(rand.NewSource(time.Now().UnixNano() ^ int64(seed.Sum64())))
+
+	simulators := make([]*sensorSimulator, 0, len(r.device.Sensors))
+	for _, sensor := range r.device.Sensors {
+		if simulator := newSensorSimulator(sensor, rng); simulator != nil {
+			simulators = append(simulators, simulator)
+		}
+	}
+
+	// Repartir los envíos de la flota en el tiempo para no enviar todo a la vez
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(time.Duration(rng.Int63n(int64(r.readingInterval)))):
+	}
+
+	r.heartbeat(ctx)
+
+	readingTicker := time.NewTicker(r.readingInterval)
+	defer readingTicker.Stop()
+	heartbeatTicker := time.NewTicker(r.heartbeatInterval)
+	defer heartbeatTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeatTicker.C:
+			if !r.incident.offline(time.Now()) {
+				r.heartbeat(ctx)
+			}
+		case now := <-readingTicker.C:
+			if len(simulators) == 0 {
+				continue
+			}
+			for _, simulator := range simulators {
+				reading := simulator.reading(now, rng, r.incident)
+				reading.Seq = r.device.nextSeq()
+				r.buffer = append(r.buffer, reading)
+			}
+			if !r.incident.offline(now) {
+				r.flush(ctx, len(simulators))
+			}
+		}
+	}
+}
+
+// heartbeat envía un heartbeat y registra su resultado
+func (r *deviceRunner) heartbeat(ctx context.Context) {
+	start := time.Now()
+	err := r.transport.Heartbeat(ctx, r.device)
+	r.stats.Record(opHeartbeat, time.Since(start), err)
+}
+
+// flush envía las lecturas acumuladas: en tiempo real si son solo las de la última medición
+// (perLap lecturas) o como lote si se acumularon durante un corte o un envío fallido. Si el
+// envío falla se conservan para reintentarlas, como haría el firmware; la API descarta las
+// secuencias repetidas.
+func (r *deviceRunner) flush(ctx context.Context, perLap int) {
+	// El firmware tiene memoria limitada: si el corte se alarga se pierden las más antiguas
+	if len(r.buffer) > services.MaxReadingsPerBatch {
+		r.buffer = r.buffer[len(r.buffer)-services.MaxReadingsPerBatch:]
+	}
+
+	op := opReadings
+	if len(r.buffer) > perLap || len(r.buffer) > services.MaxReadingsPerRequest {
+		op = opBatch
+	}
+
+	start := time.Now()
+	err := r.transport.SendReadings(ctx, r.device, r.buffer, op == opBatch)
+	if ctx.Err() != nil {
+		return
+	}
+	r.stats.Record(op, time.Since(start), err)
+	if err == nil {
+		r.buffer = r.buffer[:0]
+	}
+}
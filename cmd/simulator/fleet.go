@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"hex_go/src/esp32/application/services"
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/infrastructure/repositories"
+)
+
+// virtualDevice es un ESP32 simulado con las credenciales que usa su firmware
+type virtualDevice struct {
+	NumeroSerie string
+	Token       string
+	Sensors     []*entities.DeviceSensor
+	// Último número de secuencia enviado; arranca en la hora de inicio en milisegundos para
+	// que las lecturas de una nueva ejecución no se descarten como reenvíos de la anterior
+	seq uint64
+}
+
+// nextSeq devuelve el siguiente número de secuencia de lectura
+func (d *virtualDevice) nextSeq() *uint64 {
+	d.seq++
+	seq := d.seq
+	return &seq
+}
+
+// prepareFleet da de alta los ESP32 virtuales que no existen y emite un nuevo token para los
+// que ya existen, de modo que el simulador conozca el token de todos
+func prepareFleet(ctx context.Context, db *sql.DB, prefix string, count int) ([]*virtualDevice, error) {
+	esp32Repo := repositories.NewMySQLESP32Repository(db)
+	deviceSensorRepo := repositories.NewMySQLDeviceSensorRepository(db)
+	provisionUseCase := services.NewProvisionESP32UseCase(esp32Repo, repositories.NewMySQLSensorTypeRepository(db))
+	regenerateTokenUseCase := services.NewRegenerateDeviceTokenUseCase(esp32Repo)
+
+	seqStart := uint64(time.Now().UnixMilli())
+	fleet := make([]*virtualDevice, 0, count)
+	created := 0
+
+	for i := 1; i <= count; i++ {
+		numeroSerie := fmt.Sprintf("%s-%04d", prefix, i)
+
+		esp32, err := esp32Repo.FindByNumeroSerie(ctx, numeroSerie)
+		if err != nil {
+			return nil, err
+		}
+
+		var token string
+		if esp32 == nil {
+			response, err := provisionUseCase.Execute(ctx, numeroSerie, "")
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", numeroSerie, err)
+			}
+			esp32, token = response.ESP32, response.DeviceToken
+			created++
+		} else {
+			token, err = regenerateTokenUseCase.Execute(ctx, esp32.ID)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", numeroSerie, err)
+			}
+		}
+
+		sensors, err := deviceSensorRepo.FindByESP32ID(ctx, esp32.ID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", numeroSerie, err)
+		}
+		if len(sensors) == 0 {
+			log.Printf("Warning: %s has no sensors, it will only send heartbeats", numeroSerie)
+		}
+
+		fleet = append(fleet, &virtualDevice{
+			NumeroSerie: numeroSerie,
+			Token:       token,
+			Sensors:     sensors,
+			seq:         seqStart,
+		})
+	}
+
+	log.Printf("%d virtual ESP32s ready (%d created, %d reused)", len(fleet), created, len(fleet)-created)
+	return fleet, nil
+}
@@ -0,0 +1,157 @@
+// simulator simula una flota de ESP32 para pruebas de carga y demostraciones.
+//
+// Uso:
+//
+//	go run ./cmd/simulator -devices 50                                  # HTTP contra localhost:8080
+//	go run ./cmd/simulator -devices 50 -transport mqtt -format protobuf # MQTT con Protobuf
+//	go run ./cmd/simulator -devices 20 -scenario fire -affected 0.25 -incident-after 1m
+//
+// Los ESP32 virtuales se llaman <prefix>-0001, <prefix>-0002, ... Los que no existen se dan
+// de alta con el kit de sensores por defecto y los que ya existen se reutilizan emitiendo un
+// nuevo token de firmware, por lo que el simulador necesita acceso a la base de datos con las
+// mismas variables de entorno que la API.
+//
+// Escenarios:
+//
+//	normal  lecturas estables con ruido
+//	fire    incendio: sube la temperatura, el humo y la calidad del aire empeora hasta que se activa el sensor de llama
+//	gas     fuga de gas: sube la lectura del MQ_2 sin llama ni temperatura
+//	outage  corte de Wi-Fi: los ESP32 dejan de enviar y al reconectar suben las lecturas acumuladas en un lote
+//
+// Al terminar (por -duration o con Ctrl+C) se escriben las estadísticas de latencia y errores.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/joho/godotenv"
+	"hex_go/src/config"
+	esp32Infrastructure "hex_go/src/esp32/infrastructure"
+	"hex_go/src/telemetry/infrastructure/codec"
+)
+
+// formats asocia el valor de -format con el tipo de contenido de los envíos
+var formats = map[string]string{
+	"json":     codec.ContentTypeJSON,
+	"cbor":     codec.ContentTypeCBOR,
+	"protobuf": codec.ContentTypeProtobuf,
+}
+
+func main() {
+	devices := flag.Int("devices", 10, "number of virtual ESP32s")
+	prefix := flag.String("prefix", "SIM", "serial number prefix of the virtual ESP32s")
+	transportName := flag.String("transport", "http", "transport: http or mqtt")
+	format := flag.String("format", "json", "payload format: json, cbor or protobuf")
+	apiURL := flag.String("url", "http://localhost:8080", "API base URL (http transport)")
+	brokerURL := flag.String("broker", config.GetEnv("MQTT_BROKER_URL", "tcp://localhost:1883"), "MQTT broker URL (mqtt transport)")
+	topicPrefix := flag.String("topic-prefix", config.GetEnv("MQTT_TOPIC_PREFIX", "stopfire"), "MQTT topic prefix (mqtt transport)")
+	mqttUsername := flag.String("mqtt-username", "", "MQTT username (mqtt transport)")
+	mqttPassword := flag.String("mqtt-password", "", "MQTT password (mqtt transport)")
+	readingInterval := flag.Duration("interval", 10*time.Second, "time between readings of each ESP32")
+	heartbeatInterval := flag.Duration("heartbeat", time.Minute, "time between heartbeats of each ESP32")
+	duration := flag.Duration("duration", 0, "how long to run; 0 runs until interrupted")
+	scenarioName := flag.String("scenario", scenarioNormal, "scenario: normal, fire, gas or outage")
+	affected := flag.Float64("affected", 0.1, "fraction of the ESP32s affected by the scenario")
+	incidentAfter := flag.Duration("incident-after", time.Minute, "time until the scenario incident starts")
+	incidentLength := flag.Duration("incident-length", 2*time.Minute, "time until the incident peaks (fire, gas) or ends (outage)")
+	reportEvery := flag.Duration("report", 30*time.Second, "interval between partial statistics; 0 disables them")
+	flag.Parse()
+
+	contentType, ok := formats[strings.ToLower(*format)]
+	if !ok {
+		log.Fatalf("Unsupported format %q, use json, cbor or protobuf", *format)
+	}
+	scenario, err := newScenario(*scenarioName, *affected, *incidentAfter, *incidentLength)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *devices <= 0 || *readingInterval <= 0 || *heartbeatInterval <= 0 {
+		log.Fatal("-devices, -interval and -heartbeat must be positive")
+	}
+
+	// Cargar variables de entorno
+	if err := godotenv.Load(); err != nil {
+		log.Println("Warning: .env file not found")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if *duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *duration)
+		defer cancel()
+	}
+
+	db, err := config.InitDB()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	esp32Infrastructure.Migrate(db)
+
+	fleet, err := prepareFleet(ctx, db, *prefix, *devices)
+	db.Close()
+	if err != nil {
+		log.Fatalf("Failed to prepare the virtual ESP32s: %v", err)
+	}
+
+	var transport Transport
+	switch strings.ToLower(*transportName) {
+	case "http":
+		transport = newHTTPTransport(*apiURL, contentType)
+	case "mqtt":
+		transport, err = newMQTTTransport(*brokerURL, *mqttUsername, *mqttPassword, *topicPrefix, contentType)
+		if err != nil {
+			log.Fatalf("Failed to connect to the MQTT broker: %v", err)
+		}
+	default:
+		log.Fatalf("Unsupported transport %q, use http or mqtt", *transportName)
+	}
+	defer transport.Close()
+
+	stats := newStats()
+	log.Printf("Simulating %d ESP32s over %s (%s), scenario %s", len(fleet), *transportName, *format, scenario.name)
+
+	if *reportEvery > 0 {
+		go func() {
+			ticker := time.NewTicker(*reportEvery)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					log.Print(stats.Summary())
+				}
+			}
+		}()
+	}
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i, device := range fleet {
+		wg.Add(1)
+		go func(index int, device *virtualDevice) {
+			defer wg.Done()
+			runner := &deviceRunner{
+				device:            device,
+				transport:         transport,
+				stats:             stats,
+				readingInterval:   *readingInterval,
+				heartbeatInterval: *heartbeatInterval,
+				incident:          scenario.forDevice(index, len(fleet), start),
+			}
+			runner.Run(ctx)
+		}(i, device)
+	}
+	wg.Wait()
+
+	stats.Write(os.Stdout, time.Since(start))
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/telemetry/application/services"
+)
+
+// Escenarios que puede reproducir el simulador
+const (
+	scenarioNormal = "normal"
+	scenarioFire   = "fire"
+	scenarioGas    = "gas"
+	scenarioOutage = "outage"
+)
+
+// Umbrales con los que el firmware simulado decide que un sensor analógico está en alarma
+const (
+	temperatureAlarm = 57.0
+	smokeAlarm       = 1000.0
+	airQualityAlarm  = 400.0
+)
+
+// scenario describe el incidente que se reproduce sobre una parte de la flota
+type scenario struct {
+	name     string
+	affected float64       // Fracción de los ESP32 que sufren el incidente
+	after    time.Duration // Tiempo desde el inicio hasta que empieza el incidente
+	length   time.Duration // Tiempo hasta el pico del incidente o, en un corte, hasta que termina
+}
+
+// newScenario valida los parámetros del escenario
+func newScenario(name string, affected float64, after, length time.Duration) (*scenario, error) {
+	switch name {
+	case scenarioNormal, scenarioFire, scenarioGas, scenarioOutage:
+	default:
+		return nil, fmt.Errorf("unsupported scenario %q, use normal, fire, gas or outage", name)
+	}
+	if affected < 0 || affected > 1 {
+		return nil, fmt.Errorf("-affected must be between 0 and 1")
+	}
+	if after < 0 || length <= 0 {
+		return nil, fmt.Errorf("-incident-after must not be negative and -incident-length must be positive")
+	}
+
+	return &scenario{name: name, affected: affected, after: after, length: length}, nil
+}
+
+// forDevice devuelve el incidente que sufre el ESP32 indicado, o nil si no está afectado
+func (s *scenario) forDevice(index, total int, start time.Time) *incident {
+	if s.name == scenarioNormal || index >= int(math.Ceil(s.affected*float64(total))) {
+		return nil
+	}
+
+	return &incident{kind: s.name, start: start.Add(s.after), length: s.length}
+}
+
+// incident es el incidente que sufre un ESP32 concreto
+type incident struct {
+	kind   string
+	start  time.Time
+	length time.Duration
+}
+
+// progress devuelve el avance del incidente entre 0 (no empezó) y 1 (en su pico)
+func (i *incident) progress(now time.Time) float64 {
+	if i == nil || now.Before(i.start) {
+		return 0
+	}
+	return math.Min(1, float64(now.Sub(i.start))/float64(i.length))
+}
+
+// offline indica si el ESP32 está sin conexión por un corte en el momento indicado
+func (i *incident) offline(now time.Time) bool {
+	return i != nil && i.kind == scenarioOutage && !now.Before(i.start) && now.Before(i.start.Add(i.length))
+}
+
+// is indica si el incidente es del tipo indicado
+func (i *incident) is(kind string) bool {
+	return i != nil && i.kind == kind
+}
+
+// sensorSimulator genera lecturas verosímiles para un sensor instalado
+type sensorSimulator struct {
+	sensor   *entities.DeviceSensor
+	baseline float64
+}
+
+// newSensorSimulator elige el valor de reposo del sensor; devuelve nil para los tipos que el
+// simulador no sabe reproducir
+func newSensorSimulator(sensor *entities.DeviceSensor, rng *rand.Rand) *sensorSimulator {
+	var baseline float64
+	switch sensor.SensorType {
+	case entities.SensorKY026:
+	case entities.SensorDHT22:
+		baseline = 20 + rng.Float64()*5
+	case entities.SensorMQ2:
+		baseline = 250 + rng.Float64()*150
+	case entities.SensorMQ135:
+		baseline = 80 + rng.Float64()*70
+	default:
+		return nil
+	}
+
+	return &sensorSimulator{sensor: sensor, baseline: baseline}
+}
+
+// reading genera la lectura del sensor en el momento indicado según el avance del incidente
+func (s *sensorSimulator) reading(now time.Time, rng *rand.Rand, incident *incident) services.ReadingInput {
+	p := incident.progress(now)
+	fire := incident.is(scenarioFire)
+	gas := incident.is(scenarioGas)
+
+	sensorID := s.sensor.ID
+	recordedAt := now
+	input := services.ReadingInput{
+		SensorID:   &sensorID,
+		SensorType: s.sensor.SensorType,
+		RecordedAt: &recordedAt,
+	}
+
+	var value, limit float64
+	switch s.sensor.SensorType {
+	case entities.SensorKY026:
+		// El sensor de llama es digital y se activa a mitad del incendio
+		alarm := fire && p >= 0.5
+		input.Alarm = &alarm
+		return input
+	case entities.SensorDHT22:
+		value, limit = s.baseline+rng.NormFloat64()*0.2, temperatureAlarm
+		if fire {
+			value += 62 * p
+		}
+		value = math.Min(value, 80)
+	case entities.SensorMQ2:
+		value, limit = s.baseline+rng.NormFloat64()*15, smokeAlarm
+		if fire {
+			value += 4000 * p
+		}
+		if gas {
+			value += 6000 * p
+		}
+	case entities.SensorMQ135:
+		value, limit = s.baseline+rng.NormFloat64()*8, airQualityAlarm
+		if fire {
+			value += 600 * p
+		}
+		if gas {
+			value += 150 * p
+		}
+	}
+
+	value = math.Round(value*10) / 10
+	alarm := value > limit
+	input.Value = &value
+	input.Alarm = &alarm
+	return input
+}
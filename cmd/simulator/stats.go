@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// maxErrorKinds limita la cantidad de errores distintos que se listan en el resumen final
+const maxErrorKinds = 10
+
+// stats acumula la latencia y los errores de cada operación de todos los ESP32 virtuales
+type stats struct {
+	mu         sync.Mutex
+	operations map[string]*operationStats
+}
+
+// operationStats son las mediciones de una operación
+type operationStats struct {
+	latencies []time.Duration
+	errors    int
+	messages  map[string]int // Cantidad de veces que se repitió cada mensaje de error
+}
+
+// newStats crea un acumulador vacío
+func newStats() *stats {
+	return &stats{operations: make(map[string]*operationStats)}
+}
+
+// Record registra el resultado de un envío
+func (s *stats) Record(operation string, latency time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	op, ok := s.operations[operation]
+	if !ok {
+		op = &operationStats{messages: make(map[string]int)}
+		s.operations[operation] = op
+	}
+
+	op.latencies = append(op.latencies, latency)
+	if err != nil {
+		op.errors++
+		op.messages[err.Error()]++
+	}
+}
+
+// Summary devuelve una línea con el estado parcial de cada operación
+func (s *stats) Summary() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	parts := make([]string, 0, len(s.operations))
+	for _, name := range s.operationNames() {
+		op := s.operations[name]
+		sorted := op.sorted()
+		parts = append(parts, fmt.Sprintf("%s: %d sent, %d errors, p95 %s",
+			name, len(sorted), op.errors, percentile(sorted, 0.95)))
+	}
+	if len(parts) == 0 {
+		return "nothing sent yet"
+	}
+	return strings.Join(parts, " | ")
+}
+
+// Write escribe el resumen final con los percentiles de latencia y los errores más frecuentes
+func (s *stats) Write(w io.Writer, elapsed time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fmt.Fprintf(w, "\nSimulation ran for %s\n\n", elapsed.Round(time.Second))
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(table, "operation\tsent\terrors\trate/s\tmin\tavg\tp50\tp95\tp99\tmax\t")
+	for _, name := range s.operationNames() {
+		op := s.operations[name]
+		sorted := op.sorted()
+
+		var total time.Duration
+		for _, latency := range sorted {
+			total += latency
+		}
+
+		fmt.Fprintf(table, "%s\t%d\t%d\t%.1f\t%s\t%s\t%s\t%s\t%s\t%s\t\n",
+			name, len(sorted), op.errors, float64(len(sorted))/elapsed.Seconds(),
+			round(sorted[0]), round(total/time.Duration(len(sorted))),
+			percentile(sorted, 0.50), percentile(sorted, 0.95), percentile(sorted, 0.99),
+			round(sorted[len(sorted)-1]))
+	}
+	table.Flush()
+
+	for _, name := range s.operationNames() {
+		op := s.operations[name]
+		if op.errors == 0 {
+			continue
+		}
+
+		messages := make([]string, 0, len(op.messages))
+		for message := range op.messages {
+			messages = append(messages, message)
+		}
+		sort.Slice(messages, func(i, j int) bool {
+			return op.messages[messages[i]] > op.messages[messages[j]]
+		})
+		if len(messages) > maxErrorKinds {
+			messages = messages[:maxErrorKinds]
+		}
+
+		fmt.Fprintf(w, "\n%s errors:\n", name)
+		for _, message := range messages {
+			fmt.Fprintf(w, "  %6d  %s\n", op.messages[message], message)
+		}
+	}
+}
+
+// operationNames devuelve las operaciones registradas en orden alfabético
+func (s *stats) operationNames() []string {
+	names := make([]string, 0, len(s.operations))
+	for name := range s.operations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sorted devuelve una copia ordenada de las latencias
+func (o *operationStats) sorted() []time.Duration {
+	sorted := make([]time.Duration, len(o.latencies))
+	copy(sorted, o.latencies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
+
+// percentile devuelve el percentil indicado de latencias ya ordenadas
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	index := int(float64(len(sorted)-1) * p)
+	return round(sorted[index])
+}
+
+// round redondea una latencia para que el resumen sea legible
+func round(latency time.Duration) time.Duration {
+	if latency >= time.Second {
+		return latency.Round(time.Millisecond)
+	}
+	return latency.Round(10 * time.Microsecond)
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	pahomqtt "github.com/eclipse/paho.mqtt.golang"
+	"hex_go/src/telemetry/application/services"
+	"hex_go/src/telemetry/infrastructure/codec"
+)
+
+// requestTimeout limita la espera de cada envío
+const requestTimeout = 10 * time.Second
+
+// Transport envía los mensajes de un ESP32 virtual a la API
+type Transport interface {
+	Heartbeat(ctx context.Context, device *virtualDevice) error
+	SendReadings(ctx context.Context, device *virtualDevice, readings []services.ReadingInput, batch bool) error
+	Close()
+}
+
+// httpTransport envía los mensajes a los endpoints HTTP de dispositivos
+type httpTransport struct {
+	baseURL     string
+	contentType string
+	client      *http.Client
+}
+
+// newHTTPTransport crea un transporte HTTP contra la API indicada
+func newHTTPTransport(baseURL, contentType string) *httpTransport {
+	return &httpTransport{
+		baseURL:     strings.TrimRight(baseURL, "/"),
+		contentType: contentType,
+		client:      &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// Heartbeat envía un heartbeat a /api/devices/heartbeat
+func (t *httpTransport) Heartbeat(ctx context.Context, device *virtualDevice) error {
+	body, err := codec.EncodeHeartbeat(t.contentType, &codec.Heartbeat{})
+	if err != nil {
+		return err
+	}
+	return t.post(ctx, device, "/api/devices/heartbeat", body)
+}
+
+// SendReadings envía lecturas a /api/devices/readings o, si es un lote, a /api/devices/readings/batch
+func (t *httpTransport) SendReadings(ctx context.Context, device *virtualDevice, readings []services.ReadingInput, batch bool) error {
+	body, err := codec.EncodeReadingBatch(t.contentType, &codec.ReadingBatch{Readings: readings})
+	if err != nil {
+		return err
+	}
+
+	path := "/api/devices/readings"
+	if batch {
+		path += "/batch"
+	}
+	return t.post(ctx, device, path, body)
+}
+
+// Close no tiene recursos que liberar en HTTP
+func (t *httpTransport) Close() {}
+
+// post envía el cuerpo con las credenciales del ESP32 y convierte las respuestas de error en errores
+func (t *httpTransport) post(ctx context.Context, device *virtualDevice, path string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", t.contentType)
+	req.Header.Set("X-Device-Serial", device.NumeroSerie)
+	req.Header.Set("X-Device-Token", device.Token)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 300 {
+		io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+
+	var apiError struct {
+		Error string `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&apiError); err != nil || apiError.Error == "" {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return fmt.Errorf("HTTP %d: %s", resp.StatusCode, apiError.Error)
+}
+
+// topicSuffixes asocia el tipo de contenido con el sufijo de tópico que espera el puente MQTT
+var topicSuffixes = map[string]string{
+	codec.ContentTypeJSON:     "",
+	codec.ContentTypeCBOR:     "/cbor",
+	codec.ContentTypeProtobuf: "/pb",
+}
+
+// mqttTransport publica los mensajes en los tópicos que escucha el puente MQTT de la API.
+// La latencia medida es la de la confirmación del broker (QoS 1), no la del procesamiento en la API.
+type mqttTransport struct {
+	client      pahomqtt.Client
+	topicPrefix string
+	contentType string
+}
+
+// newMQTTTransport conecta con el broker; todos los ESP32 virtuales comparten la conexión
+func newMQTTTransport(brokerURL, username, password, topicPrefix, contentType string) (*mqttTransport, error) {
+	options := pahomqtt.NewClientOptions().
+		AddBroker(brokerURL).
+		SetClientID(fmt.Sprintf("stopfire-simulator-%d", os.Getpid())).
+		SetUsername(username).
+		SetPassword(password).
+		SetAutoReconnect(true)
+
+	client := pahomqtt.NewClient(options)
+	token := client.Connect()
+	if !token.WaitTimeout(requestTimeout) {
+		return nil, errors.New("timed out connecting to the broker")
+	}
+	if err := token.Error(); err != nil {
+		return nil, err
+	}
+
+	return &mqttTransport{client: client, topicPrefix: topicPrefix, contentType: contentType}, nil
+}
+
+// Heartbeat publica un heartbeat en <prefix>/<numero_serie>/heartbeat
+func (t *mqttTransport) Heartbeat(ctx context.Context, device *virtualDevice) error {
+	payload, err := codec.EncodeHeartbeat(t.contentType, &codec.Heartbeat{Token: device.Token})
+	if err != nil {
+		return err
+	}
+	return t.publish(device, "heartbeat", payload)
+}
+
+// SendReadings publica lecturas en <prefix>/<numero_serie>/readings o, si es un lote, en .../batch
+func (t *mqttTransport) SendReadings(ctx context.Context, device *virtualDevice, readings []services.ReadingInput, batch bool) error {
+	payload, err := codec.EncodeReadingBatch(t.contentType, &codec.ReadingBatch{Readings: readings, Token: device.Token})
+	if err != nil {
+		return err
+	}
+
+	kind := "readings"
+	if batch {
+		kind = "batch"
+	}
+	return t.publish(device, kind, payload)
+}
+
+// Close cierra la conexión con el broker
+func (t *mqttTransport) Close() {
+	t.client.Disconnect(250)
+}
+
+// publish publica con QoS 1 y espera la confirmación del broker
+func (t *mqttTransport) publish(device *virtualDevice, kind string, payload []byte) error {
+	topic := t.topicPrefix + "/" + device.NumeroSerie + "/" + kind + topicSuffixes[t.contentType]
+
+	token := t.client.Publish(topic, 1, false, payload)
+	if !token.WaitTimeout(requestTimeout) {
+		return errors.New("timed out waiting for the broker")
+	}
+	return token.Error()
+}
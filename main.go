@@ -9,6 +9,8 @@ import (
 	"hex_go/src/alerts/infrastructure"
 	"hex_go/src/config"
 	esp32Infrastructure "hex_go/src/esp32/infrastructure"
+	firmwareInfrastructure "hex_go/src/firmware/infrastructure"
+	telemetryInfrastructure "hex_go/src/telemetry/infrastructure"
 	userInfrastructure "hex_go/src/users/infrastructure"
 )
 
@@ -31,7 +33,7 @@ func main() {
 	config.AllowOrigins = []string{"*"}
 	config.AllowCredentials = true
 	config.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization"}
-	config.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
+	config.AllowMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
 
 	router.Use(cors.New(config))
 
@@ -51,6 +53,15 @@ func main() {
 	// Inicializar infraestructura de alertas
 	infrastructure.Init(router, db)
 
+	// Inicializar infraestructura de firmware
+	firmwareInfrastructure.Init(router, db)
+
+	// Inicializar infraestructura de telemetría
+	telemetryInfrastructure.Init(router, db)
+
+	// Iniciar el puente MQTT (opcional, requiere MQTT_BROKER_URL)
+	telemetryInfrastructure.StartMQTTBridge(db)
+
 	// Iniciar el servidor
 	log.Println("Server running on port 8080")
 	router.Run(":8080")
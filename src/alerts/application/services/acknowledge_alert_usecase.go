@@ -0,0 +1,29 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/alerts/domain/entities"
+	"hex_go/src/alerts/domain/repositories"
+	esp32Services "hex_go/src/esp32/application/services"
+)
+
+// AcknowledgeAlertUseCase acknowledges an alert to show someone is taking care of it
+type AcknowledgeAlertUseCase struct {
+	lifecycle alertLifecycle
+}
+
+// NewAcknowledgeAlertUseCase creates a new instance of AcknowledgeAlertUseCase
+func NewAcknowledgeAlertUseCase(alertRepository repositories.AlertRepository, deviceAccessRepository repositories.DeviceAccessRepository) *AcknowledgeAlertUseCase {
+	return &AcknowledgeAlertUseCase{
+		lifecycle: alertLifecycle{
+			alertRepository:        alertRepository,
+			deviceAccessRepository: deviceAccessRepository,
+		},
+	}
+}
+
+// Execute runs the use case
+func (uc *AcknowledgeAlertUseCase) Execute(ctx context.Context, alertID int, actor esp32Services.Actor, input AlertTransitionInput) (*entities.Alert, error) {
+	return uc.lifecycle.transition(ctx, alertID, actor, entities.AlertAcknowledged, input)
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"hex_go/src/alerts/domain/entities"
+	"hex_go/src/alerts/domain/repositories"
+	esp32Services "hex_go/src/esp32/application/services"
+	userEntities "hex_go/src/users/domain/entities"
+)
+
+// memoryDeviceAccessRepository grants operate access to the owner of each ESP32 and to admins
+type memoryDeviceAccessRepository struct {
+	repositories.DeviceAccessRepository
+	owners map[int]int // Owner of each ESP32 by ID
+}
+
+func (m *memoryDeviceAccessRepository) AccessLevel(ctx context.Context, esp32ID, userID int, role string) (repositories.DeviceAccess, bool, error) {
+	owner, ok := m.owners[esp32ID]
+	if !ok {
+		return repositories.DeviceAccessNone, false, nil
+	}
+	if owner == userID || role == userEntities.RoleAdmin {
+		return repositories.DeviceAccessOperate, true, nil
+	}
+	return repositories.DeviceAccessNone, true, nil
+}
+
+func TestAlertAccessAfterTransfer(t *testing.T) {
+	const (
+		esp32ID       = 1
+		previousOwner = 10
+		newOwner      = 20
+		adminID       = 30
+	)
+	transferredAt := time.Date(2026, 3, 14, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		triggeredAt time.Time
+		actor       esp32Services.Actor
+		wantErr     error
+	}{
+		{"el nuevo dueño no ve las alertas del dueño anterior", transferredAt.Add(-time.Hour),
+			esp32Services.Actor{UserID: newOwner, Role: userEntities.RoleUser}, ErrAlertNotFound},
+		{"el nuevo dueño ve las alertas posteriores a la transferencia", transferredAt.Add(time.Hour),
+			esp32Services.Actor{UserID: newOwner, Role: userEntities.RoleUser}, nil},
+		{"el dueño anterior ya no tiene acceso al ESP32", transferredAt.Add(-time.Hour),
+			esp32Services.Actor{UserID: previousOwner, Role: userEntities.RoleUser}, ErrAlertNotFound},
+		{"un administrador ve todo el historial", transferredAt.Add(-time.Hour),
+			esp32Services.Actor{UserID: adminID, Role: userEntities.RoleAdmin}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alertRepo := newMemoryAlertRepository()
+			alert := &entities.Alert{ESP32ID: esp32ID, SensorID: 7, SensorType: entities.AlertTypeKY026,
+				State: entities.AlertTriggered, TriggeredAt: tt.triggeredAt, ESP32AssignedAt: &transferredAt}
+			if _, err := alertRepo.Create(context.Background(), alert); err != nil {
+				t.Fatalf("create: %v", err)
+			}
+			accessRepo := &memoryDeviceAccessRepository{owners: map[int]int{esp32ID: newOwner}}
+
+			_, err := NewGetAlertUseCase(alertRepo, accessRepo).Execute(context.Background(), alert.ID, tt.actor)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("get error = %v, want %v", err, tt.wantErr)
+			}
+
+			_, err = NewAcknowledgeAlertUseCase(alertRepo, accessRepo).Execute(context.Background(), alert.ID, tt.actor, AlertTransitionInput{})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("acknowledge error = %v, want %v", err, tt.wantErr)
+			}
+			stored, _ := alertRepo.FindByID(context.Background(), alert.ID)
+			if acknowledged := stored.State == entities.AlertAcknowledged; acknowledged != (tt.wantErr == nil) {
+				t.Errorf("state = %s after acknowledge with error %v", stored.State, err)
+			}
+		})
+	}
+}
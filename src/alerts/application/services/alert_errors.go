@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"hex_go/src/alerts/domain/entities"
+	"hex_go/src/alerts/domain/repositories"
+	esp32Services "hex_go/src/esp32/application/services"
+)
+
+var (
+	// ErrAlertNotFound is returned when the alert does not exist or the user may not see it
+	ErrAlertNotFound = errors.New("alert not found")
+	// ErrAlertForbidden is returned when the user may see the alert but not change its state
+	ErrAlertForbidden = errors.New("you are not allowed to change this alert")
+	// ErrInvalidAlertTransition is returned when the alert cannot move to the requested state
+	ErrInvalidAlertTransition = errors.New("invalid alert transition")
+)
+
+// findAlert returns the alert if the actor has at least the required access on its ESP32.
+// Alerts of devices the actor may not see, and alerts raised before the current owner received
+// the ESP32, are reported as not found; only admins see the history of previous owners.
+func findAlert(ctx context.Context, alertRepo repositories.AlertRepository, accessRepo repositories.DeviceAccessRepository,
+	alertID int, actor esp32Services.Actor, required repositories.DeviceAccess) (*entities.Alert, error) {
+	alert, err := alertRepo.FindByID(ctx, alertID)
+	if err != nil {
+		return nil, err
+	}
+	if alert == nil || (!actor.IsAdmin() && !alert.BelongsToCurrentOwner()) {
+		return nil, ErrAlertNotFound
+	}
+
+	access, found, err := accessRepo.AccessLevel(ctx, alert.ESP32ID, actor.UserID, actor.Role)
+	if err != nil {
+		return nil, err
+	}
+	if !found || access < repositories.DeviceAccessView {
+		return nil, ErrAlertNotFound
+	}
+	if access < required {
+		return nil, ErrAlertForbidden
+	}
+
+	return alert, nil
+}
+
+// authorizeESP32View checks that the actor may see the alerts of the ESP32
+func authorizeESP32View(ctx context.Context, accessRepo repositories.DeviceAccessRepository, esp32ID int, actor esp32Services.Actor) error {
+	access, found, err := accessRepo.AccessLevel(ctx, esp32ID, actor.UserID, actor.Role)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return esp32Services.ErrESP32NotFound
+	}
+	if access < repositories.DeviceAccessView {
+		return esp32Services.ErrESP32Forbidden
+	}
+	return nil
+}
+
+// ParseAlertStates parses a comma separated list of states. An empty list means the open states
+// (triggered and acknowledged) and "all" means every state.
+func ParseAlertStates(raw string) ([]entities.AlertState, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return entities.OpenAlertStates, nil
+	}
+	if raw == "all" {
+		return nil, nil
+	}
+
+	var states []entities.AlertState
+	for _, value := range strings.Split(raw, ",") {
+		state := entities.AlertState(strings.TrimSpace(value))
+		if !entities.IsValidAlertState(state) {
+			return nil, fmt.Errorf("invalid alert state %q", value)
+		}
+		states = append(states, state)
+	}
+
+	return states, nil
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"hex_go/src/alerts/domain/entities"
+	"hex_go/src/alerts/domain/repositories"
+	esp32Services "hex_go/src/esp32/application/services"
+)
+
+// AlertTransitionInput contains the optional data of a state change
+type AlertTransitionInput struct {
+	Note  string     `json:"note"`
+	Until *time.Time `json:"until"` // Only for mutes; without it the alert stays muted until someone acts on it
+}
+
+// alertLifecycle moves alerts between states on behalf of users
+type alertLifecycle struct {
+	alertRepository        repositories.AlertRepository
+	deviceAccessRepository repositories.DeviceAccessRepository
+}
+
+// transition moves the alert to the given state and stores who did it and when
+func (l *alertLifecycle) transition(ctx context.Context, alertID int, actor esp32Services.Actor, to entities.AlertState, input AlertTransitionInput) (*entities.Alert, error) {
+	note := strings.TrimSpace(input.Note)
+	if utf8.RuneCountInString(note) > 255 {
+		return nil, errors.New("note must be at most 255 characters")
+	}
+
+	alert, err := findAlert(ctx, l.alertRepository, l.deviceAccessRepository, alertID, actor, repositories.DeviceAccessOperate)
+	if err != nil {
+		return nil, err
+	}
+	if !alert.CanTransition(to) {
+		return nil, ErrInvalidAlertTransition
+	}
+
+	now := time.Now()
+	if to == entities.AlertMuted && input.Until != nil && !input.Until.After(now) {
+		return nil, errors.New("until must be in the future")
+	}
+
+	changedBy := actor.UserID
+	transition := alert.Transition(to, &changedBy, now, note)
+	if to == entities.AlertMuted {
+		alert.MutedUntil = input.Until
+	}
+
+	if err := l.alertRepository.SaveTransition(ctx, alert, transition); err != nil {
+		return nil, err
+	}
+
+	return alert, nil
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"hex_go/src/alerts/domain/entities"
+	"hex_go/src/alerts/domain/repositories"
+)
+
+const (
+	// defaultAlertPageSize is the number of alerts returned when no limit is given
+	defaultAlertPageSize = 50
+	// maxAlertPageSize caps the number of alerts returned in a single page
+	maxAlertPageSize = 200
+)
+
+// ErrInvalidAlertCursor is returned when the pagination cursor cannot be decoded
+var ErrInvalidAlertCursor = errors.New("invalid cursor")
+
+// AlertQuery holds the filters and pagination of the alert history
+type AlertQuery struct {
+	ESP32ID    *int       `form:"esp32_id"`
+	SensorType string     `form:"sensor_type"`
+	State      string     `form:"state"` // Comma separated list of states; empty or "all" means every state
+	From       *time.Time `form:"from"`  // RFC3339, inclusive
+	To         *time.Time `form:"to"`    // RFC3339, exclusive
+	Cursor     string     `form:"cursor"`
+	Limit      int        `form:"limit"`
+}
+
+// AlertPage is a page of the alert history
+type AlertPage struct {
+	Alerts     []*entities.Alert           `json:"alerts"`
+	Total      int                         `json:"total"`  // Alerts matching every filter, across all pages
+	Counts     map[entities.AlertState]int `json:"counts"` // Alerts matching the filters by state, ignoring the state filter
+	NextCursor string                      `json:"next_cursor,omitempty"`
+}
+
+// filter validates the query and converts it into a repository filter
+func (q AlertQuery) filter() (repositories.AlertFilter, error) {
+	var filter repositories.AlertFilter
+
+	if q.State != "" {
+		states, err := ParseAlertStates(q.State)
+		if err != nil {
+			return filter, err
+		}
+		filter.States = states
+	}
+
+	if q.From != nil && q.To != nil && !q.From.Before(*q.To) {
+		return filter, errors.New("from must be before to")
+	}
+	if q.Limit < 0 || q.Limit > maxAlertPageSize {
+		return filter, fmt.Errorf("limit must be between 1 and %d", maxAlertPageSize)
+	}
+
+	if q.Cursor != "" {
+		cursor, err := decodeAlertCursor(q.Cursor)
+		if err != nil {
+			return filter, err
+		}
+		filter.After = cursor
+	}
+
+	filter.ESP32ID = q.ESP32ID
+	filter.SensorType = entities.AlertType(strings.ToUpper(strings.TrimSpace(q.SensorType)))
+	filter.From = q.From
+	filter.To = q.To
+	filter.Limit = q.Limit
+	if filter.Limit == 0 {
+		filter.Limit = defaultAlertPageSize
+	}
+
+	return filter, nil
+}
+
+// newAlertPage builds a page from the alerts read with one more than the page size,
+// the extra alert only telling whether there is a next page
+func newAlertPage(alerts []*entities.Alert, counts map[entities.AlertState]int, filter repositories.AlertFilter) *AlertPage {
+	page := &AlertPage{
+		Alerts: alerts,
+		Counts: counts,
+	}
+	if page.Alerts == nil {
+		page.Alerts = []*entities.Alert{}
+	}
+
+	if len(page.Alerts) > filter.Limit {
+		page.Alerts = page.Alerts[:filter.Limit]
+		page.NextCursor = encodeAlertCursor(page.Alerts[len(page.Alerts)-1])
+	}
+
+	for state, count := range counts {
+		if len(filter.States) == 0 || containsState(filter.States, state) {
+			page.Total += count
+		}
+	}
+
+	return page
+}
+
+// encodeAlertCursor returns the position right after the alert in the history
+func encodeAlertCursor(alert *entities.Alert) string {
+	raw := fmt.Sprintf("%d:%d", alert.TriggeredAt.Unix(), alert.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeAlertCursor parses a cursor returned by encodeAlertCursor
+func decodeAlertCursor(cursor string) (*repositories.AlertCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidAlertCursor
+	}
+
+	parts := strings.Split(string(raw), ":")
+	if len(parts) != 2 {
+		return nil, ErrInvalidAlertCursor
+	}
+
+	seconds, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidAlertCursor
+	}
+	id, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil, ErrInvalidAlertCursor
+	}
+
+	return &repositories.AlertCursor{TriggeredAt: time.Unix(seconds, 0).UTC(), ID: id}, nil
+}
+
+// containsState reports whether the state is in the list
+func containsState(states []entities.AlertState, state entities.AlertState) bool {
+	for _, s := range states {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
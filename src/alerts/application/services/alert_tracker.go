@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"hex_go/src/alerts/domain/entities"
+	"hex_go/src/alerts/domain/repositories"
+)
+
+// AlertTracker keeps the stored alerts in sync with the conditions reported by the devices.
+// Each new occurrence of a condition is stored as a triggered alert; when the condition goes
+// away the alert is marked as cleared and, if nobody closed it, resolved by the system.
+type AlertTracker struct {
+	alertRepository repositories.AlertRepository
+}
+
+// NewAlertTracker creates a new instance of AlertTracker
+func NewAlertTracker(alertRepository repositories.AlertRepository) *AlertTracker {
+	return &AlertTracker{
+		alertRepository: alertRepository,
+	}
+}
+
+// Run synchronizes the alerts periodically until the context is cancelled
+func (t *AlertTracker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := t.sync(ctx, time.Now()); err != nil {
+				log.Printf("Warning: alert synchronization failed: %v", err)
+			}
+		}
+	}
+}
+
+// sync stores new occurrences, clears the alerts whose condition went away and ends expired mutes
+func (t *AlertTracker) sync(ctx context.Context, now time.Time) error {
+	conditions, err := t.alertRepository.FindActiveConditions(ctx)
+	if err != nil {
+		return err
+	}
+	uncleared, err := t.alertRepository.FindUncleared(ctx)
+	if err != nil {
+		return err
+	}
+
+	stored := make(map[string]*entities.Alert, len(uncleared))
+	for _, alert := range uncleared {
+		stored[alert.Key()] = alert
+	}
+	active := make(map[string]bool, len(conditions))
+	for _, condition := range conditions {
+		active[condition.Key()] = true
+		if condition.ClearedAt != nil {
+			if err := t.recordEnded(ctx, condition, stored[condition.Key()], now); err != nil {
+				return err
+			}
+			continue
+		}
+		if stored[condition.Key()] != nil {
+			continue
+		}
+
+		condition.CreatedAt = now
+		if _, err := t.alertRepository.Create(ctx, condition); err != nil {
+			return err
+		}
+	}
+
+	for _, alert := range uncleared {
+		if active[alert.Key()] {
+			continue
+		}
+		if err := t.alertRepository.MarkCleared(ctx, alert.ID, now); err != nil {
+			return err
+		}
+		if alert.IsOpen() {
+			t.transition(ctx, alert, entities.AlertResolved, now, "condition cleared")
+		}
+	}
+
+	expired, err := t.alertRepository.FindExpiredMutes(ctx, now)
+	if err != nil {
+		return err
+	}
+	for _, alert := range expired {
+		to := entities.AlertTriggered
+		if alert.AcknowledgedAt != nil {
+			to = entities.AlertAcknowledged
+		}
+		t.transition(ctx, alert, to, now, "mute expired")
+	}
+
+	return nil
+}
+
+// recordEnded stores a condition that already went away, such as an alarm that started and ended
+// while the device was offline, and clears and resolves it at the time the condition ended.
+// alert is the stored alert of the occurrence, or nil if it has none yet.
+func (t *AlertTracker) recordEnded(ctx context.Context, condition, alert *entities.Alert, now time.Time) error {
+	clearedAt := *condition.ClearedAt
+	if alert == nil {
+		condition.ClearedAt = nil
+		condition.CreatedAt = now
+		created, err := t.alertRepository.Create(ctx, condition)
+		if err != nil || !created {
+			return err
+		}
+		alert = condition
+	}
+
+	if err := t.alertRepository.MarkCleared(ctx, alert.ID, clearedAt); err != nil {
+		return err
+	}
+	if alert.IsOpen() {
+		t.transition(ctx, alert, entities.AlertResolved, clearedAt, "condition cleared")
+	}
+	return nil
+}
+
+// transition stores a state change made by the system. A user may have changed the alert
+// since it was read; in that case the user's change is kept.
+func (t *AlertTracker) transition(ctx context.Context, alert *entities.Alert, to entities.AlertState, now time.Time, note string) {
+	transition := alert.Transition(to, nil, now, note)
+	if err := t.alertRepository.SaveTransition(ctx, alert, transition); err != nil && !errors.Is(err, repositories.ErrAlertStateConflict) {
+		log.Printf("Warning: failed to move alert %d to %s: %v", alert.ID, to, err)
+	}
+}
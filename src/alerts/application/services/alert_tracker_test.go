@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"hex_go/src/alerts/domain/entities"
+	"hex_go/src/alerts/domain/repositories"
+)
+
+// memoryAlertRepository keeps the alerts stored by the tracker in memory
+type memoryAlertRepository struct {
+	repositories.AlertRepository
+	conditions  []*entities.Alert
+	alerts      map[string]*entities.Alert
+	transitions []*entities.AlertTransition
+}
+
+func newMemoryAlertRepository() *memoryAlertRepository {
+	return &memoryAlertRepository{alerts: make(map[string]*entities.Alert)}
+}
+
+func (m *memoryAlertRepository) FindActiveConditions(ctx context.Context) ([]*entities.Alert, error) {
+	var conditions []*entities.Alert
+	for _, condition := range m.conditions {
+		// Ended conditions are only returned until their alert is stored as cleared
+		if stored := m.alerts[condition.Key()]; condition.ClearedAt != nil && stored != nil && stored.ClearedAt != nil {
+			continue
+		}
+		clone := *condition
+		conditions = append(conditions, &clone)
+	}
+	return conditions, nil
+}
+
+func (m *memoryAlertRepository) FindByID(ctx context.Context, id int) (*entities.Alert, error) {
+	for _, alert := range m.alerts {
+		if alert.ID == id {
+			clone := *alert
+			return &clone, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryAlertRepository) FindTransitions(ctx context.Context, alertID int) ([]*entities.AlertTransition, error) {
+	var transitions []*entities.AlertTransition
+	for _, transition := range m.transitions {
+		if transition.AlertID == alertID {
+			transitions = append(transitions, transition)
+		}
+	}
+	return transitions, nil
+}
+
+func (m *memoryAlertRepository) FindUncleared(ctx context.Context) ([]*entities.Alert, error) {
+	var uncleared []*entities.Alert
+	for _, alert := range m.alerts {
+		if alert.ClearedAt == nil {
+			clone := *alert
+			uncleared = append(uncleared, &clone)
+		}
+	}
+	return uncleared, nil
+}
+
+func (m *memoryAlertRepository) FindExpiredMutes(ctx context.Context, now time.Time) ([]*entities.Alert, error) {
+	return nil, nil
+}
+
+func (m *memoryAlertRepository) Create(ctx context.Context, alert *entities.Alert) (bool, error) {
+	if _, ok := m.alerts[alert.Key()]; ok {
+		return false, nil
+	}
+	alert.ID = len(m.alerts) + 1
+	clone := *alert
+	m.alerts[alert.Key()] = &clone
+	return true, nil
+}
+
+func (m *memoryAlertRepository) MarkCleared(ctx context.Context, id int, at time.Time) error {
+	for _, alert := range m.alerts {
+		if alert.ID == id && alert.ClearedAt == nil {
+			alert.ClearedAt = &at
+		}
+	}
+	return nil
+}
+
+func (m *memoryAlertRepository) SaveTransition(ctx context.Context, alert *entities.Alert, transition *entities.AlertTransition) error {
+	for _, stored := range m.alerts {
+		if stored.ID == alert.ID {
+			if stored.State != transition.FromState {
+				return repositories.ErrAlertStateConflict
+			}
+			stored.State = alert.State
+			stored.ResolvedAt = alert.ResolvedAt
+		}
+	}
+	m.transitions = append(m.transitions, transition)
+	return nil
+}
+
+func TestAlertTrackerRecordsEndedAlarms(t *testing.T) {
+	startedAt := time.Date(2026, 3, 14, 2, 0, 0, 0, time.UTC)
+	endedAt := startedAt.Add(10 * time.Minute)
+	now := startedAt.Add(3 * time.Hour)
+	smoke := func(clearedAt *time.Time) *entities.Alert {
+		return &entities.Alert{ESP32ID: 1, SensorID: 7, SensorType: entities.AlertTypeKY026,
+			State: entities.AlertTriggered, TriggeredAt: startedAt, ClearedAt: clearedAt}
+	}
+
+	tests := []struct {
+		name    string
+		current bool // The alarm was stored as an alert before the device went offline
+	}{
+		{"alarma que empezó y terminó sin conexión", false},
+		{"alarma registrada que terminó sin conexión", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMemoryAlertRepository()
+			tracker := NewAlertTracker(repo)
+			if tt.current {
+				repo.conditions = []*entities.Alert{smoke(nil)}
+				if err := tracker.sync(context.Background(), startedAt.Add(time.Minute)); err != nil {
+					t.Fatalf("sync: %v", err)
+				}
+			}
+
+			repo.conditions = []*entities.Alert{smoke(&endedAt)}
+			for i := 0; i < 2; i++ {
+				if err := tracker.sync(context.Background(), now); err != nil {
+					t.Fatalf("sync: %v", err)
+				}
+			}
+
+			if len(repo.alerts) != 1 {
+				t.Fatalf("got %d alerts, want 1", len(repo.alerts))
+			}
+			alert := repo.alerts[smoke(nil).Key()]
+			if alert == nil || !alert.TriggeredAt.Equal(startedAt) {
+				t.Fatalf("alert = %+v, want one triggered at %v", alert, startedAt)
+			}
+			if alert.ClearedAt == nil || !alert.ClearedAt.Equal(endedAt) {
+				t.Errorf("cleared_at = %v, want %v", alert.ClearedAt, endedAt)
+			}
+			if alert.State != entities.AlertResolved || alert.ResolvedAt == nil || !alert.ResolvedAt.Equal(endedAt) {
+				t.Errorf("state = %s resolved at %v, want resolved at %v", alert.State, alert.ResolvedAt, endedAt)
+			}
+			if len(repo.transitions) != 1 {
+				t.Errorf("got %d transitions, want 1", len(repo.transitions))
+			}
+		})
+	}
+}
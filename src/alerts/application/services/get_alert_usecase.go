@@ -0,0 +1,41 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/alerts/domain/entities"
+	"hex_go/src/alerts/domain/repositories"
+	esp32Services "hex_go/src/esp32/application/services"
+)
+
+// GetAlertUseCase handles getting a single alert together with its transitions
+type GetAlertUseCase struct {
+	alertRepository        repositories.AlertRepository
+	deviceAccessRepository repositories.DeviceAccessRepository
+}
+
+// NewGetAlertUseCase creates a new instance of GetAlertUseCase
+func NewGetAlertUseCase(alertRepository repositories.AlertRepository, deviceAccessRepository repositories.DeviceAccessRepository) *GetAlertUseCase {
+	return &GetAlertUseCase{
+		alertRepository:        alertRepository,
+		deviceAccessRepository: deviceAccessRepository,
+	}
+}
+
+// Execute gets the alert if the actor may see its ESP32
+func (uc *GetAlertUseCase) Execute(ctx context.Context, alertID int, actor esp32Services.Actor) (*entities.Alert, error) {
+	alert, err := findAlert(ctx, uc.alertRepository, uc.deviceAccessRepository, alertID, actor, repositories.DeviceAccessView)
+	if err != nil {
+		return nil, err
+	}
+
+	alert.Transitions, err = uc.alertRepository.FindTransitions(ctx, alert.ID)
+	if err != nil {
+		return nil, err
+	}
+	if alert.Transitions == nil {
+		alert.Transitions = []*entities.AlertTransition{}
+	}
+
+	return alert, nil
+}
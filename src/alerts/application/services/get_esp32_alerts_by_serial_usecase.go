@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/alerts/domain/repositories"
+	esp32Services "hex_go/src/esp32/application/services"
+)
+
+// GetESP32AlertsBySerialUseCase handles browsing the alert history of an ESP32 identified by its serial number
+type GetESP32AlertsBySerialUseCase struct {
+	alertRepository        repositories.AlertRepository
+	deviceAccessRepository repositories.DeviceAccessRepository
+}
+
+// NewGetESP32AlertsBySerialUseCase creates a new instance of GetESP32AlertsBySerialUseCase
+func NewGetESP32AlertsBySerialUseCase(alertRepository repositories.AlertRepository, deviceAccessRepository repositories.DeviceAccessRepository) *GetESP32AlertsBySerialUseCase {
+	return &GetESP32AlertsBySerialUseCase{
+		alertRepository:        alertRepository,
+		deviceAccessRepository: deviceAccessRepository,
+	}
+}
+
+// Execute gets a page of the alerts of the ESP32 matching the query if the actor owns it or has shared access to it
+func (uc *GetESP32AlertsBySerialUseCase) Execute(ctx context.Context, numeroSerie string, actor esp32Services.Actor, query AlertQuery) (*AlertPage, error) {
+	filter, err := query.filter()
+	if err != nil {
+		return nil, err
+	}
+	filter.ESP32ID = nil
+
+	esp32ID, found, err := uc.deviceAccessRepository.FindESP32IDByNumeroSerie(ctx, numeroSerie)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, esp32Services.ErrESP32NotFound
+	}
+	if err := authorizeESP32View(ctx, uc.deviceAccessRepository, esp32ID, actor); err != nil {
+		return nil, err
+	}
+
+	counts, err := uc.alertRepository.CountAlertsByESP32NumeroSerie(ctx, numeroSerie, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read one more alert than requested to know whether there is a next page
+	pageFilter := filter
+	pageFilter.Limit++
+	alerts, err := uc.alertRepository.GetAlertsByESP32NumeroSerie(ctx, numeroSerie, pageFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	return newAlertPage(alerts, counts, filter), nil
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/alerts/domain/repositories"
+	esp32Services "hex_go/src/esp32/application/services"
+)
+
+// GetESP32AlertsUseCase handles browsing the alert history of an ESP32
+type GetESP32AlertsUseCase struct {
+	alertRepository        repositories.AlertRepository
+	deviceAccessRepository repositories.DeviceAccessRepository
+}
+
+// NewGetESP32AlertsUseCase creates a new instance of GetESP32AlertsUseCase
+func NewGetESP32AlertsUseCase(alertRepository repositories.AlertRepository, deviceAccessRepository repositories.DeviceAccessRepository) *GetESP32AlertsUseCase {
+	return &GetESP32AlertsUseCase{
+		alertRepository:        alertRepository,
+		deviceAccessRepository: deviceAccessRepository,
+	}
+}
+
+// Execute gets a page of the alerts of the ESP32 matching the query if the actor owns it or has shared access to it
+func (uc *GetESP32AlertsUseCase) Execute(ctx context.Context, esp32ID int, actor esp32Services.Actor, query AlertQuery) (*AlertPage, error) {
+	filter, err := query.filter()
+	if err != nil {
+		return nil, err
+	}
+	filter.ESP32ID = nil
+
+	if err := authorizeESP32View(ctx, uc.deviceAccessRepository, esp32ID, actor); err != nil {
+		return nil, err
+	}
+
+	counts, err := uc.alertRepository.CountAlertsByESP32ID(ctx, esp32ID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read one more alert than requested to know whether there is a next page
+	pageFilter := filter
+	pageFilter.Limit++
+	alerts, err := uc.alertRepository.GetAlertsByESP32ID(ctx, esp32ID, pageFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	return newAlertPage(alerts, counts, filter), nil
+}
@@ -2,23 +2,40 @@ package services
 
 import (
 	"context"
+
 	"hex_go/src/alerts/domain/entities"
 	"hex_go/src/alerts/domain/repositories"
 )
 
 // GetUserAlertsUseCase handles getting alerts for a user
 type GetUserAlertsUseCase struct {
-	alertRepository repositories.AlertRepository
+	alertRepository        repositories.AlertRepository
+	deviceAccessRepository repositories.DeviceAccessRepository
 }
 
 // NewGetUserAlertsUseCase creates a new instance of GetUserAlertsUseCase
-func NewGetUserAlertsUseCase(alertRepository repositories.AlertRepository) *GetUserAlertsUseCase {
+func NewGetUserAlertsUseCase(alertRepository repositories.AlertRepository, deviceAccessRepository repositories.DeviceAccessRepository) *GetUserAlertsUseCase {
 	return &GetUserAlertsUseCase{
-		alertRepository: alertRepository,
+		alertRepository:        alertRepository,
+		deviceAccessRepository: deviceAccessRepository,
 	}
 }
 
-// Execute gets all alerts for a user
-func (uc *GetUserAlertsUseCase) Execute(ctx context.Context, userID int) ([]*entities.Alert, error) {
-	return uc.alertRepository.GetAlertsByUserID(ctx, userID)
-}
\ No newline at end of file
+// Execute gets the alerts in any of the given states of the ESP32s the user owns or sees through
+// a group; no states means every state
+func (uc *GetUserAlertsUseCase) Execute(ctx context.Context, userID int, states []entities.AlertState) ([]*entities.Alert, error) {
+	scope, err := userAlertScope(ctx, uc.deviceAccessRepository, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	alerts, err := uc.alertRepository.GetAlertsByScope(ctx, scope, repositories.AlertFilter{States: states})
+	if err != nil {
+		return nil, err
+	}
+	if alerts == nil {
+		alerts = []*entities.Alert{}
+	}
+
+	return alerts, nil
+}
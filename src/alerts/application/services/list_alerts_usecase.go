@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/alerts/domain/repositories"
+)
+
+// ListAlertsUseCase handles browsing the alert history of a user
+type ListAlertsUseCase struct {
+	alertRepository        repositories.AlertRepository
+	deviceAccessRepository repositories.DeviceAccessRepository
+}
+
+// NewListAlertsUseCase creates a new instance of ListAlertsUseCase
+func NewListAlertsUseCase(alertRepository repositories.AlertRepository, deviceAccessRepository repositories.DeviceAccessRepository) *ListAlertsUseCase {
+	return &ListAlertsUseCase{
+		alertRepository:        alertRepository,
+		deviceAccessRepository: deviceAccessRepository,
+	}
+}
+
+// Execute gets a page of the alerts matching the query of the ESP32s the user owns or sees
+// through a group, most recent first
+func (uc *ListAlertsUseCase) Execute(ctx context.Context, userID int, query AlertQuery) (*AlertPage, error) {
+	filter, err := query.filter()
+	if err != nil {
+		return nil, err
+	}
+	scope, err := userAlertScope(ctx, uc.deviceAccessRepository, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := uc.alertRepository.CountAlertsByScope(ctx, scope, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read one more alert than requested to know whether there is a next page
+	pageFilter := filter
+	pageFilter.Limit++
+	alerts, err := uc.alertRepository.GetAlertsByScope(ctx, scope, pageFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	return newAlertPage(alerts, counts, filter), nil
+}
+
+// userAlertScope returns the ESP32s whose alerts the user lists: the owned ones and, as in the
+// device authorization, the ones in the groups where the user is a manager or a viewer
+func userAlertScope(ctx context.Context, accessRepo repositories.DeviceAccessRepository, userID int) (repositories.AlertScope, error) {
+	groupIDs, err := accessRepo.FindGroupIDs(ctx, userID)
+	if err != nil {
+		return repositories.AlertScope{}, err
+	}
+	return repositories.AlertScope{UserID: userID, GroupIDs: groupIDs}, nil
+}
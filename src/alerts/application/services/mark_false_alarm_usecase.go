@@ -0,0 +1,29 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/alerts/domain/entities"
+	"hex_go/src/alerts/domain/repositories"
+	esp32Services "hex_go/src/esp32/application/services"
+)
+
+// MarkFalseAlarmUseCase closes an alert that was not caused by a real fire or fault
+type MarkFalseAlarmUseCase struct {
+	lifecycle alertLifecycle
+}
+
+// NewMarkFalseAlarmUseCase creates a new instance of MarkFalseAlarmUseCase
+func NewMarkFalseAlarmUseCase(alertRepository repositories.AlertRepository, deviceAccessRepository repositories.DeviceAccessRepository) *MarkFalseAlarmUseCase {
+	return &MarkFalseAlarmUseCase{
+		lifecycle: alertLifecycle{
+			alertRepository:        alertRepository,
+			deviceAccessRepository: deviceAccessRepository,
+		},
+	}
+}
+
+// Execute runs the use case
+func (uc *MarkFalseAlarmUseCase) Execute(ctx context.Context, alertID int, actor esp32Services.Actor, input AlertTransitionInput) (*entities.Alert, error) {
+	return uc.lifecycle.transition(ctx, alertID, actor, entities.AlertFalseAlarm, input)
+}
@@ -0,0 +1,29 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/alerts/domain/entities"
+	"hex_go/src/alerts/domain/repositories"
+	esp32Services "hex_go/src/esp32/application/services"
+)
+
+// MuteAlertUseCase mutes an alert, hiding it from the default listings
+type MuteAlertUseCase struct {
+	lifecycle alertLifecycle
+}
+
+// NewMuteAlertUseCase creates a new instance of MuteAlertUseCase
+func NewMuteAlertUseCase(alertRepository repositories.AlertRepository, deviceAccessRepository repositories.DeviceAccessRepository) *MuteAlertUseCase {
+	return &MuteAlertUseCase{
+		lifecycle: alertLifecycle{
+			alertRepository:        alertRepository,
+			deviceAccessRepository: deviceAccessRepository,
+		},
+	}
+}
+
+// Execute runs the use case
+func (uc *MuteAlertUseCase) Execute(ctx context.Context, alertID int, actor esp32Services.Actor, input AlertTransitionInput) (*entities.Alert, error) {
+	return uc.lifecycle.transition(ctx, alertID, actor, entities.AlertMuted, input)
+}
@@ -0,0 +1,29 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/alerts/domain/entities"
+	"hex_go/src/alerts/domain/repositories"
+	esp32Services "hex_go/src/esp32/application/services"
+)
+
+// ResolveAlertUseCase resolves an alert once its cause was dealt with
+type ResolveAlertUseCase struct {
+	lifecycle alertLifecycle
+}
+
+// NewResolveAlertUseCase creates a new instance of ResolveAlertUseCase
+func NewResolveAlertUseCase(alertRepository repositories.AlertRepository, deviceAccessRepository repositories.DeviceAccessRepository) *ResolveAlertUseCase {
+	return &ResolveAlertUseCase{
+		lifecycle: alertLifecycle{
+			alertRepository:        alertRepository,
+			deviceAccessRepository: deviceAccessRepository,
+		},
+	}
+}
+
+// Execute runs the use case
+func (uc *ResolveAlertUseCase) Execute(ctx context.Context, alertID int, actor esp32Services.Actor, input AlertTransitionInput) (*entities.Alert, error) {
+	return uc.lifecycle.transition(ctx, alertID, actor, entities.AlertResolved, input)
+}
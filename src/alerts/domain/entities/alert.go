@@ -1,8 +1,12 @@
 package entities
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
-// AlertType represents the type of sensor that triggered the alert
+// AlertType represents the type of sensor that triggered the alert.
+// Sensor alerts use the code of the sensor type registry, so any registered type may appear.
 type AlertType string
 
 const (
@@ -10,16 +14,141 @@ const (
 	AlertTypeMQ2   AlertType = "MQ_2"
 	AlertTypeMQ135 AlertType = "MQ_135"
 	AlertTypeDHT22 AlertType = "DHT_22"
+	// AlertTypeDeviceOffline is raised when an ESP32 stops sending heartbeats.
+	// For these alerts SensorID holds the ID of the connectivity event.
+	AlertTypeDeviceOffline AlertType = "DEVICE_OFFLINE"
+	// AlertTypeCalibrationOverdue is raised when a gas sensor has not been calibrated within
+	// the interval of its sensor type. FechaActivacion holds the date the calibration was due.
+	AlertTypeCalibrationOverdue AlertType = "CALIBRATION_OVERDUE"
+)
+
+// AlertState represents the lifecycle state of an alert
+type AlertState string
+
+const (
+	AlertTriggered    AlertState = "triggered"
+	AlertAcknowledged AlertState = "acknowledged" // Someone is looking into it
+	AlertMuted        AlertState = "muted"        // Hidden from the default listings, optionally until a given time
+	AlertResolved     AlertState = "resolved"
+	AlertFalseAlarm   AlertState = "false_alarm"
 )
 
+// alertTransitions lists the states each state can move to. Resolved and false alarm are final.
+var alertTransitions = map[AlertState][]AlertState{
+	AlertTriggered:    {AlertAcknowledged, AlertMuted, AlertResolved, AlertFalseAlarm},
+	AlertAcknowledged: {AlertMuted, AlertResolved, AlertFalseAlarm},
+	AlertMuted:        {AlertTriggered, AlertAcknowledged, AlertResolved, AlertFalseAlarm},
+}
+
+// OpenAlertStates are the states of alerts that still need attention
+var OpenAlertStates = []AlertState{AlertTriggered, AlertAcknowledged}
+
 // Alert represents a sensor alert
 type Alert struct {
-	ID              int       `json:"id"`
-	ESP32ID         int       `json:"esp32_id"`
+	ID               int       `json:"id"`
+	ESP32ID          int       `json:"esp32_id"`
 	ESP32NumeroSerie string    `json:"esp32_numero_serie"`
-	SensorID        int       `json:"sensor_id"`
-	SensorType      AlertType `json:"sensor_type"`
-	Estado          int       `json:"estado"`
-	FechaActivacion string    `json:"fecha_activacion"`
-	CreatedAt       time.Time `json:"created_at"`
-}
\ No newline at end of file
+	ESP32Nickname    string    `json:"esp32_nickname"`
+	Room             string    `json:"room"`
+	Address          string    `json:"address"`
+	Latitude         *float64  `json:"latitude"`
+	Longitude        *float64  `json:"longitude"`
+	SensorID         int       `json:"sensor_id"`
+	SensorType       AlertType `json:"sensor_type"`
+	SensorName       string    `json:"sensor_name"`
+	SensorLabel      string    `json:"sensor_label"`
+	Unit             string    `json:"unit"`
+	Estado           int       `json:"estado"` // 1 while the condition that raised the alert is still present
+	FechaActivacion  string    `json:"fecha_activacion"`
+	// Lifecycle of the alert; the transitions are kept in AlertTransition records
+	State          AlertState `json:"state"`
+	TriggeredAt    time.Time  `json:"triggered_at"`
+	ClearedAt      *time.Time `json:"cleared_at"` // When the condition went away
+	AcknowledgedAt *time.Time `json:"acknowledged_at"`
+	AcknowledgedBy *int       `json:"acknowledged_by"`
+	ResolvedAt     *time.Time `json:"resolved_at"` // Also set for false alarms
+	ResolvedBy     *int       `json:"resolved_by"` // Null when the alert was resolved because the condition cleared
+	MutedUntil     *time.Time `json:"muted_until"`
+	CreatedAt      time.Time  `json:"created_at"`
+	// When the current owner received the ESP32; alerts raised before belong to the previous owner
+	ESP32AssignedAt *time.Time `json:"-"`
+	// Transitions of the alert; only loaded when a single alert is requested
+	Transitions []*AlertTransition `json:"transitions,omitempty"`
+}
+
+// AlertTransition records who moved an alert from one state to another and when
+type AlertTransition struct {
+	ID        int        `json:"id"`
+	AlertID   int        `json:"alert_id"`
+	FromState AlertState `json:"from_state"`
+	ToState   AlertState `json:"to_state"`
+	ChangedBy *int       `json:"changed_by"` // Null for transitions made by the system
+	ChangedAt time.Time  `json:"changed_at"`
+	Note      string     `json:"note,omitempty"`
+}
+
+// Key identifies the occurrence of a condition. A sensor that goes back into alarm, a new
+// offline event or a new calibration due date is a different occurrence and a new alert.
+func (a *Alert) Key() string {
+	return fmt.Sprintf("%s/%d/%d/%d", a.SensorType, a.ESP32ID, a.SensorID, a.TriggeredAt.Unix())
+}
+
+// BelongsToCurrentOwner reports whether the alert was raised while the ESP32 had its current owner
+func (a *Alert) BelongsToCurrentOwner() bool {
+	return a.ESP32AssignedAt == nil || !a.TriggeredAt.Before(*a.ESP32AssignedAt)
+}
+
+// IsOpen reports whether the alert has not been resolved or dismissed as a false alarm
+func (a *Alert) IsOpen() bool {
+	return a.State != AlertResolved && a.State != AlertFalseAlarm
+}
+
+// CanTransition reports whether the alert may move to the given state
+func (a *Alert) CanTransition(to AlertState) bool {
+	for _, state := range alertTransitions[a.State] {
+		if state == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Transition moves the alert to the given state and returns the record of the change.
+// changedBy is nil for transitions made by the system.
+func (a *Alert) Transition(to AlertState, changedBy *int, at time.Time, note string) *AlertTransition {
+	transition := &AlertTransition{
+		AlertID:   a.ID,
+		FromState: a.State,
+		ToState:   to,
+		ChangedBy: changedBy,
+		ChangedAt: at,
+		Note:      note,
+	}
+
+	a.State = to
+	switch to {
+	case AlertAcknowledged:
+		if a.AcknowledgedAt == nil {
+			a.AcknowledgedAt = &at
+			a.AcknowledgedBy = changedBy
+		}
+		a.MutedUntil = nil
+	case AlertTriggered:
+		a.MutedUntil = nil
+	case AlertResolved, AlertFalseAlarm:
+		a.ResolvedAt = &at
+		a.ResolvedBy = changedBy
+		a.MutedUntil = nil
+	}
+
+	return transition
+}
+
+// IsValidAlertState reports whether the state is one of the supported alert states
+func IsValidAlertState(state AlertState) bool {
+	switch state {
+	case AlertTriggered, AlertAcknowledged, AlertMuted, AlertResolved, AlertFalseAlarm:
+		return true
+	}
+	return false
+}
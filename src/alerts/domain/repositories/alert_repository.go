@@ -2,12 +2,65 @@ package repositories
 
 import (
 	"context"
+	"errors"
+	"time"
+
 	"hex_go/src/alerts/domain/entities"
 )
 
+// ErrAlertStateConflict is returned when an alert changed state after it was read
+var ErrAlertStateConflict = errors.New("alert state changed, reload it and try again")
+
+// AlertFilter narrows down the alert listings
+type AlertFilter struct {
+	States     []entities.AlertState // Empty means every state
+	ESP32ID    *int
+	SensorType entities.AlertType
+	From       *time.Time   // Inclusive bound on the activation time
+	To         *time.Time   // Exclusive bound on the activation time
+	After      *AlertCursor // Only alerts listed after this position
+	Limit      int          // Zero means no limit
+}
+
+// AlertScope is the set of ESP32s whose alerts a user lists: the ones the user owns and the
+// ones that belong to the groups the user is a member of
+type AlertScope struct {
+	UserID   int
+	GroupIDs []int // Groups and subgroups the user may see through a membership
+}
+
+// AlertCursor is a position in the alert listings, which are ordered by activation time and ID, newest first
+type AlertCursor struct {
+	TriggeredAt time.Time
+	ID          int
+}
+
 // AlertRepository defines operations for alert data
 type AlertRepository interface {
-	GetAlertsByUserID(ctx context.Context, userID int) ([]*entities.Alert, error)
-	GetAlertsByESP32ID(ctx context.Context, esp32ID int) ([]*entities.Alert, error)
-	GetAlertsByESP32NumeroSerie(ctx context.Context, numeroSerie string) ([]*entities.Alert, error)
-}
\ No newline at end of file
+	// The Count methods group the alerts matching the filter by state, ignoring its states, cursor and limit
+	GetAlertsByScope(ctx context.Context, scope AlertScope, filter AlertFilter) ([]*entities.Alert, error)
+	CountAlertsByScope(ctx context.Context, scope AlertScope, filter AlertFilter) (map[entities.AlertState]int, error)
+	GetAlertsByESP32ID(ctx context.Context, esp32ID int, filter AlertFilter) ([]*entities.Alert, error)
+	CountAlertsByESP32ID(ctx context.Context, esp32ID int, filter AlertFilter) (map[entities.AlertState]int, error)
+	GetAlertsByESP32NumeroSerie(ctx context.Context, numeroSerie string, filter AlertFilter) ([]*entities.Alert, error)
+	CountAlertsByESP32NumeroSerie(ctx context.Context, numeroSerie string, filter AlertFilter) (map[entities.AlertState]int, error)
+	FindByID(ctx context.Context, id int) (*entities.Alert, error)
+	// FindTransitions returns the transitions of an alert, oldest first
+	FindTransitions(ctx context.Context, alertID int) ([]*entities.AlertTransition, error)
+	// FindActiveConditions returns the conditions currently present on every ESP32: sensors in alarm,
+	// offline devices and overdue calibrations. Sensor alarms that started and ended within one upload
+	// of readings are returned with ClearedAt set until their alert is stored as cleared.
+	// The returned alerts are not stored yet.
+	FindActiveConditions(ctx context.Context) ([]*entities.Alert, error)
+	// FindUncleared returns the stored alerts whose condition has not cleared yet, whatever their state
+	FindUncleared(ctx context.Context) ([]*entities.Alert, error)
+	// FindExpiredMutes returns the muted alerts whose mute ended before now
+	FindExpiredMutes(ctx context.Context, now time.Time) ([]*entities.Alert, error)
+	// Create stores a new triggered alert. It returns false when the occurrence already has an alert.
+	Create(ctx context.Context, alert *entities.Alert) (bool, error)
+	// SaveTransition stores the new state of the alert together with the transition in one
+	// transaction. It returns ErrAlertStateConflict if the alert is no longer in transition.FromState.
+	SaveTransition(ctx context.Context, alert *entities.Alert, transition *entities.AlertTransition) error
+	// MarkCleared records when the condition of the alert went away
+	MarkCleared(ctx context.Context, id int, at time.Time) error
+}
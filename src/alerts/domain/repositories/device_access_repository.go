@@ -0,0 +1,22 @@
+package repositories
+
+import "context"
+
+// DeviceAccess is the level of access a user has on an ESP32 and its alerts
+type DeviceAccess int
+
+const (
+	DeviceAccessNone    DeviceAccess = iota
+	DeviceAccessView                 // May see the alerts
+	DeviceAccessOperate              // May also acknowledge, resolve and mute them
+)
+
+// DeviceAccessRepository tells what a user may do with an ESP32
+type DeviceAccessRepository interface {
+	// AccessLevel returns the access of the user on the ESP32; found is false if the ESP32 does not exist
+	AccessLevel(ctx context.Context, esp32ID, userID int, role string) (access DeviceAccess, found bool, err error)
+	// FindGroupIDs returns the groups, and their subgroups, whose ESP32s the user may see as a member
+	FindGroupIDs(ctx context.Context, userID int) ([]int, error)
+	// FindESP32IDByNumeroSerie returns the ID of the ESP32 with the serial number; found is false if it does not exist
+	FindESP32IDByNumeroSerie(ctx context.Context, numeroSerie string) (id int, found bool, err error)
+}
@@ -1,25 +1,80 @@
 package controllers
 
 import (
+	"context"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"hex_go/src/alerts/application/services"
+	"hex_go/src/alerts/domain/entities"
+	esp32Services "hex_go/src/esp32/application/services"
 )
 
 // AlertController handles HTTP requests for alerts
 type AlertController struct {
-	getUserAlertsUseCase *services.GetUserAlertsUseCase
+	listAlertsUseCase             *services.ListAlertsUseCase
+	getUserAlertsUseCase          *services.GetUserAlertsUseCase
+	getESP32AlertsUseCase         *services.GetESP32AlertsUseCase
+	getESP32AlertsBySerialUseCase *services.GetESP32AlertsBySerialUseCase
+	getAlertUseCase               *services.GetAlertUseCase
+	acknowledgeAlertUseCase       *services.AcknowledgeAlertUseCase
+	resolveAlertUseCase           *services.ResolveAlertUseCase
+	muteAlertUseCase              *services.MuteAlertUseCase
+	markFalseAlarmUseCase         *services.MarkFalseAlarmUseCase
 }
 
 // NewAlertController creates a new instance of AlertController
-func NewAlertController(getUserAlertsUseCase *services.GetUserAlertsUseCase) *AlertController {
+func NewAlertController(
+	listAlertsUseCase *services.ListAlertsUseCase,
+	getUserAlertsUseCase *services.GetUserAlertsUseCase,
+	getESP32AlertsUseCase *services.GetESP32AlertsUseCase,
+	getESP32AlertsBySerialUseCase *services.GetESP32AlertsBySerialUseCase,
+	getAlertUseCase *services.GetAlertUseCase,
+	acknowledgeAlertUseCase *services.AcknowledgeAlertUseCase,
+	resolveAlertUseCase *services.ResolveAlertUseCase,
+	muteAlertUseCase *services.MuteAlertUseCase,
+	markFalseAlarmUseCase *services.MarkFalseAlarmUseCase,
+) *AlertController {
 	return &AlertController{
-		getUserAlertsUseCase: getUserAlertsUseCase,
+		listAlertsUseCase:             listAlertsUseCase,
+		getUserAlertsUseCase:          getUserAlertsUseCase,
+		getESP32AlertsUseCase:         getESP32AlertsUseCase,
+		getESP32AlertsBySerialUseCase: getESP32AlertsBySerialUseCase,
+		getAlertUseCase:               getAlertUseCase,
+		acknowledgeAlertUseCase:       acknowledgeAlertUseCase,
+		resolveAlertUseCase:           resolveAlertUseCase,
+		muteAlertUseCase:              muteAlertUseCase,
+		markFalseAlarmUseCase:         markFalseAlarmUseCase,
 	}
 }
 
-// GetUserAlerts handles the HTTP request to get all alerts for a user
+// ListAlerts handles the HTTP request to browse the alert history of the user.
+// It accepts the esp32_id, sensor_type, state, from, to (RFC3339), cursor and limit query parameters.
+func (c *AlertController) ListAlerts(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	var query services.AlertQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := c.listAlertsUseCase.Execute(ctx, actor.UserID, query)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+}
+
+// GetUserAlerts handles the HTTP request to get the alerts of a user.
+// The state query parameter takes a comma separated list of states or "all"; by default only open alerts are returned.
 func (c *AlertController) GetUserAlerts(ctx *gin.Context) {
 	// Get the user ID from the context (set by the authentication middleware)
 	userID, exists := ctx.Get("userID")
@@ -28,7 +83,13 @@ func (c *AlertController) GetUserAlerts(ctx *gin.Context) {
 		return
 	}
 
-	alerts, err := c.getUserAlertsUseCase.Execute(ctx, userID.(int))
+	states, err := services.ParseAlertStates(ctx.Query("state"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	alerts, err := c.getUserAlertsUseCase.Execute(ctx, userID.(int), states)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -37,6 +98,134 @@ func (c *AlertController) GetUserAlerts(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, alerts)
 }
 
+// GetESP32Alerts handles the HTTP request to browse the alert history of an ESP32.
+// It accepts the same query parameters as ListAlerts except esp32_id.
+func (c *AlertController) GetESP32Alerts(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	esp32ID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ESP32 ID"})
+		return
+	}
+
+	var query services.AlertQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := c.getESP32AlertsUseCase.Execute(ctx, esp32ID, actor, query)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+}
+
+// GetESP32AlertsBySerial handles the HTTP request to browse the alert history of an ESP32 by its serial number
+func (c *AlertController) GetESP32AlertsBySerial(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	var query services.AlertQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := c.getESP32AlertsBySerialUseCase.Execute(ctx, ctx.Param("numeroSerie"), actor, query)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+}
+
+// GetAlert handles the HTTP request to get an alert and its transitions
+func (c *AlertController) GetAlert(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	alertID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid alert ID"})
+		return
+	}
+
+	alert, err := c.getAlertUseCase.Execute(ctx, alertID, actor)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, alert)
+}
+
+// AcknowledgeAlert handles the HTTP request to acknowledge an alert
+func (c *AlertController) AcknowledgeAlert(ctx *gin.Context) {
+	c.transitionAlert(ctx, c.acknowledgeAlertUseCase.Execute)
+}
+
+// ResolveAlert handles the HTTP request to resolve an alert
+func (c *AlertController) ResolveAlert(ctx *gin.Context) {
+	c.transitionAlert(ctx, c.resolveAlertUseCase.Execute)
+}
+
+// MuteAlert handles the HTTP request to mute an alert, optionally until a given time
+func (c *AlertController) MuteAlert(ctx *gin.Context) {
+	c.transitionAlert(ctx, c.muteAlertUseCase.Execute)
+}
+
+// MarkFalseAlarm handles the HTTP request to close an alert as a false alarm
+func (c *AlertController) MarkFalseAlarm(ctx *gin.Context) {
+	c.transitionAlert(ctx, c.markFalseAlarmUseCase.Execute)
+}
+
+// transitionAlert reads the alert and the optional body of a state change and runs the given use case
+func (c *AlertController) transitionAlert(ctx *gin.Context, execute func(context.Context, int, esp32Services.Actor, services.AlertTransitionInput) (*entities.Alert, error)) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	alertID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid alert ID"})
+		return
+	}
+
+	// The body is optional: without it the state changes without a note
+	var req services.AlertTransitionInput
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	alert, err := execute(ctx, alertID, actor, req)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, alert)
+}
+
 // SetupRoutes configures the routes for the alert controller
 func (c *AlertController) SetupRoutes(router *gin.Engine, authMiddleware gin.HandlerFunc) {
 	api := router.Group("/api")
@@ -47,8 +236,22 @@ func (c *AlertController) SetupRoutes(router *gin.Engine, authMiddleware gin.Han
 			protected := alerts.Group("")
 			protected.Use(authMiddleware)
 			{
+				protected.GET("", c.ListAlerts)
 				protected.GET("/user", c.GetUserAlerts)
+				protected.GET("/:id", c.GetAlert)
+				protected.POST("/:id/acknowledge", c.AcknowledgeAlert)
+				protected.POST("/:id/resolve", c.ResolveAlert)
+				protected.POST("/:id/mute", c.MuteAlert)
+				protected.POST("/:id/false-alarm", c.MarkFalseAlarm)
 			}
 		}
+
+		// Alerts of a single ESP32, for its owner and the users it is shared with
+		esp32s := api.Group("/esp32s")
+		esp32s.Use(authMiddleware)
+		{
+			esp32s.GET("/:id/alerts", c.GetESP32Alerts)
+			esp32s.GET("/serial/:numeroSerie/alerts", c.GetESP32AlertsBySerial)
+		}
 	}
-}
\ No newline at end of file
+}
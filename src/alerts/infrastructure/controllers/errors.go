@@ -0,0 +1,39 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"hex_go/src/alerts/application/services"
+	"hex_go/src/alerts/domain/repositories"
+	esp32Services "hex_go/src/esp32/application/services"
+)
+
+// respondError maps the errors of the use cases to HTTP responses
+func respondError(ctx *gin.Context, err error) {
+	status := http.StatusBadRequest
+	switch {
+	case errors.Is(err, services.ErrAlertNotFound), errors.Is(err, esp32Services.ErrESP32NotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, services.ErrAlertForbidden), errors.Is(err, esp32Services.ErrESP32Forbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, services.ErrInvalidAlertTransition), errors.Is(err, repositories.ErrAlertStateConflict):
+		status = http.StatusConflict
+	}
+
+	ctx.JSON(status, gin.H{"error": err.Error()})
+}
+
+// actorFromContext returns the authenticated user stored by the authentication middleware
+func actorFromContext(ctx *gin.Context) (esp32Services.Actor, bool) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		return esp32Services.Actor{}, false
+	}
+
+	return esp32Services.Actor{
+		UserID: userID.(int),
+		Role:   ctx.GetString("role"),
+	}, true
+}
@@ -1,13 +1,18 @@
 package infrastructure
 
 import (
+	"context"
 	"database/sql"
 	"log"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"hex_go/src/alerts/application/services"
 	"hex_go/src/alerts/infrastructure/controllers"
 	"hex_go/src/alerts/infrastructure/repositories"
+	"hex_go/src/config"
+	esp32Services "hex_go/src/esp32/application/services"
+	esp32Repo "hex_go/src/esp32/infrastructure/repositories"
 	"hex_go/src/middleware" 
 )
 
@@ -15,18 +20,94 @@ import (
 func Init(router *gin.Engine, db *sql.DB) {
 	log.Println("Initializing alerts module...")
 
+	// Create the alert tables if they don't exist
+	createAlertTables(db)
+
 	// Initialize repositories
 	alertRepo := repositories.NewMySQLAlertRepository(db)
+	esp32Repository := esp32Repo.NewMySQLESP32Repository(db)
+	groupAuthorizer := esp32Services.NewGroupAuthorizer(esp32Repo.NewMySQLDeviceGroupRepository(db))
+	esp32Authorizer := esp32Services.NewESP32Authorizer(esp32Repository, groupAuthorizer)
+	deviceAccessRepo := repositories.NewESP32DeviceAccessRepository(esp32Authorizer, groupAuthorizer, esp32Repository)
 
 	// Initialize use cases
-	getUserAlertsUseCase := services.NewGetUserAlertsUseCase(alertRepo)
+	listAlertsUseCase := services.NewListAlertsUseCase(alertRepo, deviceAccessRepo)
+	getUserAlertsUseCase := services.NewGetUserAlertsUseCase(alertRepo, deviceAccessRepo)
+	getESP32AlertsUseCase := services.NewGetESP32AlertsUseCase(alertRepo, deviceAccessRepo)
+	getESP32AlertsBySerialUseCase := services.NewGetESP32AlertsBySerialUseCase(alertRepo, deviceAccessRepo)
+	getAlertUseCase := services.NewGetAlertUseCase(alertRepo, deviceAccessRepo)
+	acknowledgeAlertUseCase := services.NewAcknowledgeAlertUseCase(alertRepo, deviceAccessRepo)
+	resolveAlertUseCase := services.NewResolveAlertUseCase(alertRepo, deviceAccessRepo)
+	muteAlertUseCase := services.NewMuteAlertUseCase(alertRepo, deviceAccessRepo)
+	markFalseAlarmUseCase := services.NewMarkFalseAlarmUseCase(alertRepo, deviceAccessRepo)
 
 	// Initialize controllers
-	alertController := controllers.NewAlertController(getUserAlertsUseCase)
+	alertController := controllers.NewAlertController(
+		listAlertsUseCase,
+		getUserAlertsUseCase,
+		getESP32AlertsUseCase,
+		getESP32AlertsBySerialUseCase,
+		getAlertUseCase,
+		acknowledgeAlertUseCase,
+		resolveAlertUseCase,
+		muteAlertUseCase,
+		markFalseAlarmUseCase,
+	)
 
 	// Get auth middleware
 	authMiddleware := middleware.AuthMiddleware()
 
 	// Setup routes
 	alertController.SetupRoutes(router, authMiddleware)
-}
\ No newline at end of file
+
+	// Keep the stored alerts in sync with the sensors, devices and calibrations
+	alertTracker := services.NewAlertTracker(alertRepo)
+	go alertTracker.Run(context.Background(), config.GetDurationEnv("ALERT_SYNC_INTERVAL", 5*time.Second))
+}
+
+// createAlertTables creates the tables of alerts and their transitions if they don't exist.
+// source_id is the sensor for sensor and calibration alerts and the connectivity event for offline alerts.
+func createAlertTables(db *sql.DB) {
+	queries := []string{
+		`CREATE TABLE IF NOT EXISTS alerts (
+			idAlert INT AUTO_INCREMENT PRIMARY KEY,
+			idESP32 INT NOT NULL,
+			alert_type VARCHAR(30) NOT NULL,
+			source_id INT NOT NULL,
+			idSensor INT NULL,
+			state VARCHAR(20) NOT NULL,
+			triggered_at DATETIME NOT NULL,
+			cleared_at DATETIME NULL,
+			acknowledged_at DATETIME NULL,
+			acknowledged_by INT NULL,
+			resolved_at DATETIME NULL,
+			resolved_by INT NULL,
+			muted_until DATETIME NULL,
+			created_at DATETIME NOT NULL,
+			UNIQUE KEY uq_alerts_occurrence (idESP32, alert_type, source_id, triggered_at),
+			INDEX idx_alerts_esp32 (idESP32, triggered_at),
+			INDEX idx_alerts_state (state, cleared_at),
+			FOREIGN KEY (idESP32) REFERENCES esp32(idESP32) ON DELETE CASCADE,
+			FOREIGN KEY (acknowledged_by) REFERENCES users(id) ON DELETE SET NULL,
+			FOREIGN KEY (resolved_by) REFERENCES users(id) ON DELETE SET NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS alert_transitions (
+			idTransition INT AUTO_INCREMENT PRIMARY KEY,
+			idAlert INT NOT NULL,
+			from_state VARCHAR(20) NOT NULL,
+			to_state VARCHAR(20) NOT NULL,
+			changed_by INT NULL,
+			changed_at DATETIME NOT NULL,
+			note VARCHAR(255) NULL,
+			INDEX idx_alert_transitions_alert (idAlert, changed_at),
+			FOREIGN KEY (idAlert) REFERENCES alerts(idAlert) ON DELETE CASCADE,
+			FOREIGN KEY (changed_by) REFERENCES users(id) ON DELETE SET NULL
+		)`,
+	}
+
+	for _, query := range queries {
+		if _, err := db.Exec(query); err != nil {
+			log.Printf("Warning: Failed to create alert tables: %v", err)
+		}
+	}
+}
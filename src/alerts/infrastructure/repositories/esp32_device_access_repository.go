@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"hex_go/src/alerts/domain/repositories"
+	esp32Services "hex_go/src/esp32/application/services"
+	esp32Repositories "hex_go/src/esp32/domain/repositories"
+)
+
+// ESP32DeviceAccessRepository implements DeviceAccessRepository with the authorization rules of the ESP32 module:
+// owners, admins and group managers operate an ESP32 and group viewers may see it
+type ESP32DeviceAccessRepository struct {
+	authorizer      *esp32Services.ESP32Authorizer
+	groupAuthorizer *esp32Services.GroupAuthorizer
+	esp32Repository esp32Repositories.ESP32Repository
+}
+
+// NewESP32DeviceAccessRepository creates a new instance of ESP32DeviceAccessRepository
+func NewESP32DeviceAccessRepository(
+	authorizer *esp32Services.ESP32Authorizer,
+	groupAuthorizer *esp32Services.GroupAuthorizer,
+	esp32Repository esp32Repositories.ESP32Repository,
+) repositories.DeviceAccessRepository {
+	return &ESP32DeviceAccessRepository{
+		authorizer:      authorizer,
+		groupAuthorizer: groupAuthorizer,
+		esp32Repository: esp32Repository,
+	}
+}
+
+// AccessLevel returns the highest access the user has on the ESP32
+func (r *ESP32DeviceAccessRepository) AccessLevel(ctx context.Context, esp32ID, userID int, role string) (repositories.DeviceAccess, bool, error) {
+	actor := esp32Services.Actor{UserID: userID, Role: role}
+
+	_, err := r.authorizer.AuthorizeOperate(ctx, esp32ID, actor)
+	switch {
+	case err == nil:
+		return repositories.DeviceAccessOperate, true, nil
+	case errors.Is(err, esp32Services.ErrESP32NotFound):
+		return repositories.DeviceAccessNone, false, nil
+	case !errors.Is(err, esp32Services.ErrESP32Forbidden):
+		return repositories.DeviceAccessNone, false, err
+	}
+
+	_, err = r.authorizer.AuthorizeView(ctx, esp32ID, actor)
+	switch {
+	case err == nil:
+		return repositories.DeviceAccessView, true, nil
+	case errors.Is(err, esp32Services.ErrESP32Forbidden):
+		return repositories.DeviceAccessNone, true, nil
+	}
+	return repositories.DeviceAccessNone, false, err
+}
+
+// FindGroupIDs returns the groups the user is a member of with any role, together with their subgroups
+func (r *ESP32DeviceAccessRepository) FindGroupIDs(ctx context.Context, userID int) ([]int, error) {
+	return r.groupAuthorizer.MemberGroupIDs(ctx, userID)
+}
+
+// FindESP32IDByNumeroSerie looks up the ID of an ESP32 by its serial number
+func (r *ESP32DeviceAccessRepository) FindESP32IDByNumeroSerie(ctx context.Context, numeroSerie string) (int, bool, error) {
+	esp32, err := r.esp32Repository.FindByNumeroSerie(ctx, numeroSerie)
+	if err != nil || esp32 == nil {
+		return 0, false, err
+	}
+	return esp32.ID, true, nil
+}
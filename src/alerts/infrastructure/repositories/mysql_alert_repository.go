@@ -3,12 +3,25 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
 	"time"
-	
+
 	"hex_go/src/alerts/domain/entities"
 	"hex_go/src/alerts/domain/repositories"
 )
 
+// alertColumns are the columns read by every query on stored alerts
+const alertColumns = `a.idAlert, a.idESP32, e.numero_serie, e.nickname, e.room, e.address, e.latitude, e.longitude,
+	a.source_id, a.alert_type, t.name, s.label, t.unit, a.state, a.triggered_at, a.cleared_at,
+	a.acknowledged_at, a.acknowledged_by, a.resolved_at, a.resolved_by, a.muted_until, a.created_at, e.assigned_at`
+
+// alertJoins joins the device and, for sensor alerts, the sensor and its type
+const alertJoins = `FROM alerts a
+	JOIN esp32 e ON e.idESP32 = a.idESP32
+	LEFT JOIN device_sensors s ON s.idSensor = a.idSensor
+	LEFT JOIN sensor_types t ON t.code = s.sensor_type`
+
 // MySQLAlertRepository implements AlertRepository using MySQL
 type MySQLAlertRepository struct {
 	db *sql.DB
@@ -21,143 +34,120 @@ func NewMySQLAlertRepository(db *sql.DB) repositories.AlertRepository {
 	}
 }
 
-// GetAlertsByUserID retrieves all alerts for a specific user
-func (r *MySQLAlertRepository) GetAlertsByUserID(ctx context.Context, userID int) ([]*entities.Alert, error) {
-	query := `
-		SELECT 
-			s.idKY_026 as sensor_id, 
-			'KY_026' as sensor_type, 
-			s.estado, 
-			s.fecha_activacion, 
-			e.idESP32, 
-			e.numero_serie
-		FROM KY_026 s
-		JOIN ESP32 e ON s.idKY_026 = e.idKY_026
-		WHERE e.idUser = ? AND s.estado = 1
-		UNION
-		SELECT 
-			s.idMQ_2 as sensor_id, 
-			'MQ_2' as sensor_type, 
-			s.estado, 
-			s.fecha_activacion, 
-			e.idESP32, 
-			e.numero_serie
-		FROM MQ_2 s
-		JOIN ESP32 e ON s.idMQ_2 = e.idMQ_2
-		WHERE e.idUser = ? AND s.estado = 1
-		UNION
-		SELECT 
-			s.idMQ_135 as sensor_id, 
-			'MQ_135' as sensor_type, 
-			s.estado, 
-			s.fecha_activacion, 
-			e.idESP32, 
-			e.numero_serie
-		FROM MQ_135 s
-		JOIN ESP32 e ON s.idMQ_135 = e.idMQ_135
-		WHERE e.idUser = ? AND s.estado = 1
-		UNION
-		SELECT 
-			s.idDHT_22 as sensor_id, 
-			'DHT_22' as sensor_type, 
-			s.estado, 
-			s.fecha_activacion, 
-			e.idESP32, 
-			e.numero_serie
-		FROM DHT_22 s
-		JOIN ESP32 e ON s.idDHT_22 = e.idDHT_22
-		WHERE e.idUser = ? AND s.estado = 1
-		ORDER BY fecha_activacion DESC
-	`
-
-	rows, err := r.db.QueryContext(ctx, query, userID, userID, userID, userID)
+// Scopes of the alert listings. Alerts raised before the current owner received the ESP32
+// belong to the previous owner and are excluded.
+const (
+	currentOwnership = "(e.assigned_at IS NULL OR a.triggered_at >= e.assigned_at)"
+	esp32IDScope     = "e.idESP32 = ? AND " + currentOwnership
+	numeroSerieScope = "e.numero_serie = ? AND " + currentOwnership
+)
+
+// GetAlertsByScope retrieves the alerts of the ESP32s a user owns or sees through a group
+func (r *MySQLAlertRepository) GetAlertsByScope(ctx context.Context, scope repositories.AlertScope, filter repositories.AlertFilter) ([]*entities.Alert, error) {
+	where, args := userScope(scope)
+	return r.listAlerts(ctx, where, args, filter)
+}
+
+// CountAlertsByScope counts the alerts of the ESP32s a user owns or sees through a group by state
+func (r *MySQLAlertRepository) CountAlertsByScope(ctx context.Context, scope repositories.AlertScope, filter repositories.AlertFilter) (map[entities.AlertState]int, error) {
+	where, args := userScope(scope)
+	return r.countAlerts(ctx, where, args, filter)
+}
+
+// userScope builds the scope of the ESP32s owned by the user or installed in one of the user's groups
+func userScope(scope repositories.AlertScope) (string, []interface{}) {
+	where := "(e.idUser = ?"
+	args := []interface{}{scope.UserID}
+	if len(scope.GroupIDs) > 0 {
+		where += " OR e.idGroup IN (?" + strings.Repeat(", ?", len(scope.GroupIDs)-1) + ")"
+		for _, groupID := range scope.GroupIDs {
+			args = append(args, groupID)
+		}
+	}
+
+	return where + ") AND " + currentOwnership, args
+}
+
+// GetAlertsByESP32ID retrieves the alerts of a specific ESP32 by ID
+func (r *MySQLAlertRepository) GetAlertsByESP32ID(ctx context.Context, esp32ID int, filter repositories.AlertFilter) ([]*entities.Alert, error) {
+	return r.listAlerts(ctx, esp32IDScope, []interface{}{esp32ID}, filter)
+}
+
+// CountAlertsByESP32ID counts the alerts of a specific ESP32 by state
+func (r *MySQLAlertRepository) CountAlertsByESP32ID(ctx context.Context, esp32ID int, filter repositories.AlertFilter) (map[entities.AlertState]int, error) {
+	return r.countAlerts(ctx, esp32IDScope, []interface{}{esp32ID}, filter)
+}
+
+// GetAlertsByESP32NumeroSerie retrieves the alerts of a specific ESP32 by serial number
+func (r *MySQLAlertRepository) GetAlertsByESP32NumeroSerie(ctx context.Context, numeroSerie string, filter repositories.AlertFilter) ([]*entities.Alert, error) {
+	return r.listAlerts(ctx, numeroSerieScope, []interface{}{numeroSerie}, filter)
+}
+
+// CountAlertsByESP32NumeroSerie counts the alerts of a specific ESP32 by serial number and state
+func (r *MySQLAlertRepository) CountAlertsByESP32NumeroSerie(ctx context.Context, numeroSerie string, filter repositories.AlertFilter) (map[entities.AlertState]int, error) {
+	return r.countAlerts(ctx, numeroSerieScope, []interface{}{numeroSerie}, filter)
+}
+
+// FindByID retrieves a stored alert by ID
+func (r *MySQLAlertRepository) FindByID(ctx context.Context, id int) (*entities.Alert, error) {
+	query := `SELECT ` + alertColumns + ` ` + alertJoins + ` WHERE a.idAlert = ?`
+
+	alert, err := scanAlert(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // No error, just no alert found
+		}
+		return nil, err
+	}
+
+	return alert, nil
+}
+
+// FindTransitions retrieves the transitions of an alert, oldest first
+func (r *MySQLAlertRepository) FindTransitions(ctx context.Context, alertID int) ([]*entities.AlertTransition, error) {
+	query := `SELECT idTransition, idAlert, from_state, to_state, changed_by, changed_at, note
+		FROM alert_transitions WHERE idAlert = ? ORDER BY changed_at, idTransition`
+
+	rows, err := r.db.QueryContext(ctx, query, alertID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var alerts []*entities.Alert
+	var transitions []*entities.AlertTransition
 
 	for rows.Next() {
-		var alert entities.Alert
-		var sensorType string
+		var transition entities.AlertTransition
+		var fromState, toState string
+		var changedBy sql.NullInt64
+		var note sql.NullString
 
-		err := rows.Scan(
-			&alert.SensorID,
-			&sensorType,
-			&alert.Estado,
-			&alert.FechaActivacion,
-			&alert.ESP32ID,
-			&alert.ESP32NumeroSerie,
-		)
-
-		if err != nil {
+		if err := rows.Scan(&transition.ID, &transition.AlertID, &fromState, &toState, &changedBy, &transition.ChangedAt, &note); err != nil {
 			return nil, err
 		}
 
-		alert.SensorType = entities.AlertType(sensorType)
-		alert.CreatedAt = time.Now() // Since we don't have this in the database
+		transition.FromState = entities.AlertState(fromState)
+		transition.ToState = entities.AlertState(toState)
+		if changedBy.Valid {
+			userID := int(changedBy.Int64)
+			transition.ChangedBy = &userID
+		}
+		transition.Note = note.String
 
-		alerts = append(alerts, &alert)
+		transitions = append(transitions, &transition)
 	}
 
 	if err = rows.Err(); err != nil {
 		return nil, err
 	}
 
-	return alerts, nil
+	return transitions, nil
 }
 
-// GetAlertsByESP32ID retrieves all alerts for a specific ESP32 by ID
-func (r *MySQLAlertRepository) GetAlertsByESP32ID(ctx context.Context, esp32ID int) ([]*entities.Alert, error) {
-	query := `
-		SELECT 
-			s.idKY_026 as sensor_id, 
-			'KY_026' as sensor_type, 
-			s.estado, 
-			s.fecha_activacion, 
-			e.idESP32, 
-			e.numero_serie
-		FROM KY_026 s
-		JOIN ESP32 e ON s.idKY_026 = e.idKY_026
-		WHERE e.idESP32 = ? AND s.estado = 1
-		UNION
-		SELECT 
-			s.idMQ_2 as sensor_id, 
-			'MQ_2' as sensor_type, 
-			s.estado, 
-			s.fecha_activacion, 
-			e.idESP32, 
-			e.numero_serie
-		FROM MQ_2 s
-		JOIN ESP32 e ON s.idMQ_2 = e.idMQ_2
-		WHERE e.idESP32 = ? AND s.estado = 1
-		UNION
-		SELECT 
-			s.idMQ_135 as sensor_id, 
-			'MQ_135' as sensor_type, 
-			s.estado, 
-			s.fecha_activacion, 
-			e.idESP32, 
-			e.numero_serie
-		FROM MQ_135 s
-		JOIN ESP32 e ON s.idMQ_135 = e.idMQ_135
-		WHERE e.idESP32 = ? AND s.estado = 1
-		UNION
-		SELECT 
-			s.idDHT_22 as sensor_id, 
-			'DHT_22' as sensor_type, 
-			s.estado, 
-			s.fecha_activacion, 
-			e.idESP32, 
-			e.numero_serie
-		FROM DHT_22 s
-		JOIN ESP32 e ON s.idDHT_22 = e.idDHT_22
-		WHERE e.idESP32 = ? AND s.estado = 1
-		ORDER BY fecha_activacion DESC
-	`
-
-	rows, err := r.db.QueryContext(ctx, query, esp32ID, esp32ID, esp32ID, esp32ID)
+// FindActiveConditions retrieves the conditions currently present on every ESP32 and the
+// sensor alarms that ended before they were stored as alerts
+func (r *MySQLAlertRepository) FindActiveConditions(ctx context.Context) ([]*entities.Alert, error) {
+	rows, err := r.db.QueryContext(ctx, buildConditionsQuery())
 	if err != nil {
 		return nil, err
 	}
@@ -168,14 +158,32 @@ func (r *MySQLAlertRepository) GetAlertsByESP32ID(ctx context.Context, esp32ID i
 	for rows.Next() {
 		var alert entities.Alert
 		var sensorType string
+		var sensorName sql.NullString
+		var sensorLabel sql.NullString
+		var unit sql.NullString
+		var nickname sql.NullString
+		var room sql.NullString
+		var address sql.NullString
+		var latitude sql.NullFloat64
+		var longitude sql.NullFloat64
+		var clearedAt sql.NullTime
 
 		err := rows.Scan(
 			&alert.SensorID,
 			&sensorType,
+			&sensorName,
+			&sensorLabel,
+			&unit,
 			&alert.Estado,
-			&alert.FechaActivacion,
+			&alert.TriggeredAt,
 			&alert.ESP32ID,
 			&alert.ESP32NumeroSerie,
+			&nickname,
+			&room,
+			&address,
+			&latitude,
+			&longitude,
+			&clearedAt,
 		)
 
 		if err != nil {
@@ -183,7 +191,23 @@ func (r *MySQLAlertRepository) GetAlertsByESP32ID(ctx context.Context, esp32ID i
 		}
 
 		alert.SensorType = entities.AlertType(sensorType)
-		alert.CreatedAt = time.Now() // Since we don't have this in the database
+		alert.SensorName = sensorName.String
+		alert.SensorLabel = sensorLabel.String
+		alert.Unit = unit.String
+		alert.ESP32Nickname = nickname.String
+		alert.Room = room.String
+		alert.Address = address.String
+		if latitude.Valid {
+			alert.Latitude = &latitude.Float64
+		}
+		if longitude.Valid {
+			alert.Longitude = &longitude.Float64
+		}
+		if clearedAt.Valid {
+			alert.ClearedAt = &clearedAt.Time
+		}
+		alert.State = entities.AlertTriggered
+		alert.FechaActivacion = alert.TriggeredAt.Format(time.RFC3339)
 
 		alerts = append(alerts, &alert)
 	}
@@ -195,84 +219,192 @@ func (r *MySQLAlertRepository) GetAlertsByESP32ID(ctx context.Context, esp32ID i
 	return alerts, nil
 }
 
-// GetAlertsByESP32NumeroSerie retrieves all alerts for a specific ESP32 by serial number
-func (r *MySQLAlertRepository) GetAlertsByESP32NumeroSerie(ctx context.Context, numeroSerie string) ([]*entities.Alert, error) {
-	query := `
-		SELECT 
-			s.idKY_026 as sensor_id, 
-			'KY_026' as sensor_type, 
-			s.estado, 
-			s.fecha_activacion, 
-			e.idESP32, 
-			e.numero_serie
-		FROM KY_026 s
-		JOIN ESP32 e ON s.idKY_026 = e.idKY_026
-		WHERE e.numero_serie = ? AND s.estado = 1
-		UNION
-		SELECT 
-			s.idMQ_2 as sensor_id, 
-			'MQ_2' as sensor_type, 
-			s.estado, 
-			s.fecha_activacion, 
-			e.idESP32, 
-			e.numero_serie
-		FROM MQ_2 s
-		JOIN ESP32 e ON s.idMQ_2 = e.idMQ_2
-		WHERE e.numero_serie = ? AND s.estado = 1
-		UNION
-		SELECT 
-			s.idMQ_135 as sensor_id, 
-			'MQ_135' as sensor_type, 
-			s.estado, 
-			s.fecha_activacion, 
-			e.idESP32, 
-			e.numero_serie
-		FROM MQ_135 s
-		JOIN ESP32 e ON s.idMQ_135 = e.idMQ_135
-		WHERE e.numero_serie = ? AND s.estado = 1
-		UNION
-		SELECT 
-			s.idDHT_22 as sensor_id, 
-			'DHT_22' as sensor_type, 
-			s.estado, 
-			s.fecha_activacion, 
-			e.idESP32, 
-			e.numero_serie
-		FROM DHT_22 s
-		JOIN ESP32 e ON s.idDHT_22 = e.idDHT_22
-		WHERE e.numero_serie = ? AND s.estado = 1
-		ORDER BY fecha_activacion DESC
-	`
-
-	rows, err := r.db.QueryContext(ctx, query, numeroSerie, numeroSerie, numeroSerie, numeroSerie)
+// FindUncleared retrieves the stored alerts whose condition has not cleared yet
+func (r *MySQLAlertRepository) FindUncleared(ctx context.Context) ([]*entities.Alert, error) {
+	query := `SELECT ` + alertColumns + ` ` + alertJoins + ` WHERE a.cleared_at IS NULL`
+
+	return r.findMany(ctx, query)
+}
+
+// FindExpiredMutes retrieves the muted alerts whose mute ended before now
+func (r *MySQLAlertRepository) FindExpiredMutes(ctx context.Context, now time.Time) ([]*entities.Alert, error) {
+	query := `SELECT ` + alertColumns + ` ` + alertJoins + `
+		WHERE a.state = 'muted' AND a.muted_until IS NOT NULL AND a.muted_until <= ?`
+
+	return r.findMany(ctx, query, now)
+}
+
+// Create stores a new alert. The unique key on the occurrence makes concurrent trackers store it only once.
+func (r *MySQLAlertRepository) Create(ctx context.Context, alert *entities.Alert) (bool, error) {
+	// Offline alerts point to a connectivity event, every other alert to a sensor
+	var sensorID *int
+	if alert.SensorType != entities.AlertTypeDeviceOffline {
+		sensorID = &alert.SensorID
+	}
+
+	query := `INSERT IGNORE INTO alerts (idESP32, alert_type, source_id, idSensor, state, triggered_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	result, err := r.db.ExecContext(ctx, query, alert.ESP32ID, string(alert.SensorType), alert.SensorID, sensorID,
+		string(alert.State), alert.TriggeredAt, alert.CreatedAt)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil || affected == 0 {
+		return false, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return false, err
+	}
+	alert.ID = int(id)
+
+	return true, nil
+}
+
+// SaveTransition stores the new state of an alert and its transition in a transaction
+func (r *MySQLAlertRepository) SaveTransition(ctx context.Context, alert *entities.Alert, transition *entities.AlertTransition) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// The state is only changed if nobody changed it since the alert was read
+	result, err := tx.ExecContext(ctx, `UPDATE alerts
+		SET state = ?, acknowledged_at = ?, acknowledged_by = ?, resolved_at = ?, resolved_by = ?, muted_until = ?
+		WHERE idAlert = ? AND state = ?`,
+		string(alert.State), alert.AcknowledgedAt, alert.AcknowledgedBy, alert.ResolvedAt, alert.ResolvedBy,
+		alert.MutedUntil, alert.ID, string(transition.FromState))
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return repositories.ErrAlertStateConflict
+	}
+
+	result, err = tx.ExecContext(ctx, `INSERT INTO alert_transitions (idAlert, from_state, to_state, changed_by, changed_at, note)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		transition.AlertID, string(transition.FromState), string(transition.ToState), transition.ChangedBy,
+		transition.ChangedAt, nullableString(transition.Note))
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	transition.ID = int(id)
+
+	return tx.Commit()
+}
+
+// MarkCleared records when the condition of an alert went away
+func (r *MySQLAlertRepository) MarkCleared(ctx context.Context, id int, at time.Time) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE alerts SET cleared_at = ? WHERE idAlert = ? AND cleared_at IS NULL`, at, id)
+	return err
+}
+
+// listAlerts retrieves the stored alerts in scope that match the filter, most recent first
+func (r *MySQLAlertRepository) listAlerts(ctx context.Context, scope string, scopeArgs []interface{}, filter repositories.AlertFilter) ([]*entities.Alert, error) {
+	where, args := filterConditions(scope, scopeArgs, filter)
+
+	if len(filter.States) > 0 {
+		where += ` AND a.state IN (?` + strings.Repeat(", ?", len(filter.States)-1) + `)`
+		for _, state := range filter.States {
+			args = append(args, string(state))
+		}
+	}
+	if filter.After != nil {
+		where += ` AND (a.triggered_at < ? OR (a.triggered_at = ? AND a.idAlert < ?))`
+		args = append(args, filter.After.TriggeredAt, filter.After.TriggeredAt, filter.After.ID)
+	}
+
+	query := `SELECT ` + alertColumns + ` ` + alertJoins + ` WHERE ` + where + ` ORDER BY a.triggered_at DESC, a.idAlert DESC`
+	if filter.Limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, filter.Limit)
+	}
+
+	return r.findMany(ctx, query, args...)
+}
+
+// countAlerts counts the stored alerts in scope that match the filter by state
+func (r *MySQLAlertRepository) countAlerts(ctx context.Context, scope string, scopeArgs []interface{}, filter repositories.AlertFilter) (map[entities.AlertState]int, error) {
+	where, args := filterConditions(scope, scopeArgs, filter)
+	query := `SELECT a.state, COUNT(*) FROM alerts a JOIN esp32 e ON e.idESP32 = a.idESP32 WHERE ` + where + ` GROUP BY a.state`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var alerts []*entities.Alert
-
+	counts := make(map[entities.AlertState]int)
 	for rows.Next() {
-		var alert entities.Alert
-		var sensorType string
+		var state string
+		var count int
+		if err := rows.Scan(&state, &count); err != nil {
+			return nil, err
+		}
+		counts[entities.AlertState(state)] = count
+	}
 
-		err := rows.Scan(
-			&alert.SensorID,
-			&sensorType,
-			&alert.Estado,
-			&alert.FechaActivacion,
-			&alert.ESP32ID,
-			&alert.ESP32NumeroSerie,
-		)
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// filterConditions builds the conditions shared by the listings and the counts: the scope plus
+// the device, type and time range of the filter
+func filterConditions(scope string, scopeArgs []interface{}, filter repositories.AlertFilter) (string, []interface{}) {
+	where := scope
+	args := append([]interface{}{}, scopeArgs...)
+
+	if filter.ESP32ID != nil {
+		where += ` AND a.idESP32 = ?`
+		args = append(args, *filter.ESP32ID)
+	}
+	if filter.SensorType != "" {
+		where += ` AND a.alert_type = ?`
+		args = append(args, string(filter.SensorType))
+	}
+	if filter.From != nil {
+		where += ` AND a.triggered_at >= ?`
+		args = append(args, *filter.From)
+	}
+	if filter.To != nil {
+		where += ` AND a.triggered_at < ?`
+		args = append(args, *filter.To)
+	}
+
+	return where, args
+}
+
+// findMany runs a query that returns a list of stored alerts
+func (r *MySQLAlertRepository) findMany(ctx context.Context, query string, args ...interface{}) ([]*entities.Alert, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var alerts []*entities.Alert
 
+	for rows.Next() {
+		alert, err := scanAlert(rows)
 		if err != nil {
 			return nil, err
 		}
 
-		alert.SensorType = entities.AlertType(sensorType)
-		alert.CreatedAt = time.Now() // Since we don't have this in the database
-
-		alerts = append(alerts, &alert)
+		alerts = append(alerts, alert)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -280,4 +412,221 @@ func (r *MySQLAlertRepository) GetAlertsByESP32NumeroSerie(ctx context.Context,
 	}
 
 	return alerts, nil
-}
\ No newline at end of file
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanAlert converts a row with the columns of alertColumns into an Alert entity
+func scanAlert(row rowScanner) (*entities.Alert, error) {
+	var alert entities.Alert
+	var sensorType, state string
+	var sensorName sql.NullString
+	var sensorLabel sql.NullString
+	var unit sql.NullString
+	var nickname sql.NullString
+	var room sql.NullString
+	var address sql.NullString
+	var latitude sql.NullFloat64
+	var longitude sql.NullFloat64
+	var clearedAt, acknowledgedAt, resolvedAt, mutedUntil, assignedAt sql.NullTime
+	var acknowledgedBy, resolvedBy sql.NullInt64
+
+	err := row.Scan(
+		&alert.ID,
+		&alert.ESP32ID,
+		&alert.ESP32NumeroSerie,
+		&nickname,
+		&room,
+		&address,
+		&latitude,
+		&longitude,
+		&alert.SensorID,
+		&sensorType,
+		&sensorName,
+		&sensorLabel,
+		&unit,
+		&state,
+		&alert.TriggeredAt,
+		&clearedAt,
+		&acknowledgedAt,
+		&acknowledgedBy,
+		&resolvedAt,
+		&resolvedBy,
+		&mutedUntil,
+		&alert.CreatedAt,
+		&assignedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	alert.SensorType = entities.AlertType(sensorType)
+	alert.SensorName = sensorName.String
+	alert.SensorLabel = sensorLabel.String
+	alert.Unit = unit.String
+	alert.ESP32Nickname = nickname.String
+	alert.Room = room.String
+	alert.Address = address.String
+	if latitude.Valid {
+		alert.Latitude = &latitude.Float64
+	}
+	if longitude.Valid {
+		alert.Longitude = &longitude.Float64
+	}
+	alert.State = entities.AlertState(state)
+	alert.FechaActivacion = alert.TriggeredAt.Format(time.RFC3339)
+	alert.Estado = 1
+	if clearedAt.Valid {
+		alert.ClearedAt = &clearedAt.Time
+		alert.Estado = 0
+	}
+	if acknowledgedAt.Valid {
+		alert.AcknowledgedAt = &acknowledgedAt.Time
+	}
+	if acknowledgedBy.Valid {
+		userID := int(acknowledgedBy.Int64)
+		alert.AcknowledgedBy = &userID
+	}
+	if resolvedAt.Valid {
+		alert.ResolvedAt = &resolvedAt.Time
+	}
+	if resolvedBy.Valid {
+		userID := int(resolvedBy.Int64)
+		alert.ResolvedBy = &userID
+	}
+	if mutedUntil.Valid {
+		alert.MutedUntil = &mutedUntil.Time
+	}
+	if assignedAt.Valid {
+		alert.ESP32AssignedAt = &assignedAt.Time
+	}
+
+	return &alert, nil
+}
+
+// nullableString stores empty strings as NULL
+func nullableString(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+// buildConditionsQuery builds the UNION of sensors in alarm, offline devices and overdue calibrations
+// of every ESP32 in service. Each row is one occurrence of a condition, identified by its type, device,
+// sensor or event and activation time. Sensors retired by a replacement no longer raise alerts.
+// Alarms that started and ended within one upload of readings are included with their end time
+// in cleared_at until their alert is stored as cleared.
+func buildConditionsQuery() string {
+	var branches []string
+
+	// Sensors migrated from the legacy tables may be in alarm without an activation date
+	branches = append(branches, `
+		SELECT 
+			s.idSensor as sensor_id, 
+			s.sensor_type, 
+			t.name as sensor_name,
+			s.label as sensor_label,
+			t.unit,
+			s.alarm as estado, 
+			COALESCE(s.fecha_activacion, s.installed_at) as fecha_activacion, 
+			e.idESP32, 
+			e.numero_serie,
+			e.nickname,
+			e.room,
+			e.address,
+			e.latitude,
+			e.longitude,
+			NULL as cleared_at
+		FROM device_sensors s
+		JOIN esp32 e ON s.idESP32 = e.idESP32
+		LEFT JOIN sensor_types t ON t.code = s.sensor_type
+		WHERE e.decommissioned_at IS NULL AND s.alarm = 1 AND s.retired_at IS NULL`)
+
+	// The current state of a sensor only keeps its last alarm, so the alarms that started and ended
+	// while the device was buffering readings are recorded as episodes by the telemetry module
+	branches = append(branches, `
+		SELECT 
+			ep.idSensor as sensor_id, 
+			s.sensor_type, 
+			t.name as sensor_name,
+			s.label as sensor_label,
+			t.unit,
+			0 as estado, 
+			ep.started_at as fecha_activacion, 
+			e.idESP32, 
+			e.numero_serie,
+			e.nickname,
+			e.room,
+			e.address,
+			e.latitude,
+			e.longitude,
+			ep.ended_at as cleared_at
+		FROM sensor_alarm_episodes ep
+		JOIN device_sensors s ON s.idSensor = ep.idSensor
+		JOIN esp32 e ON ep.idESP32 = e.idESP32
+		LEFT JOIN sensor_types t ON t.code = s.sensor_type
+		WHERE e.decommissioned_at IS NULL AND NOT EXISTS (
+			SELECT 1 FROM alerts a
+			WHERE a.idESP32 = ep.idESP32 AND a.alert_type = s.sensor_type AND a.source_id = ep.idSensor
+				AND a.triggered_at = ep.started_at AND a.cleared_at IS NOT NULL
+		)`)
+
+	// A device is reported as offline from the moment the checker marked it until its next heartbeat;
+	// decommissioned devices are offline for good and are not reported
+	branches = append(branches, fmt.Sprintf(`
+		SELECT 
+			ev.idEvent as sensor_id, 
+			'%s' as sensor_type, 
+			NULL as sensor_name,
+			NULL as sensor_label,
+			NULL as unit,
+			1 as estado, 
+			ev.created_at as fecha_activacion, 
+			e.idESP32, 
+			e.numero_serie,
+			e.nickname,
+			e.room,
+			e.address,
+			e.latitude,
+			e.longitude,
+			NULL as cleared_at
+		FROM esp32_events ev
+		JOIN esp32 e ON ev.idESP32 = e.idESP32
+		WHERE e.online = 0 AND e.decommissioned_at IS NULL
+			AND ev.event_type = 'offline' AND ev.created_at >= e.last_seen_at`,
+		entities.AlertTypeDeviceOffline))
+
+	// A sensor that was never calibrated is due one interval after it was installed
+	branches = append(branches, fmt.Sprintf(`
+		SELECT 
+			s.idSensor as sensor_id, 
+			'%s' as sensor_type, 
+			t.name as sensor_name,
+			s.label as sensor_label,
+			t.unit,
+			1 as estado, 
+			DATE_ADD(COALESCE(c.last_calibrated_at, s.installed_at), INTERVAL t.calibration_interval_days DAY) as fecha_activacion, 
+			e.idESP32, 
+			e.numero_serie,
+			e.nickname,
+			e.room,
+			e.address,
+			e.latitude,
+			e.longitude,
+			NULL as cleared_at
+		FROM device_sensors s
+		JOIN esp32 e ON s.idESP32 = e.idESP32
+		JOIN sensor_types t ON t.code = s.sensor_type
+		LEFT JOIN (
+			SELECT idSensor, MAX(calibrated_at) as last_calibrated_at FROM sensor_calibrations GROUP BY idSensor
+		) c ON c.idSensor = s.idSensor
+		WHERE e.decommissioned_at IS NULL AND s.retired_at IS NULL AND t.calibration_interval_days IS NOT NULL
+			AND DATE_ADD(COALESCE(c.last_calibrated_at, s.installed_at), INTERVAL t.calibration_interval_days DAY) <= NOW()`,
+		entities.AlertTypeCalibrationOverdue))
+
+	return strings.Join(branches, "\n\t\tUNION") + "\n\t\tORDER BY fecha_activacion\n\t"
+}
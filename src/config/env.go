@@ -0,0 +1,27 @@
+package config
+
+import (
+	"log"
+	"time"
+)
+
+// GetDurationEnv obtiene una duración (por ejemplo "5m") de una variable de entorno
+// o devuelve un valor por defecto si no está definida o no es válida
+func GetDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	value := getEnv(key, "")
+	if value == "" {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("Warning: invalid duration %q for %s, using %s", value, key, defaultValue)
+		return defaultValue
+	}
+	return duration
+}
+
+// GetEnv obtiene una variable de entorno o devuelve un valor por defecto
+func GetEnv(key, defaultValue string) string {
+	return getEnv(key, defaultValue)
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+)
+
+// EnsureColumn agrega una columna a una tabla existente si todavía no existe.
+// Se usa para migrar tablas creadas por versiones anteriores de la API.
+func EnsureColumn(db *sql.DB, table, column, definition string) {
+	exists, err := ColumnExists(db, table, column)
+	if err != nil {
+		log.Printf("Warning: Failed to inspect column %s.%s: %v", table, column, err)
+		return
+	}
+	if exists {
+		return
+	}
+
+	query := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition)
+	if _, err := db.Exec(query); err != nil {
+		log.Printf("Warning: Failed to add column %s.%s: %v", table, column, err)
+		return
+	}
+	log.Printf("Column %s.%s added successfully", table, column)
+}
+
+// EnsureIndex crea un índice en una tabla existente si todavía no existe.
+// definition es la parte de la sentencia que sigue a ADD, por ejemplo "UNIQUE KEY uq_x (a, b)".
+func EnsureIndex(db *sql.DB, table, index, definition string) {
+	var count int
+	err := db.QueryRow(`SELECT COUNT(*) FROM information_schema.STATISTICS
+		WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND INDEX_NAME = ?`, table, index).Scan(&count)
+	if err != nil {
+		log.Printf("Warning: Failed to inspect index %s.%s: %v", table, index, err)
+		return
+	}
+	if count > 0 {
+		return
+	}
+
+	query := fmt.Sprintf("ALTER TABLE %s ADD %s", table, definition)
+	if _, err := db.Exec(query); err != nil {
+		log.Printf("Warning: Failed to add index %s.%s: %v", table, index, err)
+		return
+	}
+	log.Printf("Index %s.%s added successfully", table, index)
+}
+
+// TableExists indica si la tabla existe en la base de datos actual
+func TableExists(db *sql.DB, table string) (bool, error) {
+	var count int
+	err := db.QueryRow(`SELECT COUNT(*) FROM information_schema.TABLES
+		WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?`, table).Scan(&count)
+	return count > 0, err
+}
+
+// ColumnExists indica si la columna existe en la tabla indicada
+func ColumnExists(db *sql.DB, table, column string) (bool, error) {
+	var count int
+	err := db.QueryRow(`SELECT COUNT(*) FROM information_schema.COLUMNS
+		WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND COLUMN_NAME = ?`, table, column).Scan(&count)
+	return count > 0, err
+}
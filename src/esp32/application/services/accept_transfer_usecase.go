@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+	userRepo "hex_go/src/users/domain/repositories"
+)
+
+// AcceptTransferUseCase implementa el caso de uso para que el destinatario acepte un ESP32
+type AcceptTransferUseCase struct {
+	transferRepository repositories.ESP32TransferRepository
+	userRepository     userRepo.UserRepository
+}
+
+// NewAcceptTransferUseCase crea una nueva instancia de AcceptTransferUseCase
+func NewAcceptTransferUseCase(transferRepo repositories.ESP32TransferRepository, userRepo userRepo.UserRepository) *AcceptTransferUseCase {
+	return &AcceptTransferUseCase{
+		transferRepository: transferRepo,
+		userRepository:     userRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *AcceptTransferUseCase) Execute(ctx context.Context, transferID int, actor Actor) (*entities.ESP32Transfer, error) {
+	transfer, err := findTransferForRecipient(ctx, uc.transferRepository, uc.userRepository, transferID, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reasignar el ESP32 y cerrar la transferencia de forma atómica
+	err = uc.transferRepository.Accept(ctx, transfer, actor.UserID)
+	if errors.Is(err, repositories.ErrTransferConflict) {
+		// El remitente ya no es el dueño; la transferencia queda anulada
+		if cancelErr := uc.transferRepository.UpdateStatus(ctx, transfer.ID, entities.TransferCancelled); cancelErr != nil {
+			return nil, cancelErr
+		}
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.transferRepository.FindByID(ctx, transfer.ID)
+}
+
+// findTransferForRecipient busca una transferencia pendiente dirigida al email del actor
+func findTransferForRecipient(ctx context.Context, transferRepo repositories.ESP32TransferRepository, userRepository userRepo.UserRepository, transferID int, actor Actor) (*entities.ESP32Transfer, error) {
+	transfer, err := transferRepo.FindByID(ctx, transferID)
+	if err != nil {
+		return nil, err
+	}
+	if transfer == nil {
+		return nil, ErrTransferNotFound
+	}
+
+	user, err := userRepository.FindByID(ctx, actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || !transfer.IsAddressedTo(user.Email) {
+		return nil, ErrTransferForbidden
+	}
+
+	if !transfer.IsPending() {
+		return nil, ErrTransferNotPending
+	}
+
+	return transfer, nil
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// AcknowledgeCommandUseCase implementa el caso de uso con el que el firmware informa
+// el resultado de un comando
+type AcknowledgeCommandUseCase struct {
+	deviceCommandRepository repositories.DeviceCommandRepository
+}
+
+// NewAcknowledgeCommandUseCase crea una nueva instancia de AcknowledgeCommandUseCase
+func NewAcknowledgeCommandUseCase(deviceCommandRepo repositories.DeviceCommandRepository) *AcknowledgeCommandUseCase {
+	return &AcknowledgeCommandUseCase{
+		deviceCommandRepository: deviceCommandRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *AcknowledgeCommandUseCase) Execute(ctx context.Context, esp32ID, commandID int, status entities.CommandStatus, result string) (*entities.DeviceCommand, error) {
+	if status != entities.CommandSucceeded && status != entities.CommandFailed {
+		return nil, errors.New("status must be succeeded or failed")
+	}
+	if runes := []rune(result); len(runes) > 255 {
+		result = string(runes[:255])
+	}
+
+	command, err := uc.deviceCommandRepository.FindByID(ctx, commandID)
+	if err != nil {
+		return nil, err
+	}
+	// Un ESP32 solo puede confirmar sus propios comandos
+	if command == nil || command.ESP32ID != esp32ID {
+		return nil, ErrCommandNotFound
+	}
+
+	now := time.Now()
+	completed, err := uc.deviceCommandRepository.Complete(ctx, commandID, status, result, now)
+	if err != nil {
+		return nil, err
+	}
+	if !completed {
+		return nil, ErrCommandNotOpen
+	}
+
+	command.Status = status
+	command.Result = result
+	command.CompletedAt = &now
+
+	return command, nil
+}
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// ErrUnknownConfigVersion se devuelve cuando el ESP32 confirma una versión que no fue publicada
+var ErrUnknownConfigVersion = errors.New("unknown configuration version")
+
+// AcknowledgeDeviceConfigUseCase implementa el caso de uso con el que el firmware confirma
+// qué versión de la configuración aplicó
+type AcknowledgeDeviceConfigUseCase struct {
+	deviceConfigRepository repositories.DeviceConfigRepository
+}
+
+// NewAcknowledgeDeviceConfigUseCase crea una nueva instancia de AcknowledgeDeviceConfigUseCase
+func NewAcknowledgeDeviceConfigUseCase(deviceConfigRepo repositories.DeviceConfigRepository) *AcknowledgeDeviceConfigUseCase {
+	return &AcknowledgeDeviceConfigUseCase{
+		deviceConfigRepository: deviceConfigRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *AcknowledgeDeviceConfigUseCase) Execute(ctx context.Context, esp32ID, version int) error {
+	config, err := uc.deviceConfigRepository.FindByESP32ID(ctx, esp32ID)
+	if err != nil {
+		return err
+	}
+
+	// La configuración por defecto (versión 0) no se guarda, no hay nada que registrar
+	if config == nil {
+		if version == 0 {
+			return nil
+		}
+		return ErrUnknownConfigVersion
+	}
+	if version < 0 || version > config.Version {
+		return ErrUnknownConfigVersion
+	}
+
+	return uc.deviceConfigRepository.MarkApplied(ctx, esp32ID, version, time.Now())
+}
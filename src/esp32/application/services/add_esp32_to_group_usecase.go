@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// AddESP32ToGroupUseCase implementa el caso de uso para agregar un ESP32 a un grupo
+type AddESP32ToGroupUseCase struct {
+	esp32Repository repositories.ESP32Repository
+	groupAuthorizer *GroupAuthorizer
+}
+
+// NewAddESP32ToGroupUseCase crea una nueva instancia de AddESP32ToGroupUseCase
+func NewAddESP32ToGroupUseCase(esp32Repo repositories.ESP32Repository, groupAuthorizer *GroupAuthorizer) *AddESP32ToGroupUseCase {
+	return &AddESP32ToGroupUseCase{
+		esp32Repository: esp32Repo,
+		groupAuthorizer: groupAuthorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso. Si el ESP32 ya estaba en otro grupo, lo mueve.
+// El ESP32 debe pertenecer al cliente dueño de la jerarquía.
+func (uc *AddESP32ToGroupUseCase) Execute(ctx context.Context, groupID, esp32ID int, actor Actor) error {
+	group, err := uc.groupAuthorizer.Authorize(ctx, groupID, actor, GroupAccessOwner)
+	if err != nil {
+		return err
+	}
+
+	esp32, err := uc.esp32Repository.FindByID(ctx, esp32ID)
+	if err != nil {
+		return err
+	}
+	if esp32 == nil {
+		return ErrESP32NotFound
+	}
+	if esp32.UserID == nil || *esp32.UserID != group.OwnerID {
+		return errors.New("the ESP32 must belong to the owner of the group")
+	}
+
+	return uc.esp32Repository.SetGroup(ctx, esp32.ID, group.OwnerID, &group.ID)
+}
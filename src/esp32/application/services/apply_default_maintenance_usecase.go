@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// ApplyDefaultMaintenanceUseCase implementa el caso de uso para programar en un ESP32 las tareas
+// habituales que todavía no tiene: prueba mensual, inspección anual y reemplazo de cada sensor
+type ApplyDefaultMaintenanceUseCase struct {
+	deviceSensorRepository repositories.DeviceSensorRepository
+	maintenanceRepository  repositories.MaintenanceRepository
+	authorizer             *ESP32Authorizer
+}
+
+// NewApplyDefaultMaintenanceUseCase crea una nueva instancia de ApplyDefaultMaintenanceUseCase
+func NewApplyDefaultMaintenanceUseCase(
+	deviceSensorRepo repositories.DeviceSensorRepository,
+	maintenanceRepo repositories.MaintenanceRepository,
+	authorizer *ESP32Authorizer,
+) *ApplyDefaultMaintenanceUseCase {
+	return &ApplyDefaultMaintenanceUseCase{
+		deviceSensorRepository: deviceSensorRepo,
+		maintenanceRepository:  maintenanceRepo,
+		authorizer:             authorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso y devuelve todas las tareas del ESP32
+func (uc *ApplyDefaultMaintenanceUseCase) Execute(ctx context.Context, esp32ID int, actor Actor) ([]*entities.MaintenanceSchedule, error) {
+	esp32, err := uc.authorizer.AuthorizeOperate(ctx, esp32ID, actor)
+	if err != nil {
+		return nil, err
+	}
+	if esp32.IsDecommissioned() {
+		return nil, ErrESP32Decommissioned
+	}
+
+	existing, err := uc.maintenanceRepository.FindSchedulesByESP32ID(ctx, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+	sensors, err := uc.deviceSensorRepository.FindByESP32ID(ctx, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var missing []*entities.MaintenanceSchedule
+	for _, task := range []entities.MaintenanceTask{entities.MaintenanceMonthlyTest, entities.MaintenanceAnnualInspection} {
+		if !hasSchedule(existing, task, nil) {
+			intervalDays := entities.DefaultMaintenanceIntervalDays(task)
+			missing = append(missing, entities.NewMaintenanceSchedule(esp32ID, nil, task, intervalDays, firstDueAt(task, nil, intervalDays, now)))
+		}
+	}
+	for _, sensor := range sensors {
+		sensorID := sensor.ID
+		if !hasSchedule(existing, entities.MaintenanceSensorReplacement, &sensorID) {
+			intervalDays := entities.DefaultMaintenanceIntervalDays(entities.MaintenanceSensorReplacement)
+			dueAt := firstDueAt(entities.MaintenanceSensorReplacement, sensor, intervalDays, now)
+			missing = append(missing, entities.NewMaintenanceSchedule(esp32ID, &sensorID, entities.MaintenanceSensorReplacement, intervalDays, dueAt))
+		}
+	}
+
+	for _, schedule := range missing {
+		if _, err := uc.maintenanceRepository.CreateSchedule(ctx, schedule); err != nil {
+			return nil, err
+		}
+	}
+
+	schedules, err := uc.maintenanceRepository.FindSchedulesByESP32ID(ctx, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+	if schedules == nil {
+		schedules = []*entities.MaintenanceSchedule{}
+	}
+
+	return schedules, nil
+}
@@ -8,29 +8,36 @@ import (
 	userRepo "hex_go/src/users/domain/repositories"
 )
 
+// ErrInvalidClaim se devuelve cuando el número de serie o el código de reclamo no son válidos.
+// No distingue entre ambos casos para no revelar qué números de serie existen.
+var ErrInvalidClaim = errors.New("invalid serial number or claim code")
+
 // AssignESP32UseCase implementa el caso de uso para asignar un ESP32 a un usuario
 type AssignESP32UseCase struct {
 	esp32Repository repositories.ESP32Repository
 	userRepository  userRepo.UserRepository
+	userThrottler   *ClaimThrottler[int]
+	serialThrottler *ClaimThrottler[string]
 }
 
 // NewAssignESP32UseCase crea una nueva instancia de AssignESP32UseCase
-func NewAssignESP32UseCase(esp32Repo repositories.ESP32Repository, userRepo userRepo.UserRepository) *AssignESP32UseCase {
+// Los intentos fallidos se limitan por usuario y también por número de serie, para que crear
+// cuentas nuevas no permita seguir probando códigos sobre el mismo ESP32.
+func NewAssignESP32UseCase(esp32Repo repositories.ESP32Repository, userRepo userRepo.UserRepository,
+	userThrottler *ClaimThrottler[int], serialThrottler *ClaimThrottler[string]) *AssignESP32UseCase {
 	return &AssignESP32UseCase{
 		esp32Repository: esp32Repo,
 		userRepository:  userRepo,
+		userThrottler:   userThrottler,
+		serialThrottler: serialThrottler,
 	}
 }
 
 // Execute ejecuta el caso de uso
-func (uc *AssignESP32UseCase) Execute(ctx context.Context, esp32ID, userID int) error {
-	// Verificar si el ESP32 existe
-	esp32, err := uc.esp32Repository.FindByID(ctx, esp32ID)
-	if err != nil {
-		return err
-	}
-	if esp32 == nil {
-		return errors.New("ESP32 not found")
+func (uc *AssignESP32UseCase) Execute(ctx context.Context, numeroSerie, claimCode string, userID int) error {
+	// Verificar si el usuario o el número de serie superaron el límite de intentos fallidos
+	if !uc.userThrottler.Allow(userID) || !uc.serialThrottler.Allow(numeroSerie) {
+		return ErrTooManyClaimAttempts
 	}
 
 	// Verificar si el usuario existe
@@ -42,11 +49,30 @@ func (uc *AssignESP32UseCase) Execute(ctx context.Context, esp32ID, userID int)
 		return errors.New("user not found")
 	}
 
-	// Verificar si el ESP32 ya está asignado a otro usuario
-	if esp32.UserID != nil && *esp32.UserID != userID {
-		return errors.New("ESP32 already assigned to another user")
+	// Buscar el ESP32 por número de serie
+	esp32, err := uc.esp32Repository.FindByNumeroSerie(ctx, numeroSerie)
+	if err != nil {
+		return err
+	}
+
+	// El ESP32 ya pertenece a este usuario, no hay nada que hacer
+	if esp32 != nil && esp32.UserID != nil && *esp32.UserID == userID {
+		return nil
+	}
+
+	// Verificar el código de reclamo; un ESP32 ya asignado o retirado no tiene código vigente
+	if esp32 == nil || esp32.UserID != nil || esp32.IsDecommissioned() || !esp32.ClaimCodeMatches(claimCode) {
+		uc.userThrottler.RegisterFailure(userID)
+		uc.serialThrottler.RegisterFailure(numeroSerie)
+		return ErrInvalidClaim
 	}
 
-	// Asignar el ESP32 al usuario
-	return uc.esp32Repository.AssignToUser(ctx, esp32ID, userID)
-}
\ No newline at end of file
+	// Asignar el ESP32 al usuario; falla si otro reclamo con el mismo código se adelantó
+	if err := uc.esp32Repository.AssignToUser(ctx, esp32.ID, userID); err != nil {
+		return err
+	}
+
+	uc.userThrottler.Reset(userID)
+	uc.serialThrottler.Reset(numeroSerie)
+	return nil
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// ErrInvalidDeviceCredentials se devuelve cuando el número de serie o el token del dispositivo no son válidos
+var ErrInvalidDeviceCredentials = errors.New("invalid device credentials")
+
+// AuthenticateDeviceUseCase implementa el caso de uso para autenticar el firmware de un ESP32
+type AuthenticateDeviceUseCase struct {
+	esp32Repository repositories.ESP32Repository
+}
+
+// NewAuthenticateDeviceUseCase crea una nueva instancia de AuthenticateDeviceUseCase
+func NewAuthenticateDeviceUseCase(esp32Repo repositories.ESP32Repository) *AuthenticateDeviceUseCase {
+	return &AuthenticateDeviceUseCase{
+		esp32Repository: esp32Repo,
+	}
+}
+
+// Execute ejecuta el caso de uso y devuelve el ESP32 autenticado
+func (uc *AuthenticateDeviceUseCase) Execute(ctx context.Context, numeroSerie, token string) (*entities.ESP32, error) {
+	if numeroSerie == "" || token == "" {
+		return nil, ErrInvalidDeviceCredentials
+	}
+
+	esp32, err := uc.esp32Repository.FindByNumeroSerie(ctx, numeroSerie)
+	if err != nil {
+		return nil, err
+	}
+	// Un ESP32 retirado no tiene token vigente, pero se rechaza también explícitamente
+	if esp32 == nil || esp32.IsDecommissioned() || !esp32.DeviceTokenMatches(token) {
+		return nil, ErrInvalidDeviceCredentials
+	}
+
+	return esp32, nil
+}
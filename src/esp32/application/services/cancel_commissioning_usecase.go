@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// CancelCommissioningUseCase implementa el caso de uso para cancelar una sesión de comisionamiento en curso
+type CancelCommissioningUseCase struct {
+	commissioningRepository repositories.CommissioningRepository
+	authorizer              *ESP32Authorizer
+}
+
+// NewCancelCommissioningUseCase crea una nueva instancia de CancelCommissioningUseCase
+func NewCancelCommissioningUseCase(commissioningRepo repositories.CommissioningRepository, authorizer *ESP32Authorizer) *CancelCommissioningUseCase {
+	return &CancelCommissioningUseCase{
+		commissioningRepository: commissioningRepo,
+		authorizer:              authorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *CancelCommissioningUseCase) Execute(ctx context.Context, sessionID int, actor Actor) (*entities.CommissioningSession, error) {
+	session, err := uc.commissioningRepository.FindByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, ErrCommissioningNotFound
+	}
+	if err := authorizeCommissioningSession(ctx, uc.authorizer, session, actor, true); err != nil {
+		return nil, err
+	}
+	if !session.IsOpen() {
+		return nil, ErrCommissioningClosed
+	}
+
+	now := time.Now()
+	session.Status = entities.CommissioningCancelled
+
+	closed, err := uc.commissioningRepository.Close(ctx, session, now)
+	if err != nil {
+		return nil, err
+	}
+	if !closed {
+		return nil, ErrCommissioningClosed
+	}
+	session.CompletedAt = &now
+
+	return session, nil
+}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// CancelTransferUseCase implementa el caso de uso para que el remitente retire una transferencia
+type CancelTransferUseCase struct {
+	transferRepository repositories.ESP32TransferRepository
+}
+
+// NewCancelTransferUseCase crea una nueva instancia de CancelTransferUseCase
+func NewCancelTransferUseCase(transferRepo repositories.ESP32TransferRepository) *CancelTransferUseCase {
+	return &CancelTransferUseCase{
+		transferRepository: transferRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *CancelTransferUseCase) Execute(ctx context.Context, transferID int, actor Actor) error {
+	transfer, err := uc.transferRepository.FindByID(ctx, transferID)
+	if err != nil {
+		return err
+	}
+	if transfer == nil {
+		return ErrTransferNotFound
+	}
+
+	if transfer.FromUserID != actor.UserID && !actor.IsAdmin() {
+		return ErrTransferForbidden
+	}
+	if !transfer.IsPending() {
+		return ErrTransferNotPending
+	}
+
+	return uc.transferRepository.UpdateStatus(ctx, transfer.ID, entities.TransferCancelled)
+}
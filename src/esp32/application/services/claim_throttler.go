@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrTooManyClaimAttempts se devuelve cuando un usuario o un número de serie superan el límite de reclamos fallidos
+var ErrTooManyClaimAttempts = errors.New("too many failed claim attempts, try again later")
+
+// ClaimThrottler limita los intentos fallidos de reclamo de ESP32 por clave, ya sea un usuario o un número de serie
+type ClaimThrottler[K comparable] struct {
+	mu          sync.Mutex
+	maxAttempts int
+	window      time.Duration
+	failures    map[K][]time.Time
+	now         func() time.Time
+}
+
+// NewClaimThrottler crea una nueva instancia de ClaimThrottler
+func NewClaimThrottler[K comparable](maxAttempts int, window time.Duration) *ClaimThrottler[K] {
+	return &ClaimThrottler[K]{
+		maxAttempts: maxAttempts,
+		window:      window,
+		failures:    make(map[K][]time.Time),
+		now:         time.Now,
+	}
+}
+
+// Allow indica si todavía se puede intentar un reclamo con la clave
+func (t *ClaimThrottler[K]) Allow(key K) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return len(t.recentFailures(key)) < t.maxAttempts
+}
+
+// RegisterFailure registra un intento de reclamo fallido
+func (t *ClaimThrottler[K]) RegisterFailure(key K) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.failures[key] = append(t.recentFailures(key), t.now())
+}
+
+// Reset olvida los intentos fallidos de la clave tras un reclamo exitoso
+func (t *ClaimThrottler[K]) Reset(key K) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.failures, key)
+}
+
+// Run barre periódicamente las claves vencidas hasta que se cancele el contexto.
+// Las claves solo se depuran al volver a usarse, y los números de serie los elige el cliente,
+// así que sin el barrido el mapa crecería sin límite con seriales que no se repiten.
+func (t *ClaimThrottler[K]) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			t.Sweep()
+		}
+	}
+}
+
+// Sweep olvida las claves cuyos intentos fallidos quedaron todos fuera de la ventana de tiempo
+func (t *ClaimThrottler[K]) Sweep() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for key := range t.failures {
+		t.recentFailures(key)
+	}
+}
+
+// recentFailures descarta los intentos que quedaron fuera de la ventana de tiempo
+func (t *ClaimThrottler[K]) recentFailures(key K) []time.Time {
+	cutoff := t.now().Add(-t.window)
+	recent := t.failures[key][:0]
+	for _, failedAt := range t.failures[key] {
+		if failedAt.After(cutoff) {
+			recent = append(recent, failedAt)
+		}
+	}
+
+	if len(recent) == 0 {
+		delete(t.failures, key)
+		return nil
+	}
+	t.failures[key] = recent
+	return recent
+}
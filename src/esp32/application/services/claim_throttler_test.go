@@ -0,0 +1,40 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClaimThrottlerSweepEvictsExpiredKeys(t *testing.T) {
+	now := time.Date(2026, 3, 14, 2, 0, 0, 0, time.UTC)
+	throttler := NewClaimThrottler[string](2, 15*time.Minute)
+	throttler.now = func() time.Time { return now }
+
+	throttler.RegisterFailure("SN-OLD-1")
+	throttler.RegisterFailure("SN-OLD-2")
+	now = now.Add(10 * time.Minute)
+	throttler.RegisterFailure("SN-RECENT")
+	throttler.RegisterFailure("SN-RECENT")
+
+	now = now.Add(6 * time.Minute)
+	throttler.Sweep()
+
+	if len(throttler.failures) != 1 {
+		t.Fatalf("got %d keys after sweep, want 1: %v", len(throttler.failures), throttler.failures)
+	}
+	if _, ok := throttler.failures["SN-RECENT"]; !ok {
+		t.Error("sweep evicted a key with failures inside the window")
+	}
+	if throttler.Allow("SN-RECENT") {
+		t.Error("SN-RECENT should still be throttled")
+	}
+	if !throttler.Allow("SN-OLD-1") {
+		t.Error("SN-OLD-1 should be allowed after its failures expired")
+	}
+
+	now = now.Add(15 * time.Minute)
+	throttler.Sweep()
+	if len(throttler.failures) != 0 {
+		t.Errorf("got %d keys after every failure expired, want 0", len(throttler.failures))
+	}
+}
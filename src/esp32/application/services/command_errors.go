@@ -0,0 +1,10 @@
+package services
+
+import "errors"
+
+var (
+	// ErrCommandNotFound se devuelve cuando el comando no existe o pertenece a otro ESP32
+	ErrCommandNotFound = errors.New("command not found")
+	// ErrCommandNotOpen se devuelve al confirmar un comando ya resuelto o vencido
+	ErrCommandNotOpen = errors.New("command is no longer open")
+)
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// CommandExpirer marca como vencidos los comandos que ningún ESP32 confirmó a tiempo
+type CommandExpirer struct {
+	deviceCommandRepository repositories.DeviceCommandRepository
+}
+
+// NewCommandExpirer crea una nueva instancia de CommandExpirer
+func NewCommandExpirer(deviceCommandRepo repositories.DeviceCommandRepository) *CommandExpirer {
+	return &CommandExpirer{
+		deviceCommandRepository: deviceCommandRepo,
+	}
+}
+
+// Run vence periódicamente los comandos hasta que se cancele el contexto
+func (e *CommandExpirer) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			expired, err := e.deviceCommandRepository.ExpireStale(ctx, time.Now())
+			if err != nil {
+				log.Printf("Warning: command expiration failed: %v", err)
+				continue
+			}
+			if expired > 0 {
+				log.Printf("%d ESP32 commands expired", expired)
+			}
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"hex_go/src/esp32/domain/entities"
+)
+
+var (
+	// ErrCommissioningNotFound se devuelve cuando la sesión de comisionamiento no existe
+	ErrCommissioningNotFound = errors.New("commissioning session not found")
+	// ErrCommissioningForbidden se devuelve cuando el usuario no es el instalador de la sesión ni tiene acceso al ESP32
+	ErrCommissioningForbidden = errors.New("you do not have access to this commissioning session")
+	// ErrInstallerRoleRequired se devuelve cuando un usuario sin rol de instalador intenta comisionar un ESP32
+	ErrInstallerRoleRequired = errors.New("installer role required")
+	// ErrCommissioningInProgress se devuelve al iniciar una sesión para un ESP32 que ya tiene una en curso
+	ErrCommissioningInProgress = errors.New("the ESP32 already has a commissioning session in progress")
+	// ErrCommissioningClosed se devuelve al operar sobre una sesión finalizada, cancelada o vencida
+	ErrCommissioningClosed = errors.New("commissioning session is no longer in progress")
+	// ErrCommissioningIncomplete se devuelve al finalizar una sesión con sensores sin aprobar
+	ErrCommissioningIncomplete = errors.New("not every sensor has passed its test")
+	// ErrNoOpenCommissioning se devuelve cuando el ESP32 informa una prueba sin una sesión en curso
+	ErrNoOpenCommissioning = errors.New("the ESP32 has no commissioning session in progress")
+	// ErrCertificateNotIssued se devuelve al pedir el certificado de una sesión no aprobada
+	ErrCertificateNotIssued = errors.New("commissioning certificate not issued")
+	// ErrCertificateSigningDisabled se devuelve si la API no tiene la clave para firmar certificados
+	ErrCertificateSigningDisabled = errors.New("commissioning certificate signing key is not configured")
+)
+
+// CertificateSigner firma los certificados de comisionamiento
+type CertificateSigner interface {
+	// Sign devuelve la firma en base64 de los bytes del certificado
+	Sign(payload []byte) (string, error)
+	// Algorithm devuelve el nombre del algoritmo de firma
+	Algorithm() string
+	// PublicKey devuelve en base64 la clave pública con la que se verifica la firma
+	PublicKey() string
+}
+
+// CommissioningCertificateResponse es el certificado firmado tal como se entrega al residente.
+// Certificate contiene exactamente los bytes firmados.
+type CommissioningCertificateResponse struct {
+	Certificate json.RawMessage `json:"certificate"`
+	Signature   string          `json:"signature"`
+	Algorithm   string          `json:"algorithm"`
+	PublicKey   string          `json:"public_key"`
+}
+
+// canCommission indica si el actor puede iniciar y ver sesiones de cualquier ESP32
+func canCommission(actor Actor) bool {
+	return actor.IsAdmin() || actor.IsInstaller()
+}
+
+// authorizeCommissioningESP32 verifica que el actor pueda consultar las sesiones de un ESP32:
+// instaladores y administradores, o quienes pueden consultar el ESP32
+func authorizeCommissioningESP32(ctx context.Context, authorizer *ESP32Authorizer, esp32ID int, actor Actor) error {
+	if !canCommission(actor) {
+		_, err := authorizer.AuthorizeView(ctx, esp32ID, actor)
+		return err
+	}
+
+	esp32, err := authorizer.esp32Repository.FindByID(ctx, esp32ID)
+	if err != nil {
+		return err
+	}
+	if esp32 == nil {
+		return ErrESP32NotFound
+	}
+	return nil
+}
+
+// authorizeCommissioningSession verifica que el actor pueda consultar la sesión; si manage está
+// activo solo la pueden modificar el instalador que la inició o un administrador
+func authorizeCommissioningSession(ctx context.Context, authorizer *ESP32Authorizer, session *entities.CommissioningSession, actor Actor, manage bool) error {
+	if actor.IsAdmin() {
+		return nil
+	}
+	if session.InstallerID != nil && *session.InstallerID == actor.UserID {
+		return nil
+	}
+	if manage {
+		return ErrCommissioningForbidden
+	}
+
+	if _, err := authorizer.AuthorizeView(ctx, session.ESP32ID, actor); err != nil {
+		if errors.Is(err, ErrESP32Forbidden) {
+			return ErrCommissioningForbidden
+		}
+		return err
+	}
+	return nil
+}
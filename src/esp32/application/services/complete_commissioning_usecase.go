@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+	userRepositories "hex_go/src/users/domain/repositories"
+)
+
+// CompleteCommissioningUseCase implementa el caso de uso para finalizar un comisionamiento en el que
+// todos los sensores pasaron su prueba, emitiendo el certificado firmado que recibe el residente
+type CompleteCommissioningUseCase struct {
+	esp32Repository         repositories.ESP32Repository
+	deviceSensorRepository  repositories.DeviceSensorRepository
+	commissioningRepository repositories.CommissioningRepository
+	userRepository          userRepositories.UserRepository
+	signer                  CertificateSigner
+	authorizer              *ESP32Authorizer
+}
+
+// NewCompleteCommissioningUseCase crea una nueva instancia de CompleteCommissioningUseCase.
+// signer puede ser nil si la clave de firma no está configurada; en ese caso no se emiten certificados.
+func NewCompleteCommissioningUseCase(
+	esp32Repo repositories.ESP32Repository,
+	deviceSensorRepo repositories.DeviceSensorRepository,
+	commissioningRepo repositories.CommissioningRepository,
+	userRepo userRepositories.UserRepository,
+	signer CertificateSigner,
+	authorizer *ESP32Authorizer,
+) *CompleteCommissioningUseCase {
+	return &CompleteCommissioningUseCase{
+		esp32Repository:         esp32Repo,
+		deviceSensorRepository:  deviceSensorRepo,
+		commissioningRepository: commissioningRepo,
+		userRepository:          userRepo,
+		signer:                  signer,
+		authorizer:              authorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *CompleteCommissioningUseCase) Execute(ctx context.Context, sessionID int, actor Actor, notes string) (*CommissioningCertificateResponse, error) {
+	notes = strings.TrimSpace(notes)
+	if len(notes) > 255 {
+		return nil, errors.New("notes must be at most 255 characters")
+	}
+
+	session, err := uc.commissioningRepository.FindByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, ErrCommissioningNotFound
+	}
+	if err := authorizeCommissioningSession(ctx, uc.authorizer, session, actor, true); err != nil {
+		return nil, err
+	}
+	if !session.IsOpen() {
+		return nil, ErrCommissioningClosed
+	}
+
+	var notPassed []string
+	for _, test := range session.Tests {
+		if test.Status != entities.TestPassed {
+			notPassed = append(notPassed, fmt.Sprintf("%d (%s, %s)", test.SensorID, test.SensorType, test.Status))
+		}
+	}
+	if len(notPassed) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrCommissioningIncomplete, strings.Join(notPassed, ", "))
+	}
+
+	if uc.signer == nil {
+		return nil, ErrCertificateSigningDisabled
+	}
+
+	now := time.Now()
+	session.Notes = notes
+	certificate, err := uc.buildCertificate(ctx, session, now)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(certificate)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := uc.signer.Sign(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	session.Status = entities.CommissioningPassed
+	session.Certificate = &entities.SignedCertificate{Payload: payload, Signature: signature}
+
+	closed, err := uc.commissioningRepository.Close(ctx, session, now)
+	if err != nil {
+		return nil, err
+	}
+	if !closed {
+		return nil, ErrCommissioningClosed
+	}
+
+	return certificateResponse(session.Certificate, uc.signer), nil
+}
+
+// buildCertificate arma el contenido del certificado con los datos del ESP32, del instalador y de cada prueba
+func (uc *CompleteCommissioningUseCase) buildCertificate(ctx context.Context, session *entities.CommissioningSession, issuedAt time.Time) (*entities.CommissioningCertificate, error) {
+	esp32, err := uc.esp32Repository.FindByID(ctx, session.ESP32ID)
+	if err != nil {
+		return nil, err
+	}
+	if esp32 == nil {
+		return nil, ErrESP32NotFound
+	}
+
+	certificate := &entities.CommissioningCertificate{
+		SessionID:        session.ID,
+		ESP32ID:          esp32.ID,
+		ESP32NumeroSerie: esp32.NumeroSerie,
+		StartedAt:        session.StartedAt,
+		IssuedAt:         issuedAt,
+		Notes:            session.Notes,
+		Tests:            make([]entities.CertificateTestResult, 0, len(session.Tests)),
+	}
+
+	if session.InstallerID != nil {
+		certificate.InstallerID = *session.InstallerID
+		installer, err := uc.userRepository.FindByID(ctx, *session.InstallerID)
+		if err == nil && installer != nil {
+			certificate.InstallerName = installer.Username
+		}
+	}
+
+	sensors, err := uc.deviceSensorRepository.FindByESP32ID(ctx, session.ESP32ID)
+	if err != nil {
+		return nil, err
+	}
+	serials := make(map[int]string, len(sensors))
+	for _, sensor := range sensors {
+		serials[sensor.ID] = sensor.NumeroSerie
+	}
+
+	for _, test := range session.Tests {
+		certificate.Tests = append(certificate.Tests, entities.CertificateTestResult{
+			SensorID:          test.SensorID,
+			SensorType:        test.SensorType,
+			SensorNumeroSerie: serials[test.SensorID],
+			Kind:              test.Kind,
+			Value:             test.Value,
+			TestedAt:          *test.ReportedAt,
+		})
+	}
+
+	return certificate, nil
+}
+
+// certificateResponse arma la respuesta con el certificado firmado y los datos para verificarlo
+func certificateResponse(certificate *entities.SignedCertificate, signer CertificateSigner) *CommissioningCertificateResponse {
+	return &CommissioningCertificateResponse{
+		Certificate: json.RawMessage(certificate.Payload),
+		Signature:   certificate.Signature,
+		Algorithm:   signer.Algorithm(),
+		PublicKey:   signer.PublicKey(),
+	}
+}
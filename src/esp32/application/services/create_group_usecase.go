@@ -0,0 +1,49 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// CreateGroupUseCase implementa el caso de uso para crear un grupo de ESP32
+type CreateGroupUseCase struct {
+	groupRepository repositories.DeviceGroupRepository
+	groupAuthorizer *GroupAuthorizer
+}
+
+// NewCreateGroupUseCase crea una nueva instancia de CreateGroupUseCase
+func NewCreateGroupUseCase(groupRepo repositories.DeviceGroupRepository, groupAuthorizer *GroupAuthorizer) *CreateGroupUseCase {
+	return &CreateGroupUseCase{
+		groupRepository: groupRepo,
+		groupAuthorizer: groupAuthorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso. Un grupo sin padre inicia una nueva jerarquía del actor;
+// un subgrupo pertenece al mismo cliente que su padre y solo lo puede crear el dueño de la jerarquía.
+func (uc *CreateGroupUseCase) Execute(ctx context.Context, actor Actor, name string, kind entities.GroupKind, parentID *int) (*entities.DeviceGroup, error) {
+	if !entities.IsValidGroupKind(kind) {
+		return nil, fmt.Errorf("invalid group kind %q, use site, building, floor or zone", kind)
+	}
+	if err := entities.ValidateGroupName(name); err != nil {
+		return nil, err
+	}
+
+	ownerID := actor.UserID
+	if parentID != nil {
+		parent, err := uc.groupAuthorizer.Authorize(ctx, *parentID, actor, GroupAccessOwner)
+		if err != nil {
+			return nil, err
+		}
+		if !parent.Kind.CanContain(kind) {
+			return nil, fmt.Errorf("a %s cannot contain a %s", parent.Kind, kind)
+		}
+		ownerID = parent.OwnerID
+	}
+
+	group := entities.NewDeviceGroup(parentID, kind, name, ownerID)
+	return uc.groupRepository.Create(ctx, group)
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// MaintenanceScheduleInput contiene los datos para crear o editar una tarea de mantenimiento
+type MaintenanceScheduleInput struct {
+	Task         entities.MaintenanceTask `json:"task"`
+	SensorID     *int                     `json:"sensor_id"`     // Solo en los reemplazos de sensores
+	IntervalDays *int                     `json:"interval_days"` // Si se omite se usa el intervalo habitual de la tarea
+	DueAt        *time.Time               `json:"due_at"`        // Si se omite se calcula a partir del intervalo
+}
+
+// CreateMaintenanceScheduleUseCase implementa el caso de uso para programar una tarea de mantenimiento en un ESP32
+type CreateMaintenanceScheduleUseCase struct {
+	deviceSensorRepository repositories.DeviceSensorRepository
+	maintenanceRepository  repositories.MaintenanceRepository
+	authorizer             *ESP32Authorizer
+}
+
+// NewCreateMaintenanceScheduleUseCase crea una nueva instancia de CreateMaintenanceScheduleUseCase
+func NewCreateMaintenanceScheduleUseCase(
+	deviceSensorRepo repositories.DeviceSensorRepository,
+	maintenanceRepo repositories.MaintenanceRepository,
+	authorizer *ESP32Authorizer,
+) *CreateMaintenanceScheduleUseCase {
+	return &CreateMaintenanceScheduleUseCase{
+		deviceSensorRepository: deviceSensorRepo,
+		maintenanceRepository:  maintenanceRepo,
+		authorizer:             authorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *CreateMaintenanceScheduleUseCase) Execute(ctx context.Context, esp32ID int, actor Actor, input MaintenanceScheduleInput) (*entities.MaintenanceSchedule, error) {
+	esp32, err := uc.authorizer.AuthorizeOperate(ctx, esp32ID, actor)
+	if err != nil {
+		return nil, err
+	}
+	if esp32.IsDecommissioned() {
+		return nil, ErrESP32Decommissioned
+	}
+
+	var sensor *entities.DeviceSensor
+	if input.SensorID != nil {
+		sensor, err = uc.deviceSensorRepository.FindByID(ctx, *input.SensorID)
+		if err != nil {
+			return nil, err
+		}
+		if sensor == nil || sensor.ESP32ID != esp32ID {
+			return nil, ErrSensorNotFound
+		}
+		if sensor.IsRetired() {
+			return nil, repositories.ErrSensorRetired
+		}
+	}
+
+	intervalDays := entities.DefaultMaintenanceIntervalDays(input.Task)
+	if input.IntervalDays != nil {
+		intervalDays = *input.IntervalDays
+	}
+	dueAt := firstDueAt(input.Task, sensor, intervalDays, time.Now())
+	if input.DueAt != nil {
+		dueAt = *input.DueAt
+	}
+
+	schedule := entities.NewMaintenanceSchedule(esp32ID, input.SensorID, input.Task, intervalDays, dueAt)
+	if err := schedule.Validate(); err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.maintenanceRepository.FindSchedulesByESP32ID(ctx, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+	if hasSchedule(existing, schedule.Task, schedule.SensorID) {
+		return nil, ErrMaintenanceScheduleExists
+	}
+
+	created, err := uc.maintenanceRepository.CreateSchedule(ctx, schedule)
+	if err != nil {
+		return nil, err
+	}
+	created.Status = created.StatusAt(time.Now())
+
+	return created, nil
+}
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// CreateSensorTypeUseCase implementa el caso de uso para registrar un nuevo tipo de sensor
+type CreateSensorTypeUseCase struct {
+	sensorTypeRepository repositories.SensorTypeRepository
+}
+
+// NewCreateSensorTypeUseCase crea una nueva instancia de CreateSensorTypeUseCase
+func NewCreateSensorTypeUseCase(sensorTypeRepo repositories.SensorTypeRepository) *CreateSensorTypeUseCase {
+	return &CreateSensorTypeUseCase{
+		sensorTypeRepository: sensorTypeRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *CreateSensorTypeUseCase) Execute(ctx context.Context, sensorType *entities.SensorType) (*entities.SensorType, error) {
+	sensorType.Code = entities.NormalizeSensorTypeCode(sensorType.Code)
+	sensorType.Name = strings.TrimSpace(sensorType.Name)
+	sensorType.Unit = strings.TrimSpace(sensorType.Unit)
+	if err := sensorType.Validate(); err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.sensorTypeRepository.FindByCode(ctx, sensorType.Code)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrSensorTypeExists
+	}
+
+	sensorType.CreatedAt = time.Now()
+	if err := uc.sensorTypeRepository.Create(ctx, sensorType); err != nil {
+		return nil, err
+	}
+
+	return sensorType, nil
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// DecommissionESP32UseCase implementa el caso de uso para retirar un ESP32 de servicio.
+// El ESP32 se conserva para que su historial de alertas siga disponible hasta que se purgue.
+type DecommissionESP32UseCase struct {
+	esp32Repository repositories.ESP32Repository
+}
+
+// NewDecommissionESP32UseCase crea una nueva instancia de DecommissionESP32UseCase
+func NewDecommissionESP32UseCase(esp32Repo repositories.ESP32Repository) *DecommissionESP32UseCase {
+	return &DecommissionESP32UseCase{
+		esp32Repository: esp32Repo,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *DecommissionESP32UseCase) Execute(ctx context.Context, esp32ID int) (*entities.ESP32, error) {
+	// Verificar si el ESP32 existe
+	esp32, err := uc.esp32Repository.FindByID(ctx, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+	if esp32 == nil {
+		return nil, ErrESP32NotFound
+	}
+	if esp32.IsDecommissioned() {
+		return nil, ErrESP32Decommissioned
+	}
+
+	// Un ESP32 en uso debe desasignarse antes de retirarlo
+	if esp32.UserID != nil {
+		return nil, errors.New("ESP32 is assigned to a user, unassign it first")
+	}
+
+	now := time.Now()
+	decommissioned, err := uc.esp32Repository.Decommission(ctx, esp32.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !decommissioned {
+		return nil, errors.New("ESP32 was claimed or decommissioned concurrently, try again")
+	}
+
+	esp32.DecommissionedAt = &now
+	esp32.Online = false
+	esp32.GroupID = nil
+	return esp32, nil
+}
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// DecommissionPurger purga los datos operativos de los ESP32 que llevan retirados más que el
+// período de retención, conservando su historial
+type DecommissionPurger struct {
+	esp32Repository repositories.ESP32Repository
+	retention       time.Duration
+}
+
+// NewDecommissionPurger crea una nueva instancia de DecommissionPurger
+func NewDecommissionPurger(esp32Repo repositories.ESP32Repository, retention time.Duration) *DecommissionPurger {
+	return &DecommissionPurger{
+		esp32Repository: esp32Repo,
+		retention:       retention,
+	}
+}
+
+// Run purga periódicamente los ESP32 retirados hasta que se cancele el contexto
+func (p *DecommissionPurger) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := p.esp32Repository.PurgeDecommissioned(ctx, time.Now().Add(-p.retention))
+			if err != nil {
+				log.Printf("Warning: decommissioned ESP32 purge failed: %v", err)
+				continue
+			}
+			if purged > 0 {
+				log.Printf("%d decommissioned ESP32 purged", purged)
+			}
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// DeleteGroupUseCase implementa el caso de uso para eliminar un grupo sin subgrupos.
+// Los ESP32 del grupo no se eliminan, solo quedan sin grupo.
+type DeleteGroupUseCase struct {
+	groupRepository repositories.DeviceGroupRepository
+	groupAuthorizer *GroupAuthorizer
+}
+
+// NewDeleteGroupUseCase crea una nueva instancia de DeleteGroupUseCase
+func NewDeleteGroupUseCase(groupRepo repositories.DeviceGroupRepository, groupAuthorizer *GroupAuthorizer) *DeleteGroupUseCase {
+	return &DeleteGroupUseCase{
+		groupRepository: groupRepo,
+		groupAuthorizer: groupAuthorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *DeleteGroupUseCase) Execute(ctx context.Context, groupID int, actor Actor) error {
+	group, err := uc.groupAuthorizer.Authorize(ctx, groupID, actor, GroupAccessOwner)
+	if err != nil {
+		return err
+	}
+
+	children, err := uc.groupRepository.FindByParentID(ctx, group.ID)
+	if err != nil {
+		return err
+	}
+	if len(children) > 0 {
+		return ErrGroupHasChildren
+	}
+
+	return uc.groupRepository.Delete(ctx, group.ID)
+}
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// DeleteMaintenanceScheduleUseCase implementa el caso de uso para dejar de programar una tarea de mantenimiento.
+// Las realizaciones registradas se conservan en el historial del ESP32.
+type DeleteMaintenanceScheduleUseCase struct {
+	maintenanceRepository repositories.MaintenanceRepository
+	authorizer            *ESP32Authorizer
+}
+
+// NewDeleteMaintenanceScheduleUseCase crea una nueva instancia de DeleteMaintenanceScheduleUseCase
+func NewDeleteMaintenanceScheduleUseCase(maintenanceRepo repositories.MaintenanceRepository, authorizer *ESP32Authorizer) *DeleteMaintenanceScheduleUseCase {
+	return &DeleteMaintenanceScheduleUseCase{
+		maintenanceRepository: maintenanceRepo,
+		authorizer:            authorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *DeleteMaintenanceScheduleUseCase) Execute(ctx context.Context, esp32ID, scheduleID int, actor Actor) error {
+	if _, err := uc.authorizer.AuthorizeOperate(ctx, esp32ID, actor); err != nil {
+		return err
+	}
+
+	if _, err := findESP32Schedule(ctx, uc.maintenanceRepository, esp32ID, scheduleID); err != nil {
+		return err
+	}
+
+	return uc.maintenanceRepository.DeleteSchedule(ctx, scheduleID)
+}
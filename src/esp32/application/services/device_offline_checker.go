@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// DeviceOfflineChecker marca como desconectados los ESP32 que dejan de enviar heartbeats
+// y registra un evento de desconexión que el módulo de alertas muestra al usuario
+type DeviceOfflineChecker struct {
+	esp32Repository       repositories.ESP32Repository
+	deviceEventRepository repositories.DeviceEventRepository
+	offlineAfter          time.Duration
+}
+
+// NewDeviceOfflineChecker crea una nueva instancia de DeviceOfflineChecker
+func NewDeviceOfflineChecker(esp32Repo repositories.ESP32Repository, deviceEventRepo repositories.DeviceEventRepository, offlineAfter time.Duration) *DeviceOfflineChecker {
+	return &DeviceOfflineChecker{
+		esp32Repository:       esp32Repo,
+		deviceEventRepository: deviceEventRepo,
+		offlineAfter:          offlineAfter,
+	}
+}
+
+// Run revisa periódicamente los ESP32 hasta que se cancele el contexto
+func (c *DeviceOfflineChecker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.Check(ctx); err != nil {
+				log.Printf("Warning: device offline check failed: %v", err)
+			}
+		}
+	}
+}
+
+// Check marca como desconectados los ESP32 cuyo último heartbeat supera la ventana de silencio
+func (c *DeviceOfflineChecker) Check(ctx context.Context) error {
+	cutoff := time.Now().Add(-c.offlineAfter)
+
+	esp32s, err := c.esp32Repository.FindStaleOnline(ctx, cutoff)
+	if err != nil {
+		return err
+	}
+
+	for _, esp32 := range esp32s {
+		marked, err := c.esp32Repository.MarkOffline(ctx, esp32.ID, cutoff)
+		if err != nil {
+			return err
+		}
+		// Un heartbeat llegó entre la consulta y la actualización
+		if !marked {
+			continue
+		}
+
+		event := entities.NewDeviceEvent(esp32.ID, entities.DeviceEventOffline)
+		if _, err := c.deviceEventRepository.Create(ctx, event); err != nil {
+			return err
+		}
+		log.Printf("ESP32 %s marked as offline", esp32.NumeroSerie)
+	}
+
+	return nil
+}
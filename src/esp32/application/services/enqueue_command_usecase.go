@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// EnqueueCommandUseCase implementa el caso de uso para encolar un comando para un ESP32
+type EnqueueCommandUseCase struct {
+	deviceCommandRepository repositories.DeviceCommandRepository
+	authorizer              *ESP32Authorizer
+}
+
+// NewEnqueueCommandUseCase crea una nueva instancia de EnqueueCommandUseCase
+func NewEnqueueCommandUseCase(deviceCommandRepo repositories.DeviceCommandRepository, authorizer *ESP32Authorizer) *EnqueueCommandUseCase {
+	return &EnqueueCommandUseCase{
+		deviceCommandRepository: deviceCommandRepo,
+		authorizer:              authorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso. Un ttl igual a 0 usa el plazo por defecto.
+func (uc *EnqueueCommandUseCase) Execute(ctx context.Context, esp32ID int, actor Actor, commandType entities.CommandType, durationSeconds int, ttl time.Duration) (*entities.DeviceCommand, error) {
+	if _, err := uc.authorizer.AuthorizeOperate(ctx, esp32ID, actor); err != nil {
+		return nil, err
+	}
+
+	if !entities.IsValidCommandType(commandType) {
+		return nil, errors.New("command type must be silence, test_siren, reboot or recalibrate")
+	}
+	if durationSeconds < 0 || durationSeconds > entities.MaxCommandDurationSeconds {
+		return nil, errors.New("duration_seconds must be between 0 and 3600")
+	}
+	if durationSeconds != 0 && commandType != entities.CommandSilence && commandType != entities.CommandTestSiren {
+		return nil, errors.New("duration_seconds only applies to silence and test_siren commands")
+	}
+	if ttl == 0 {
+		ttl = entities.DefaultCommandTTL
+	}
+	if ttl < time.Second || ttl > entities.MaxCommandTTL {
+		return nil, errors.New("ttl_seconds must be between 1 and 86400")
+	}
+
+	command := entities.NewDeviceCommand(esp32ID, commandType, durationSeconds, actor.UserID, ttl)
+
+	return uc.deviceCommandRepository.Create(ctx, command)
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+	userEntities "hex_go/src/users/domain/entities"
+)
+
+var (
+	// ErrESP32NotFound se devuelve cuando el ESP32 solicitado no existe
+	ErrESP32NotFound = errors.New("ESP32 not found")
+	// ErrESP32Forbidden se devuelve cuando el usuario no es dueño del ESP32 ni administrador
+	ErrESP32Forbidden = errors.New("you do not have access to this ESP32")
+	// ErrESP32Decommissioned se devuelve al intentar operar sobre un ESP32 retirado de servicio
+	ErrESP32Decommissioned = errors.New("ESP32 is decommissioned")
+)
+
+// Actor identifica al usuario autenticado que realiza una operación
+type Actor struct {
+	UserID int
+	Role   string
+}
+
+// IsAdmin indica si el actor tiene rol de administrador
+func (a Actor) IsAdmin() bool {
+	return a.Role == userEntities.RoleAdmin
+}
+
+// IsInstaller indica si el actor tiene rol de instalador
+func (a Actor) IsInstaller() bool {
+	return a.Role == userEntities.RoleInstaller
+}
+
+// ESP32Authorizer verifica que un actor pueda operar sobre un ESP32
+type ESP32Authorizer struct {
+	esp32Repository repositories.ESP32Repository
+	groupAuthorizer *GroupAuthorizer
+}
+
+// NewESP32Authorizer crea una nueva instancia de ESP32Authorizer
+func NewESP32Authorizer(esp32Repo repositories.ESP32Repository, groupAuthorizer *GroupAuthorizer) *ESP32Authorizer {
+	return &ESP32Authorizer{
+		esp32Repository: esp32Repo,
+		groupAuthorizer: groupAuthorizer,
+	}
+}
+
+// Authorize devuelve el ESP32 si el actor es su dueño o es administrador.
+// Se usa en las operaciones sobre la propiedad del ESP32, como desasignarlo o transferirlo.
+func (a *ESP32Authorizer) Authorize(ctx context.Context, esp32ID int, actor Actor) (*entities.ESP32, error) {
+	return a.authorize(ctx, esp32ID, actor, GroupAccessOwner)
+}
+
+// AuthorizeOperate devuelve el ESP32 si el actor puede operarlo: su dueño, un administrador
+// o un miembro con rol manager del grupo del ESP32 o de uno de sus ancestros
+func (a *ESP32Authorizer) AuthorizeOperate(ctx context.Context, esp32ID int, actor Actor) (*entities.ESP32, error) {
+	return a.authorize(ctx, esp32ID, actor, GroupAccessManager)
+}
+
+// AuthorizeView devuelve el ESP32 si el actor puede consultarlo; además de quienes pueden
+// operarlo, lo pueden consultar los miembros con rol viewer de su grupo
+func (a *ESP32Authorizer) AuthorizeView(ctx context.Context, esp32ID int, actor Actor) (*entities.ESP32, error) {
+	return a.authorize(ctx, esp32ID, actor, GroupAccessViewer)
+}
+
+// authorize devuelve el ESP32 si el actor tiene al menos el nivel de permiso indicado
+func (a *ESP32Authorizer) authorize(ctx context.Context, esp32ID int, actor Actor, required GroupAccess) (*entities.ESP32, error) {
+	esp32, err := a.esp32Repository.FindByID(ctx, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+	if esp32 == nil {
+		return nil, ErrESP32NotFound
+	}
+
+	if actor.IsAdmin() {
+		return esp32, nil
+	}
+	if esp32.UserID != nil && *esp32.UserID == actor.UserID {
+		return esp32, nil
+	}
+
+	// Los permisos de grupo nunca alcanzan las operaciones del dueño
+	if required < GroupAccessOwner && esp32.GroupID != nil {
+		access, err := a.groupAuthorizer.accessByID(ctx, *esp32.GroupID, actor)
+		if err != nil {
+			return nil, err
+		}
+		if access >= required {
+			return esp32, nil
+		}
+	}
+
+	return nil, ErrESP32Forbidden
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"hex_go/src/esp32/domain/entities"
+	userEntities "hex_go/src/users/domain/entities"
+)
+
+const (
+	ownerID   = 1
+	otherID   = 2
+	adminID   = 3
+	managerID = 4
+	viewerID  = 5
+
+	ownedESP32ID   = 10
+	unknownESP32ID = 99
+	buildingID     = 100
+	floorID        = 101
+)
+
+// newTestAuthorizer arma un ESP32 del dueño instalado en un piso de un edificio,
+// con un manager del edificio y un viewer del piso
+func newTestAuthorizer() (*ESP32Authorizer, *memoryESP32Repository) {
+	esp32Repo := newMemoryESP32Repository(&entities.ESP32{
+		ID:          ownedESP32ID,
+		NumeroSerie: "SN-0001",
+		UserID:      intPtr(ownerID),
+		GroupID:     intPtr(floorID),
+	})
+
+	return NewESP32Authorizer(esp32Repo, NewGroupAuthorizer(newTestGroupRepository())), esp32Repo
+}
+
+// newTestGroupRepository arma un edificio con un piso, un manager del edificio y un viewer del piso
+func newTestGroupRepository() *memoryDeviceGroupRepository {
+	groupRepo := newMemoryDeviceGroupRepository()
+	groupRepo.groups[buildingID] = &entities.DeviceGroup{ID: buildingID, Kind: entities.GroupBuilding, OwnerID: ownerID}
+	groupRepo.groups[floorID] = &entities.DeviceGroup{ID: floorID, ParentID: intPtr(buildingID), Kind: entities.GroupFloor, OwnerID: ownerID}
+	groupRepo.members = []*entities.GroupMember{
+		{GroupID: buildingID, UserID: managerID, Role: entities.GroupRoleManager},
+		{GroupID: floorID, UserID: viewerID, Role: entities.GroupRoleViewer},
+	}
+
+	return groupRepo
+}
+
+func TestESP32Authorizer(t *testing.T) {
+	owner := Actor{UserID: ownerID, Role: userEntities.RoleUser}
+	other := Actor{UserID: otherID, Role: userEntities.RoleUser}
+	admin := Actor{UserID: adminID, Role: userEntities.RoleAdmin}
+	manager := Actor{UserID: managerID, Role: userEntities.RoleUser}
+	viewer := Actor{UserID: viewerID, Role: userEntities.RoleUser}
+
+	authorizer, _ := newTestAuthorizer()
+	authorize := map[string]func(context.Context, int, Actor) (*entities.ESP32, error){
+		"Authorize":        authorizer.Authorize,
+		"AuthorizeOperate": authorizer.AuthorizeOperate,
+		"AuthorizeView":    authorizer.AuthorizeView,
+	}
+
+	tests := []struct {
+		name    string
+		method  string
+		esp32ID int
+		actor   Actor
+		wantErr error
+	}{
+		{"dueño puede administrar", "Authorize", ownedESP32ID, owner, nil},
+		{"dueño puede operar", "AuthorizeOperate", ownedESP32ID, owner, nil},
+		{"dueño puede consultar", "AuthorizeView", ownedESP32ID, owner, nil},
+		{"otro usuario no puede administrar", "Authorize", ownedESP32ID, other, ErrESP32Forbidden},
+		{"otro usuario no puede operar", "AuthorizeOperate", ownedESP32ID, other, ErrESP32Forbidden},
+		{"otro usuario no puede consultar", "AuthorizeView", ownedESP32ID, other, ErrESP32Forbidden},
+		{"administrador puede administrar", "Authorize", ownedESP32ID, admin, nil},
+		{"administrador puede operar", "AuthorizeOperate", ownedESP32ID, admin, nil},
+		{"administrador puede consultar", "AuthorizeView", ownedESP32ID, admin, nil},
+		{"manager del edificio no puede administrar", "Authorize", ownedESP32ID, manager, ErrESP32Forbidden},
+		{"manager del edificio puede operar", "AuthorizeOperate", ownedESP32ID, manager, nil},
+		{"manager del edificio puede consultar", "AuthorizeView", ownedESP32ID, manager, nil},
+		{"viewer del piso no puede administrar", "Authorize", ownedESP32ID, viewer, ErrESP32Forbidden},
+		{"viewer del piso no puede operar", "AuthorizeOperate", ownedESP32ID, viewer, ErrESP32Forbidden},
+		{"viewer del piso puede consultar", "AuthorizeView", ownedESP32ID, viewer, nil},
+		{"ESP32 desconocido al administrar", "Authorize", unknownESP32ID, owner, ErrESP32NotFound},
+		{"ESP32 desconocido al operar", "AuthorizeOperate", unknownESP32ID, admin, ErrESP32NotFound},
+		{"ESP32 desconocido al consultar", "AuthorizeView", unknownESP32ID, admin, ErrESP32NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			esp32, err := authorize[tt.method](context.Background(), tt.esp32ID, tt.actor)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("%s() error = %v, want %v", tt.method, err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (esp32 == nil || esp32.ID != tt.esp32ID) {
+				t.Fatalf("%s() = %+v, want ESP32 %d", tt.method, esp32, tt.esp32ID)
+			}
+			if tt.wantErr != nil && esp32 != nil {
+				t.Fatalf("%s() returned ESP32 %d along with error %v", tt.method, esp32.ID, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"hex_go/src/esp32/domain/entities"
+)
+
+// Formatos de manifiesto de fábrica soportados
+const (
+	ManifestFormatCSV  = "csv"
+	ManifestFormatJSON = "json"
+)
+
+// MaxManifestRows es la cantidad máxima de ESP32 que se pueden importar de una vez
+const MaxManifestRows = 5000
+
+// manifestSerialColumn es la columna del manifiesto con el número de serie del ESP32 y
+// manifestHardwareColumn la de su modelo de placa, que es opcional; las demás columnas
+// llevan el código de un tipo de sensor, por ejemplo ky_026 o MQ_7
+const (
+	manifestSerialColumn   = "numero_serie"
+	manifestHardwareColumn = "hardware_revision"
+)
+
+// ManifestRow es una fila de un manifiesto de fábrica: un ESP32 y los números de serie de sus sensores
+type ManifestRow struct {
+	Row              int // Número de fila en el manifiesto, empezando en 1
+	NumeroSerie      string
+	HardwareRevision string
+	SensorSerials    map[string]string // Número de serie de cada sensor, por código de tipo; vacío si no se instaló
+}
+
+// ParseManifest lee un manifiesto CSV (con encabezado numero_serie y una columna por tipo de sensor,
+// por ejemplo numero_serie,ky_026,mq_2,mq_135,dht_22) o JSON (un arreglo de objetos con esas mismas claves).
+// Que los tipos de sensor estén registrados lo verifica el caso de uso de importación.
+func ParseManifest(format string, r io.Reader) ([]ManifestRow, error) {
+	var rows []ManifestRow
+	var err error
+
+	switch strings.ToLower(format) {
+	case ManifestFormatCSV:
+		rows, err = parseCSVManifest(r)
+	case ManifestFormatJSON:
+		rows, err = parseJSONManifest(r)
+	default:
+		return nil, fmt.Errorf("unsupported manifest format %q, use csv or json", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.New("manifest has no rows")
+	}
+	if len(rows) > MaxManifestRows {
+		return nil, fmt.Errorf("manifest has %d rows, at most %d are accepted", len(rows), MaxManifestRows)
+	}
+
+	return rows, nil
+}
+
+// parseCSVManifest lee un manifiesto CSV; las columnas se identifican por el encabezado
+func parseCSVManifest(r io.Reader) ([]ManifestRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("manifest has no rows")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Las planillas exportadas desde Excel suelen empezar con una marca BOM
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\uFEFF")))] = i
+	}
+	serialColumn, ok := columns[manifestSerialColumn]
+	if !ok {
+		return nil, errors.New("manifest header must include numero_serie")
+	}
+
+	var rows []ManifestRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		field := func(index int) string {
+			if index < len(record) {
+				return strings.TrimSpace(record[index])
+			}
+			return ""
+		}
+
+		row := ManifestRow{
+			Row:           len(rows) + 1,
+			NumeroSerie:   field(serialColumn),
+			SensorSerials: make(map[string]string),
+		}
+		for column, index := range columns {
+			switch column {
+			case manifestSerialColumn:
+			case manifestHardwareColumn:
+				row.HardwareRevision = field(index)
+			default:
+				row.SensorSerials[entities.NormalizeSensorTypeCode(column)] = field(index)
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// parseJSONManifest lee un manifiesto JSON; los números de serie pueden ser cadenas o números
+func parseJSONManifest(r io.Reader) ([]ManifestRow, error) {
+	var items []map[string]interface{}
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	if err := decoder.Decode(&items); err != nil {
+		return nil, fmt.Errorf("invalid JSON manifest: %w", err)
+	}
+
+	rows := make([]ManifestRow, 0, len(items))
+	for i, item := range items {
+		row := ManifestRow{
+			Row:           i + 1,
+			SensorSerials: make(map[string]string),
+		}
+		for key, value := range item {
+			text := ""
+			switch value := value.(type) {
+			case string:
+				text = strings.TrimSpace(value)
+			case json.Number:
+				text = value.String()
+			case nil:
+			default:
+				return nil, fmt.Errorf("invalid JSON manifest: %s in row %d must be a string", key, i+1)
+			}
+
+			switch strings.ToLower(key) {
+			case manifestSerialColumn:
+				row.NumeroSerie = text
+			case manifestHardwareColumn:
+				row.HardwareRevision = text
+			default:
+				row.SensorSerials[entities.NormalizeSensorTypeCode(key)] = text
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,25 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// FetchDeviceConfigUseCase implementa el caso de uso con el que el firmware descarga su configuración
+type FetchDeviceConfigUseCase struct {
+	deviceConfigRepository repositories.DeviceConfigRepository
+}
+
+// NewFetchDeviceConfigUseCase crea una nueva instancia de FetchDeviceConfigUseCase
+func NewFetchDeviceConfigUseCase(deviceConfigRepo repositories.DeviceConfigRepository) *FetchDeviceConfigUseCase {
+	return &FetchDeviceConfigUseCase{
+		deviceConfigRepository: deviceConfigRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *FetchDeviceConfigUseCase) Execute(ctx context.Context, esp32ID int) (*entities.DeviceConfig, error) {
+	return findDeviceConfig(ctx, uc.deviceConfigRepository, esp32ID)
+}
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// GetAssignmentHistoryUseCase implementa el caso de uso para consultar quién fue dueño de un ESP32 y cuándo
+type GetAssignmentHistoryUseCase struct {
+	assignmentRepository repositories.ESP32AssignmentRepository
+	authorizer           *ESP32Authorizer
+}
+
+// NewGetAssignmentHistoryUseCase crea una nueva instancia de GetAssignmentHistoryUseCase
+func NewGetAssignmentHistoryUseCase(assignmentRepo repositories.ESP32AssignmentRepository, authorizer *ESP32Authorizer) *GetAssignmentHistoryUseCase {
+	return &GetAssignmentHistoryUseCase{
+		assignmentRepository: assignmentRepo,
+		authorizer:           authorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso. Solo el dueño actual y los administradores pueden consultar
+// el historial; el dueño ve los períodos de los dueños anteriores pero no sus emails.
+func (uc *GetAssignmentHistoryUseCase) Execute(ctx context.Context, esp32ID int, actor Actor) ([]*entities.ESP32Assignment, error) {
+	if _, err := uc.authorizer.Authorize(ctx, esp32ID, actor); err != nil {
+		return nil, err
+	}
+
+	assignments, err := uc.assignmentRepository.FindByESP32ID(ctx, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !actor.IsAdmin() {
+		for _, assignment := range assignments {
+			if assignment.UserID == nil || *assignment.UserID != actor.UserID {
+				assignment.UserID = nil
+				assignment.Email = ""
+			}
+		}
+	}
+
+	return assignments, nil
+}
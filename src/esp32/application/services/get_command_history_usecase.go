@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// commandHistoryLimit es la cantidad máxima de comandos que devuelve el historial
+const commandHistoryLimit = 100
+
+// GetCommandHistoryUseCase implementa el caso de uso para consultar los comandos enviados a un ESP32
+type GetCommandHistoryUseCase struct {
+	deviceCommandRepository repositories.DeviceCommandRepository
+	authorizer              *ESP32Authorizer
+}
+
+// NewGetCommandHistoryUseCase crea una nueva instancia de GetCommandHistoryUseCase
+func NewGetCommandHistoryUseCase(deviceCommandRepo repositories.DeviceCommandRepository, authorizer *ESP32Authorizer) *GetCommandHistoryUseCase {
+	return &GetCommandHistoryUseCase{
+		deviceCommandRepository: deviceCommandRepo,
+		authorizer:              authorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *GetCommandHistoryUseCase) Execute(ctx context.Context, esp32ID int, actor Actor) ([]*entities.DeviceCommand, error) {
+	if _, err := uc.authorizer.AuthorizeView(ctx, esp32ID, actor); err != nil {
+		return nil, err
+	}
+
+	return uc.deviceCommandRepository.FindByESP32ID(ctx, esp32ID, commandHistoryLimit)
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// GetCommissioningCertificateUseCase implementa el caso de uso para descargar el certificado firmado de un comisionamiento
+type GetCommissioningCertificateUseCase struct {
+	commissioningRepository repositories.CommissioningRepository
+	signer                  CertificateSigner
+	authorizer              *ESP32Authorizer
+}
+
+// NewGetCommissioningCertificateUseCase crea una nueva instancia de GetCommissioningCertificateUseCase
+func NewGetCommissioningCertificateUseCase(commissioningRepo repositories.CommissioningRepository, signer CertificateSigner, authorizer *ESP32Authorizer) *GetCommissioningCertificateUseCase {
+	return &GetCommissioningCertificateUseCase{
+		commissioningRepository: commissioningRepo,
+		signer:                  signer,
+		authorizer:              authorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *GetCommissioningCertificateUseCase) Execute(ctx context.Context, sessionID int, actor Actor) (*CommissioningCertificateResponse, error) {
+	session, err := uc.commissioningRepository.FindByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, ErrCommissioningNotFound
+	}
+	if err := authorizeCommissioningSession(ctx, uc.authorizer, session, actor, false); err != nil {
+		return nil, err
+	}
+
+	if session.Status != entities.CommissioningPassed || session.Certificate == nil {
+		return nil, ErrCertificateNotIssued
+	}
+	// Sin la clave no se puede informar con qué verificar la firma
+	if uc.signer == nil {
+		return nil, ErrCertificateSigningDisabled
+	}
+
+	return certificateResponse(session.Certificate, uc.signer), nil
+}
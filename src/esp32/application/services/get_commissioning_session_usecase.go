@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// GetCommissioningSessionUseCase implementa el caso de uso para consultar una sesión de comisionamiento con sus pruebas
+type GetCommissioningSessionUseCase struct {
+	commissioningRepository repositories.CommissioningRepository
+	authorizer              *ESP32Authorizer
+}
+
+// NewGetCommissioningSessionUseCase crea una nueva instancia de GetCommissioningSessionUseCase
+func NewGetCommissioningSessionUseCase(commissioningRepo repositories.CommissioningRepository, authorizer *ESP32Authorizer) *GetCommissioningSessionUseCase {
+	return &GetCommissioningSessionUseCase{
+		commissioningRepository: commissioningRepo,
+		authorizer:              authorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *GetCommissioningSessionUseCase) Execute(ctx context.Context, sessionID int, actor Actor) (*entities.CommissioningSession, error) {
+	session, err := uc.commissioningRepository.FindByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, ErrCommissioningNotFound
+	}
+
+	if err := authorizeCommissioningSession(ctx, uc.authorizer, session, actor, false); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
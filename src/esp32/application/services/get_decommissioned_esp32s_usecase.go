@@ -0,0 +1,25 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// GetDecommissionedESP32sUseCase implementa el caso de uso para listar los ESP32 retirados de servicio
+type GetDecommissionedESP32sUseCase struct {
+	esp32Repository repositories.ESP32Repository
+}
+
+// NewGetDecommissionedESP32sUseCase crea una nueva instancia de GetDecommissionedESP32sUseCase
+func NewGetDecommissionedESP32sUseCase(esp32Repo repositories.ESP32Repository) *GetDecommissionedESP32sUseCase {
+	return &GetDecommissionedESP32sUseCase{
+		esp32Repository: esp32Repo,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *GetDecommissionedESP32sUseCase) Execute(ctx context.Context) ([]*entities.ESP32, error) {
+	return uc.esp32Repository.FindDecommissioned(ctx)
+}
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// GetDeviceCommissioningUseCase implementa el caso de uso con el que un ESP32 consulta la sesión
+// de comisionamiento en curso para saber qué sensores le falta probar
+type GetDeviceCommissioningUseCase struct {
+	commissioningRepository repositories.CommissioningRepository
+}
+
+// NewGetDeviceCommissioningUseCase crea una nueva instancia de GetDeviceCommissioningUseCase
+func NewGetDeviceCommissioningUseCase(commissioningRepo repositories.CommissioningRepository) *GetDeviceCommissioningUseCase {
+	return &GetDeviceCommissioningUseCase{
+		commissioningRepository: commissioningRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso para el ESP32 autenticado
+func (uc *GetDeviceCommissioningUseCase) Execute(ctx context.Context, esp32ID int) (*entities.CommissioningSession, error) {
+	session, err := uc.commissioningRepository.FindOpenByESP32ID(ctx, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, ErrNoOpenCommissioning
+	}
+
+	return session, nil
+}
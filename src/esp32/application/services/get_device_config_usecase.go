@@ -0,0 +1,45 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// GetDeviceConfigUseCase implementa el caso de uso para consultar la configuración remota de un ESP32
+type GetDeviceConfigUseCase struct {
+	deviceConfigRepository repositories.DeviceConfigRepository
+	authorizer             *ESP32Authorizer
+}
+
+// NewGetDeviceConfigUseCase crea una nueva instancia de GetDeviceConfigUseCase
+func NewGetDeviceConfigUseCase(deviceConfigRepo repositories.DeviceConfigRepository, authorizer *ESP32Authorizer) *GetDeviceConfigUseCase {
+	return &GetDeviceConfigUseCase{
+		deviceConfigRepository: deviceConfigRepo,
+		authorizer:             authorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *GetDeviceConfigUseCase) Execute(ctx context.Context, esp32ID int, actor Actor) (*entities.DeviceConfig, error) {
+	if _, err := uc.authorizer.AuthorizeView(ctx, esp32ID, actor); err != nil {
+		return nil, err
+	}
+
+	return findDeviceConfig(ctx, uc.deviceConfigRepository, esp32ID)
+}
+
+// findDeviceConfig devuelve la configuración vigente o la configuración por defecto
+// si el ESP32 nunca fue configurado
+func findDeviceConfig(ctx context.Context, repo repositories.DeviceConfigRepository, esp32ID int) (*entities.DeviceConfig, error) {
+	config, err := repo.FindByESP32ID(ctx, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		return entities.NewDefaultDeviceConfig(esp32ID), nil
+	}
+
+	return config, nil
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// GetDeviceEventsUseCase implementa el caso de uso para consultar la conectividad de un ESP32
+type GetDeviceEventsUseCase struct {
+	deviceEventRepository repositories.DeviceEventRepository
+	authorizer            *ESP32Authorizer
+}
+
+// NewGetDeviceEventsUseCase crea una nueva instancia de GetDeviceEventsUseCase
+func NewGetDeviceEventsUseCase(deviceEventRepo repositories.DeviceEventRepository, authorizer *ESP32Authorizer) *GetDeviceEventsUseCase {
+	return &GetDeviceEventsUseCase{
+		deviceEventRepository: deviceEventRepo,
+		authorizer:            authorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *GetDeviceEventsUseCase) Execute(ctx context.Context, esp32ID int, actor Actor) ([]*entities.DeviceEvent, error) {
+	if _, err := uc.authorizer.AuthorizeView(ctx, esp32ID, actor); err != nil {
+		return nil, err
+	}
+
+	return uc.deviceEventRepository.FindByESP32ID(ctx, esp32ID)
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// GetESP32CommissioningUseCase implementa el caso de uso para consultar las sesiones de comisionamiento de un ESP32
+type GetESP32CommissioningUseCase struct {
+	commissioningRepository repositories.CommissioningRepository
+	authorizer              *ESP32Authorizer
+}
+
+// NewGetESP32CommissioningUseCase crea una nueva instancia de GetESP32CommissioningUseCase
+func NewGetESP32CommissioningUseCase(commissioningRepo repositories.CommissioningRepository, authorizer *ESP32Authorizer) *GetESP32CommissioningUseCase {
+	return &GetESP32CommissioningUseCase{
+		commissioningRepository: commissioningRepo,
+		authorizer:              authorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *GetESP32CommissioningUseCase) Execute(ctx context.Context, esp32ID int, actor Actor) ([]*entities.CommissioningSession, error) {
+	if err := authorizeCommissioningESP32(ctx, uc.authorizer, esp32ID, actor); err != nil {
+		return nil, err
+	}
+
+	sessions, err := uc.commissioningRepository.FindByESP32ID(ctx, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+	if sessions == nil {
+		sessions = []*entities.CommissioningSession{}
+	}
+
+	return sessions, nil
+}
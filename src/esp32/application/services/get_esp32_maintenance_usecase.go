@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// maintenanceHistoryLimit es la cantidad de realizaciones que se devuelven junto con las tareas
+const maintenanceHistoryLimit = 50
+
+// ESP32Maintenance reúne las tareas programadas de un ESP32 y sus últimas realizaciones
+type ESP32Maintenance struct {
+	Schedules []*entities.MaintenanceSchedule `json:"schedules"`
+	Records   []*entities.MaintenanceRecord   `json:"records"`
+}
+
+// GetESP32MaintenanceUseCase implementa el caso de uso para consultar el mantenimiento de un ESP32
+type GetESP32MaintenanceUseCase struct {
+	maintenanceRepository repositories.MaintenanceRepository
+	authorizer            *ESP32Authorizer
+}
+
+// NewGetESP32MaintenanceUseCase crea una nueva instancia de GetESP32MaintenanceUseCase
+func NewGetESP32MaintenanceUseCase(maintenanceRepo repositories.MaintenanceRepository, authorizer *ESP32Authorizer) *GetESP32MaintenanceUseCase {
+	return &GetESP32MaintenanceUseCase{
+		maintenanceRepository: maintenanceRepo,
+		authorizer:            authorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *GetESP32MaintenanceUseCase) Execute(ctx context.Context, esp32ID int, actor Actor) (*ESP32Maintenance, error) {
+	if _, err := uc.authorizer.AuthorizeView(ctx, esp32ID, actor); err != nil {
+		return nil, err
+	}
+
+	schedules, err := uc.maintenanceRepository.FindSchedulesByESP32ID(ctx, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+	records, err := uc.maintenanceRepository.FindRecordsByESP32ID(ctx, esp32ID, maintenanceHistoryLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	maintenance := &ESP32Maintenance{Schedules: schedules, Records: records}
+	if maintenance.Schedules == nil {
+		maintenance.Schedules = []*entities.MaintenanceSchedule{}
+	}
+	if maintenance.Records == nil {
+		maintenance.Records = []*entities.MaintenanceRecord{}
+	}
+
+	return maintenance, nil
+}
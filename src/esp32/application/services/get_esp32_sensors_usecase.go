@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// GetESP32SensorsUseCase implementa el caso de uso para listar los sensores instalados en un ESP32
+type GetESP32SensorsUseCase struct {
+	deviceSensorRepository repositories.DeviceSensorRepository
+	authorizer             *ESP32Authorizer
+}
+
+// NewGetESP32SensorsUseCase crea una nueva instancia de GetESP32SensorsUseCase
+func NewGetESP32SensorsUseCase(deviceSensorRepo repositories.DeviceSensorRepository, authorizer *ESP32Authorizer) *GetESP32SensorsUseCase {
+	return &GetESP32SensorsUseCase{
+		deviceSensorRepository: deviceSensorRepo,
+		authorizer:             authorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *GetESP32SensorsUseCase) Execute(ctx context.Context, esp32ID int, actor Actor) ([]*entities.DeviceSensor, error) {
+	if _, err := uc.authorizer.AuthorizeView(ctx, esp32ID, actor); err != nil {
+		return nil, err
+	}
+
+	sensors, err := uc.deviceSensorRepository.FindByESP32ID(ctx, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+	if sensors == nil {
+		sensors = []*entities.DeviceSensor{}
+	}
+
+	return sensors, nil
+}
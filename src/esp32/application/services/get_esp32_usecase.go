@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// GetESP32UseCase implementa el caso de uso para obtener un ESP32 del usuario
+type GetESP32UseCase struct {
+	deviceSensorRepository repositories.DeviceSensorRepository
+	authorizer             *ESP32Authorizer
+}
+
+// NewGetESP32UseCase crea una nueva instancia de GetESP32UseCase
+func NewGetESP32UseCase(deviceSensorRepo repositories.DeviceSensorRepository, authorizer *ESP32Authorizer) *GetESP32UseCase {
+	return &GetESP32UseCase{
+		deviceSensorRepository: deviceSensorRepo,
+		authorizer:             authorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso; el ESP32 se devuelve con sus sensores instalados
+func (uc *GetESP32UseCase) Execute(ctx context.Context, esp32ID int, actor Actor) (*entities.ESP32, error) {
+	esp32, err := uc.authorizer.AuthorizeView(ctx, esp32ID, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	sensors, err := uc.deviceSensorRepository.FindByESP32ID(ctx, esp32.ID)
+	if err != nil {
+		return nil, err
+	}
+	esp32.Sensors = sensors
+
+	return esp32, nil
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// DeviceCompliance es el cumplimiento del mantenimiento de un ESP32 dentro del reporte de un grupo
+type DeviceCompliance struct {
+	*entities.ESP32
+	// Última prueba mensual registrada, aprobada o no; nula si nunca se probó
+	LastTest  *entities.MaintenanceRecord     `json:"last_test"`
+	Schedules []*entities.MaintenanceSchedule `json:"schedules"`
+	Compliant bool                            `json:"compliant"`
+	Issues    []string                        `json:"issues"` // Motivos por los que el ESP32 no cumple
+}
+
+// ComplianceSummary cuenta los ESP32 y las tareas de un grupo según su cumplimiento
+type ComplianceSummary struct {
+	Devices      int `json:"devices"`
+	Compliant    int `json:"compliant"`
+	NonCompliant int `json:"non_compliant"`
+	NeverTested  int `json:"never_tested"`
+	TasksDue     int `json:"tasks_due"`
+	TasksOverdue int `json:"tasks_overdue"`
+	TasksFailed  int `json:"tasks_failed"`
+}
+
+// ComplianceReport es el reporte de mantenimiento de los ESP32 de un grupo y de sus subgrupos
+type ComplianceReport struct {
+	Group       *entities.DeviceGroup `json:"group"`
+	GeneratedAt time.Time             `json:"generated_at"`
+	Summary     ComplianceSummary     `json:"summary"`
+	Devices     []*DeviceCompliance   `json:"devices"`
+}
+
+// GetGroupComplianceUseCase implementa el caso de uso para generar el reporte de cumplimiento de un grupo
+type GetGroupComplianceUseCase struct {
+	esp32Repository       repositories.ESP32Repository
+	maintenanceRepository repositories.MaintenanceRepository
+	groupAuthorizer       *GroupAuthorizer
+}
+
+// NewGetGroupComplianceUseCase crea una nueva instancia de GetGroupComplianceUseCase
+func NewGetGroupComplianceUseCase(
+	esp32Repo repositories.ESP32Repository,
+	maintenanceRepo repositories.MaintenanceRepository,
+	groupAuthorizer *GroupAuthorizer,
+) *GetGroupComplianceUseCase {
+	return &GetGroupComplianceUseCase{
+		esp32Repository:       esp32Repo,
+		maintenanceRepository: maintenanceRepo,
+		groupAuthorizer:       groupAuthorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *GetGroupComplianceUseCase) Execute(ctx context.Context, groupID int, actor Actor) (*ComplianceReport, error) {
+	group, err := uc.groupAuthorizer.Authorize(ctx, groupID, actor, GroupAccessViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	groupIDs, err := uc.groupAuthorizer.Subtree(ctx, group)
+	if err != nil {
+		return nil, err
+	}
+	esp32s, err := uc.esp32Repository.FindByGroupIDs(ctx, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	esp32IDs := make([]int, len(esp32s))
+	for i, esp32 := range esp32s {
+		esp32IDs[i] = esp32.ID
+	}
+	schedules, err := uc.maintenanceRepository.FindSchedulesByESP32IDs(ctx, esp32IDs)
+	if err != nil {
+		return nil, err
+	}
+	lastTests, err := uc.maintenanceRepository.FindLatestRecords(ctx, esp32IDs, entities.MaintenanceMonthlyTest)
+	if err != nil {
+		return nil, err
+	}
+	schedulesByESP32 := make(map[int][]*entities.MaintenanceSchedule)
+	for _, schedule := range schedules {
+		schedulesByESP32[schedule.ESP32ID] = append(schedulesByESP32[schedule.ESP32ID], schedule)
+	}
+
+	report := &ComplianceReport{Group: group, GeneratedAt: time.Now(), Devices: make([]*DeviceCompliance, 0, len(esp32s))}
+	for _, esp32 := range esp32s {
+		device := &DeviceCompliance{
+			ESP32:     esp32,
+			LastTest:  lastTests[esp32.ID],
+			Schedules: schedulesByESP32[esp32.ID],
+			Issues:    []string{},
+		}
+		if device.Schedules == nil {
+			device.Schedules = []*entities.MaintenanceSchedule{}
+		}
+
+		if !hasSchedule(device.Schedules, entities.MaintenanceMonthlyTest, nil) {
+			device.Issues = append(device.Issues, "monthly_test is not scheduled")
+		}
+		for _, schedule := range device.Schedules {
+			switch schedule.Status {
+			case entities.MaintenanceDue:
+				report.Summary.TasksDue++
+			case entities.MaintenanceOverdue:
+				report.Summary.TasksOverdue++
+				device.Issues = append(device.Issues, fmt.Sprintf("%s is overdue since %s", scheduleLabel(schedule), schedule.DueAt.Format(time.DateOnly)))
+			case entities.MaintenanceFailed:
+				report.Summary.TasksFailed++
+				device.Issues = append(device.Issues, fmt.Sprintf("%s failed on its last check", scheduleLabel(schedule)))
+			}
+		}
+		device.Compliant = len(device.Issues) == 0
+
+		report.Summary.Devices++
+		if device.Compliant {
+			report.Summary.Compliant++
+		} else {
+			report.Summary.NonCompliant++
+		}
+		if device.LastTest == nil {
+			report.Summary.NeverTested++
+		}
+		report.Devices = append(report.Devices, device)
+	}
+
+	return report, nil
+}
+
+// scheduleLabel describe la tarea en los motivos de incumplimiento, incluyendo el sensor si corresponde
+func scheduleLabel(schedule *entities.MaintenanceSchedule) string {
+	if schedule.SensorID != nil {
+		return fmt.Sprintf("%s of sensor %d", schedule.Task, *schedule.SensorID)
+	}
+	return string(schedule.Task)
+}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// GetGroupMembersUseCase implementa el caso de uso para listar los miembros de un grupo
+type GetGroupMembersUseCase struct {
+	groupRepository repositories.DeviceGroupRepository
+	groupAuthorizer *GroupAuthorizer
+}
+
+// NewGetGroupMembersUseCase crea una nueva instancia de GetGroupMembersUseCase
+func NewGetGroupMembersUseCase(groupRepo repositories.DeviceGroupRepository, groupAuthorizer *GroupAuthorizer) *GetGroupMembersUseCase {
+	return &GetGroupMembersUseCase{
+		groupRepository: groupRepo,
+		groupAuthorizer: groupAuthorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso; los miembros con rol viewer no pueden ver a los demás miembros
+func (uc *GetGroupMembersUseCase) Execute(ctx context.Context, groupID int, actor Actor) ([]*entities.GroupMember, error) {
+	group, err := uc.groupAuthorizer.Authorize(ctx, groupID, actor, GroupAccessManager)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := uc.groupRepository.FindMembers(ctx, group.ID)
+	if err != nil {
+		return nil, err
+	}
+	if members == nil {
+		members = []*entities.GroupMember{}
+	}
+
+	return members, nil
+}
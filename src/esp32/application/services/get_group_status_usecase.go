@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// GroupDeviceStatus es el estado de un ESP32 dentro del resumen de un grupo
+type GroupDeviceStatus struct {
+	*entities.ESP32
+	// Sensores del ESP32 con una alarma activa
+	ActiveAlarms []*entities.DeviceSensor `json:"active_alarms"`
+}
+
+// GroupStatusSummary cuenta los ESP32 de un grupo según su estado
+type GroupStatusSummary struct {
+	Devices int `json:"devices"`
+	Online  int `json:"online"`
+	Offline int `json:"offline"`
+	InAlarm int `json:"in_alarm"`
+}
+
+// GroupStatus es el estado de todos los ESP32 de un grupo y de sus subgrupos
+type GroupStatus struct {
+	Group   *entities.DeviceGroup `json:"group"`
+	Summary GroupStatusSummary    `json:"summary"`
+	Devices []*GroupDeviceStatus  `json:"devices"`
+}
+
+// GetGroupStatusUseCase implementa el caso de uso para consultar el estado de los ESP32 de un grupo
+type GetGroupStatusUseCase struct {
+	esp32Repository        repositories.ESP32Repository
+	deviceSensorRepository repositories.DeviceSensorRepository
+	groupAuthorizer        *GroupAuthorizer
+}
+
+// NewGetGroupStatusUseCase crea una nueva instancia de GetGroupStatusUseCase
+func NewGetGroupStatusUseCase(
+	esp32Repo repositories.ESP32Repository,
+	deviceSensorRepo repositories.DeviceSensorRepository,
+	groupAuthorizer *GroupAuthorizer,
+) *GetGroupStatusUseCase {
+	return &GetGroupStatusUseCase{
+		esp32Repository:        esp32Repo,
+		deviceSensorRepository: deviceSensorRepo,
+		groupAuthorizer:        groupAuthorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *GetGroupStatusUseCase) Execute(ctx context.Context, groupID int, actor Actor) (*GroupStatus, error) {
+	group, err := uc.groupAuthorizer.Authorize(ctx, groupID, actor, GroupAccessViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	groupIDs, err := uc.groupAuthorizer.Subtree(ctx, group)
+	if err != nil {
+		return nil, err
+	}
+	esp32s, err := uc.esp32Repository.FindByGroupIDs(ctx, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	esp32IDs := make([]int, len(esp32s))
+	for i, esp32 := range esp32s {
+		esp32IDs[i] = esp32.ID
+	}
+	sensors, err := uc.deviceSensorRepository.FindByESP32IDs(ctx, esp32IDs)
+	if err != nil {
+		return nil, err
+	}
+	alarms := make(map[int][]*entities.DeviceSensor)
+	for _, sensor := range sensors {
+		if sensor.Alarm {
+			alarms[sensor.ESP32ID] = append(alarms[sensor.ESP32ID], sensor)
+		}
+	}
+
+	status := &GroupStatus{Group: group, Devices: make([]*GroupDeviceStatus, 0, len(esp32s))}
+	for _, esp32 := range esp32s {
+		device := &GroupDeviceStatus{ESP32: esp32, ActiveAlarms: alarms[esp32.ID]}
+		if device.ActiveAlarms == nil {
+			device.ActiveAlarms = []*entities.DeviceSensor{}
+		}
+
+		status.Summary.Devices++
+		if esp32.Online {
+			status.Summary.Online++
+		} else {
+			status.Summary.Offline++
+		}
+		if len(device.ActiveAlarms) > 0 {
+			status.Summary.InAlarm++
+		}
+		status.Devices = append(status.Devices, device)
+	}
+
+	return status, nil
+}
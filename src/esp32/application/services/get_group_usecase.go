@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// GetGroupUseCase implementa el caso de uso para consultar un grupo con sus subgrupos directos
+type GetGroupUseCase struct {
+	groupRepository repositories.DeviceGroupRepository
+	groupAuthorizer *GroupAuthorizer
+}
+
+// NewGetGroupUseCase crea una nueva instancia de GetGroupUseCase
+func NewGetGroupUseCase(groupRepo repositories.DeviceGroupRepository, groupAuthorizer *GroupAuthorizer) *GetGroupUseCase {
+	return &GetGroupUseCase{
+		groupRepository: groupRepo,
+		groupAuthorizer: groupAuthorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *GetGroupUseCase) Execute(ctx context.Context, groupID int, actor Actor) (*entities.DeviceGroup, error) {
+	group, err := uc.groupAuthorizer.Authorize(ctx, groupID, actor, GroupAccessViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	children, err := uc.groupRepository.FindByParentID(ctx, group.ID)
+	if err != nil {
+		return nil, err
+	}
+	group.Children = children
+
+	return group, nil
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// GetGroupsUseCase implementa el caso de uso para listar los grupos a los que el usuario tiene acceso
+type GetGroupsUseCase struct {
+	groupRepository repositories.DeviceGroupRepository
+}
+
+// NewGetGroupsUseCase crea una nueva instancia de GetGroupsUseCase
+func NewGetGroupsUseCase(groupRepo repositories.DeviceGroupRepository) *GetGroupsUseCase {
+	return &GetGroupsUseCase{
+		groupRepository: groupRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso. Devuelve los grupos del usuario y aquellos de los que es
+// miembro; los subgrupos de estos últimos se consultan a partir de cada grupo.
+func (uc *GetGroupsUseCase) Execute(ctx context.Context, actor Actor) ([]*entities.DeviceGroup, error) {
+	groups, err := uc.groupRepository.FindByOwnerID(ctx, actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	memberships, err := uc.groupRepository.FindMembershipsByUserID(ctx, actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int, 0, len(memberships))
+	for _, membership := range memberships {
+		ids = append(ids, membership.GroupID)
+	}
+	shared, err := uc.groupRepository.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[int]bool, len(groups))
+	for _, group := range groups {
+		seen[group.ID] = true
+	}
+	for _, group := range shared {
+		if !seen[group.ID] {
+			groups = append(groups, group)
+		}
+	}
+
+	if groups == nil {
+		groups = []*entities.DeviceGroup{}
+	}
+
+	return groups, nil
+}
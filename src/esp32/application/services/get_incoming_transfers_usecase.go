@@ -0,0 +1,37 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+	userRepo "hex_go/src/users/domain/repositories"
+)
+
+// GetIncomingTransfersUseCase implementa el caso de uso para listar los ESP32 ofrecidos a un usuario
+type GetIncomingTransfersUseCase struct {
+	transferRepository repositories.ESP32TransferRepository
+	userRepository     userRepo.UserRepository
+}
+
+// NewGetIncomingTransfersUseCase crea una nueva instancia de GetIncomingTransfersUseCase
+func NewGetIncomingTransfersUseCase(transferRepo repositories.ESP32TransferRepository, userRepo userRepo.UserRepository) *GetIncomingTransfersUseCase {
+	return &GetIncomingTransfersUseCase{
+		transferRepository: transferRepo,
+		userRepository:     userRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *GetIncomingTransfersUseCase) Execute(ctx context.Context, userID int) ([]*entities.ESP32Transfer, error) {
+	user, err := uc.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+
+	return uc.transferRepository.FindPendingByEmail(ctx, user.Email)
+}
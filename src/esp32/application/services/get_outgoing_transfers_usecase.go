@@ -0,0 +1,25 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// GetOutgoingTransfersUseCase implementa el caso de uso para listar las transferencias iniciadas por un usuario
+type GetOutgoingTransfersUseCase struct {
+	transferRepository repositories.ESP32TransferRepository
+}
+
+// NewGetOutgoingTransfersUseCase crea una nueva instancia de GetOutgoingTransfersUseCase
+func NewGetOutgoingTransfersUseCase(transferRepo repositories.ESP32TransferRepository) *GetOutgoingTransfersUseCase {
+	return &GetOutgoingTransfersUseCase{
+		transferRepository: transferRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *GetOutgoingTransfersUseCase) Execute(ctx context.Context, userID int) ([]*entities.ESP32Transfer, error) {
+	return uc.transferRepository.FindByFromUserID(ctx, userID)
+}
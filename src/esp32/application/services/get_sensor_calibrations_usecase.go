@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// GetSensorCalibrationsUseCase implementa el caso de uso para consultar el historial y el
+// vencimiento de la calibración de un sensor de gas
+type GetSensorCalibrationsUseCase struct {
+	deviceSensorRepository      repositories.DeviceSensorRepository
+	sensorTypeRepository        repositories.SensorTypeRepository
+	sensorCalibrationRepository repositories.SensorCalibrationRepository
+	authorizer                  *ESP32Authorizer
+}
+
+// NewGetSensorCalibrationsUseCase crea una nueva instancia de GetSensorCalibrationsUseCase
+func NewGetSensorCalibrationsUseCase(
+	deviceSensorRepo repositories.DeviceSensorRepository,
+	sensorTypeRepo repositories.SensorTypeRepository,
+	sensorCalibrationRepo repositories.SensorCalibrationRepository,
+	authorizer *ESP32Authorizer,
+) *GetSensorCalibrationsUseCase {
+	return &GetSensorCalibrationsUseCase{
+		deviceSensorRepository:      deviceSensorRepo,
+		sensorTypeRepository:        sensorTypeRepo,
+		sensorCalibrationRepository: sensorCalibrationRepo,
+		authorizer:                  authorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *GetSensorCalibrationsUseCase) Execute(ctx context.Context, esp32ID, sensorID int, actor Actor) (*CalibrationStatus, error) {
+	if _, err := uc.authorizer.AuthorizeView(ctx, esp32ID, actor); err != nil {
+		return nil, err
+	}
+
+	sensor, err := uc.deviceSensorRepository.FindByID(ctx, sensorID)
+	if err != nil {
+		return nil, err
+	}
+	if sensor == nil || sensor.ESP32ID != esp32ID {
+		return nil, ErrSensorNotFound
+	}
+	sensorType, err := calibratableSensorType(ctx, uc.sensorTypeRepository, sensor)
+	if err != nil {
+		return nil, err
+	}
+
+	calibrations, err := uc.sensorCalibrationRepository.FindBySensorID(ctx, sensor.ID)
+	if err != nil {
+		return nil, err
+	}
+	if calibrations == nil {
+		calibrations = []*entities.SensorCalibration{}
+	}
+
+	var latest *entities.SensorCalibration
+	if len(calibrations) > 0 {
+		latest = calibrations[0]
+	}
+	dueAt := calibrationDueAt(sensor, sensorType, latest)
+
+	return &CalibrationStatus{
+		SensorID:     sensor.ID,
+		SensorType:   sensor.SensorType,
+		IntervalDays: *sensorType.CalibrationIntervalDays,
+		DueAt:        dueAt,
+		Overdue:      time.Now().After(dueAt),
+		Calibrations: calibrations,
+	}, nil
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// GetSensorReplacementsUseCase implementa el caso de uso para consultar el historial de reemplazos de sensores de un ESP32
+type GetSensorReplacementsUseCase struct {
+	deviceSensorRepository repositories.DeviceSensorRepository
+	authorizer             *ESP32Authorizer
+}
+
+// NewGetSensorReplacementsUseCase crea una nueva instancia de GetSensorReplacementsUseCase
+func NewGetSensorReplacementsUseCase(deviceSensorRepo repositories.DeviceSensorRepository, authorizer *ESP32Authorizer) *GetSensorReplacementsUseCase {
+	return &GetSensorReplacementsUseCase{
+		deviceSensorRepository: deviceSensorRepo,
+		authorizer:             authorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *GetSensorReplacementsUseCase) Execute(ctx context.Context, esp32ID int, actor Actor) ([]*entities.SensorReplacement, error) {
+	if _, err := uc.authorizer.AuthorizeView(ctx, esp32ID, actor); err != nil {
+		return nil, err
+	}
+
+	replacements, err := uc.deviceSensorRepository.FindReplacementsByESP32ID(ctx, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+	if replacements == nil {
+		replacements = []*entities.SensorReplacement{}
+	}
+
+	return replacements, nil
+}
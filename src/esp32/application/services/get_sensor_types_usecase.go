@@ -0,0 +1,25 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// GetSensorTypesUseCase implementa el caso de uso para listar los tipos de sensor registrados
+type GetSensorTypesUseCase struct {
+	sensorTypeRepository repositories.SensorTypeRepository
+}
+
+// NewGetSensorTypesUseCase crea una nueva instancia de GetSensorTypesUseCase
+func NewGetSensorTypesUseCase(sensorTypeRepo repositories.SensorTypeRepository) *GetSensorTypesUseCase {
+	return &GetSensorTypesUseCase{
+		sensorTypeRepository: sensorTypeRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *GetSensorTypesUseCase) Execute(ctx context.Context) ([]*entities.SensorType, error) {
+	return uc.sensorTypeRepository.FindAll(ctx)
+}
@@ -0,0 +1,25 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// GetUnassignedESP32sUseCase implementa el caso de uso para listar los ESP32 en stock
+type GetUnassignedESP32sUseCase struct {
+	esp32Repository repositories.ESP32Repository
+}
+
+// NewGetUnassignedESP32sUseCase crea una nueva instancia de GetUnassignedESP32sUseCase
+func NewGetUnassignedESP32sUseCase(esp32Repo repositories.ESP32Repository) *GetUnassignedESP32sUseCase {
+	return &GetUnassignedESP32sUseCase{
+		esp32Repository: esp32Repo,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *GetUnassignedESP32sUseCase) Execute(ctx context.Context) ([]*entities.ESP32, error) {
+	return uc.esp32Repository.FindUnassigned(ctx)
+}
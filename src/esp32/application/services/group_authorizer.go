@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// maxGroupDepth limita el recorrido de la jerarquía de grupos: sitio, edificio, piso y zona
+const maxGroupDepth = 4
+
+// GroupAccess representa el nivel de permiso de un actor sobre un grupo
+type GroupAccess int
+
+const (
+	GroupAccessNone    GroupAccess = iota
+	GroupAccessViewer              // Ve los ESP32 del grupo y sus subgrupos
+	GroupAccessManager             // Además opera los ESP32: configuración, comandos y datos descriptivos
+	GroupAccessOwner               // Además administra la jerarquía, los miembros y los ESP32 asignados
+)
+
+// GroupAuthorizer calcula los permisos de un actor sobre los grupos de ESP32.
+// El rol de un miembro se hereda en todos los subgrupos del grupo al que pertenece.
+type GroupAuthorizer struct {
+	groupRepository repositories.DeviceGroupRepository
+}
+
+// NewGroupAuthorizer crea una nueva instancia de GroupAuthorizer
+func NewGroupAuthorizer(groupRepo repositories.DeviceGroupRepository) *GroupAuthorizer {
+	return &GroupAuthorizer{
+		groupRepository: groupRepo,
+	}
+}
+
+// Authorize devuelve el grupo si el actor tiene al menos el nivel de permiso indicado
+func (a *GroupAuthorizer) Authorize(ctx context.Context, groupID int, actor Actor, required GroupAccess) (*entities.DeviceGroup, error) {
+	group, err := a.groupRepository.FindByID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, ErrGroupNotFound
+	}
+
+	access, err := a.Access(ctx, group, actor)
+	if err != nil {
+		return nil, err
+	}
+	if access < required {
+		return nil, ErrGroupForbidden
+	}
+
+	return group, nil
+}
+
+// Access calcula el nivel de permiso del actor sobre el grupo
+func (a *GroupAuthorizer) Access(ctx context.Context, group *entities.DeviceGroup, actor Actor) (GroupAccess, error) {
+	if actor.IsAdmin() || group.OwnerID == actor.UserID {
+		return GroupAccessOwner, nil
+	}
+
+	memberships, err := a.groupRepository.FindMembershipsByUserID(ctx, actor.UserID)
+	if err != nil {
+		return GroupAccessNone, err
+	}
+	if len(memberships) == 0 {
+		return GroupAccessNone, nil
+	}
+	roles := make(map[int]entities.GroupRole, len(memberships))
+	for _, membership := range memberships {
+		roles[membership.GroupID] = membership.Role
+	}
+
+	// Se recorre el grupo y sus ancestros y se conserva el rol más alto encontrado
+	access := GroupAccessNone
+	current := group
+	for depth := 0; current != nil && depth < maxGroupDepth; depth++ {
+		switch roles[current.ID] {
+		case entities.GroupRoleManager:
+			return GroupAccessManager, nil
+		case entities.GroupRoleViewer:
+			access = GroupAccessViewer
+		}
+
+		if current.ParentID == nil {
+			break
+		}
+		current, err = a.groupRepository.FindByID(ctx, *current.ParentID)
+		if err != nil {
+			return GroupAccessNone, err
+		}
+	}
+
+	return access, nil
+}
+
+// accessByID calcula el nivel de permiso del actor sobre el grupo indicado; si el grupo
+// ya no existe el actor no tiene permisos
+func (a *GroupAuthorizer) accessByID(ctx context.Context, groupID int, actor Actor) (GroupAccess, error) {
+	group, err := a.groupRepository.FindByID(ctx, groupID)
+	if err != nil {
+		return GroupAccessNone, err
+	}
+	if group == nil {
+		return GroupAccessNone, nil
+	}
+
+	return a.Access(ctx, group, actor)
+}
+
+// MemberGroupIDs devuelve los IDs de los grupos de los que el usuario es miembro, con cualquier
+// rol, y de todos sus subgrupos; el usuario ve los ESP32 que pertenecen a ellos
+func (a *GroupAuthorizer) MemberGroupIDs(ctx context.Context, userID int) ([]int, error) {
+	memberships, err := a.groupRepository.FindMembershipsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []int
+	seen := make(map[int]bool)
+	for _, membership := range memberships {
+		if seen[membership.GroupID] {
+			continue
+		}
+		group, err := a.groupRepository.FindByID(ctx, membership.GroupID)
+		if err != nil {
+			return nil, err
+		}
+		if group == nil {
+			continue
+		}
+		subtree, err := a.Subtree(ctx, group)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range subtree {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	return ids, nil
+}
+
+// Subtree devuelve los IDs del grupo y de todos sus subgrupos
+func (a *GroupAuthorizer) Subtree(ctx context.Context, group *entities.DeviceGroup) ([]int, error) {
+	ids := []int{group.ID}
+	level := []int{group.ID}
+	for depth := 1; len(level) > 0 && depth < maxGroupDepth; depth++ {
+		var next []int
+		for _, parentID := range level {
+			children, err := a.groupRepository.FindByParentID(ctx, parentID)
+			if err != nil {
+				return nil, err
+			}
+			for _, child := range children {
+				next = append(next, child.ID)
+			}
+		}
+		ids = append(ids, next...)
+		level = next
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"testing"
+)
+
+func TestGroupAuthorizerMemberGroupIDs(t *testing.T) {
+	authorizer := NewGroupAuthorizer(newTestGroupRepository())
+
+	tests := []struct {
+		name   string
+		userID int
+		want   []int
+	}{
+		{"manager del edificio ve el edificio y sus pisos", managerID, []int{buildingID, floorID}},
+		{"viewer del piso ve solo el piso", viewerID, []int{floorID}},
+		{"el dueño no es miembro de sus grupos", ownerID, nil},
+		{"otro usuario no ve ningún grupo", otherID, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := authorizer.MemberGroupIDs(context.Background(), tt.userID)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			sort.Ints(got)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got groups %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got groups %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
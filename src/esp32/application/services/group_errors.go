@@ -0,0 +1,12 @@
+package services
+
+import "errors"
+
+var (
+	// ErrGroupNotFound se devuelve cuando el grupo solicitado no existe
+	ErrGroupNotFound = errors.New("group not found")
+	// ErrGroupForbidden se devuelve cuando el usuario no tiene permiso sobre el grupo
+	ErrGroupForbidden = errors.New("you do not have access to this group")
+	// ErrGroupHasChildren se devuelve al eliminar un grupo que todavía tiene subgrupos
+	ErrGroupHasChildren = errors.New("group has subgroups, delete them first")
+)
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"unicode/utf8"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// Estados de cada fila en el reporte de importación
+const (
+	ImportRowValid   = "valid"
+	ImportRowInvalid = "invalid"
+	ImportRowCreated = "created"
+)
+
+// ImportRowResult es el resultado de una fila del manifiesto. El código de reclamo y el token
+// solo se devuelven cuando el ESP32 se creó, y no se pueden volver a consultar.
+type ImportRowResult struct {
+	Row         int      `json:"row"`
+	NumeroSerie string   `json:"numero_serie"`
+	Status      string   `json:"status"`
+	Errors      []string `json:"errors,omitempty"`
+	ESP32ID     int      `json:"esp32_id,omitempty"`
+	ClaimCode   string   `json:"claim_code,omitempty"`
+	DeviceToken string   `json:"device_token,omitempty"`
+}
+
+// ImportReport resume la importación de un manifiesto
+type ImportReport struct {
+	DryRun  bool              `json:"dry_run"`
+	Total   int               `json:"total"`
+	Valid   int               `json:"valid"`
+	Invalid int               `json:"invalid"`
+	Created int               `json:"created"`
+	Rows    []ImportRowResult `json:"rows"`
+}
+
+// ImportESP32sUseCase implementa el caso de uso para dar de alta los ESP32 de un manifiesto de fábrica
+type ImportESP32sUseCase struct {
+	esp32Repository      repositories.ESP32Repository
+	sensorTypeRepository repositories.SensorTypeRepository
+}
+
+// NewImportESP32sUseCase crea una nueva instancia de ImportESP32sUseCase
+func NewImportESP32sUseCase(esp32Repo repositories.ESP32Repository, sensorTypeRepo repositories.SensorTypeRepository) *ImportESP32sUseCase {
+	return &ImportESP32sUseCase{
+		esp32Repository:      esp32Repo,
+		sensorTypeRepository: sensorTypeRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso. Todas las filas se validan antes de crear nada; si alguna
+// es inválida o dryRun es verdadero, solo se devuelve el reporte. En caso contrario todos los
+// ESP32 se crean en una misma transacción. Los sensores del kit por defecto son obligatorios
+// y los de otros tipos registrados se instalan solo si la fila informa su número de serie.
+func (uc *ImportESP32sUseCase) Execute(ctx context.Context, rows []ManifestRow, dryRun bool) (*ImportReport, error) {
+	report := &ImportReport{DryRun: dryRun, Total: len(rows), Rows: make([]ImportRowResult, len(rows))}
+
+	numerosSerie := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if row.NumeroSerie != "" {
+			numerosSerie = append(numerosSerie, row.NumeroSerie)
+		}
+	}
+	existing, err := uc.esp32Repository.FindExistingNumerosSerie(ctx, numerosSerie)
+	if err != nil {
+		return nil, err
+	}
+
+	sensorTypes, err := uc.sensorTypeRepository.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	registered := make(map[string]*entities.SensorType, len(sensorTypes))
+	for _, sensorType := range sensorTypes {
+		registered[sensorType.Code] = sensorType
+	}
+
+	seenSerials := make(map[string]int)
+	seenSensorSerials := make(map[string]int)
+
+	for i, row := range rows {
+		result := ImportRowResult{Row: row.Row, NumeroSerie: row.NumeroSerie}
+
+		switch {
+		case row.NumeroSerie == "":
+			result.Errors = append(result.Errors, "numero_serie is required")
+		case utf8.RuneCountInString(row.NumeroSerie) > 255:
+			result.Errors = append(result.Errors, "numero_serie must be at most 255 characters")
+		case existing[row.NumeroSerie]:
+			result.Errors = append(result.Errors, "numero_serie is already registered")
+		}
+		if utf8.RuneCountInString(row.HardwareRevision) > 50 {
+			result.Errors = append(result.Errors, "hardware_revision must be at most 50 characters")
+		}
+		if row.NumeroSerie != "" {
+			if previous, ok := seenSerials[row.NumeroSerie]; ok {
+				result.Errors = append(result.Errors, fmt.Sprintf("numero_serie is duplicated in row %d", previous))
+			} else {
+				seenSerials[row.NumeroSerie] = row.Row
+			}
+		}
+
+		for _, sensorType := range manifestSensorTypes(row, sensorTypes) {
+			if registered[sensorType] == nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("unknown sensor type %s", sensorType))
+				continue
+			}
+			serial := row.SensorSerials[sensorType]
+			if serial == "" {
+				if registered[sensorType].DefaultKit {
+					result.Errors = append(result.Errors, fmt.Sprintf("missing %s sensor", sensorType))
+				}
+				continue
+			}
+			if utf8.RuneCountInString(serial) > 100 {
+				result.Errors = append(result.Errors, fmt.Sprintf("%s sensor serial must be at most 100 characters", sensorType))
+			}
+			key := sensorType + ":" + serial
+			if previous, ok := seenSensorSerials[key]; ok {
+				result.Errors = append(result.Errors, fmt.Sprintf("%s sensor %s is duplicated in row %d", sensorType, serial, previous))
+			} else {
+				seenSensorSerials[key] = row.Row
+			}
+		}
+
+		if len(result.Errors) > 0 {
+			result.Status = ImportRowInvalid
+			report.Invalid++
+		} else {
+			result.Status = ImportRowValid
+			report.Valid++
+		}
+		report.Rows[i] = result
+	}
+
+	if dryRun || report.Invalid > 0 {
+		return report, nil
+	}
+
+	esp32s := make([]*entities.ESP32, len(rows))
+	for i, row := range rows {
+		claimCode, err := entities.GenerateClaimCode()
+		if err != nil {
+			return nil, err
+		}
+		deviceToken, err := entities.GenerateDeviceToken()
+		if err != nil {
+			return nil, err
+		}
+
+		esp32 := entities.NewESP32(row.NumeroSerie)
+		esp32.HardwareRevision = row.HardwareRevision
+		for _, sensorType := range manifestSensorTypes(row, sensorTypes) {
+			if serial := row.SensorSerials[sensorType]; serial != "" {
+				esp32.Sensors = append(esp32.Sensors, entities.NewDeviceSensor(0, sensorType, serial, ""))
+			}
+		}
+		esp32.SetClaimCode(claimCode)
+		esp32.SetDeviceToken(deviceToken)
+		esp32s[i] = esp32
+
+		report.Rows[i].ClaimCode = claimCode
+		report.Rows[i].DeviceToken = deviceToken
+	}
+
+	if err := uc.esp32Repository.CreateManyWithSensors(ctx, esp32s); err != nil {
+		return nil, err
+	}
+
+	for i, esp32 := range esp32s {
+		report.Rows[i].Status = ImportRowCreated
+		report.Rows[i].ESP32ID = esp32.ID
+	}
+	report.Created = len(esp32s)
+
+	return report, nil
+}
+
+// manifestSensorTypes devuelve los tipos de sensor a revisar en una fila: los del kit por defecto,
+// estén o no en el manifiesto, y luego los demás informados por la fila, en orden alfabético
+func manifestSensorTypes(row ManifestRow, sensorTypes []*entities.SensorType) []string {
+	var codes []string
+	seen := make(map[string]bool)
+	for _, sensorType := range sensorTypes {
+		if sensorType.DefaultKit {
+			codes = append(codes, sensorType.Code)
+			seen[sensorType.Code] = true
+		}
+	}
+
+	var others []string
+	for code := range row.SensorSerials {
+		if !seen[code] {
+			others = append(others, code)
+		}
+	}
+	sort.Strings(others)
+
+	return append(codes, others...)
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+	userRepo "hex_go/src/users/domain/repositories"
+)
+
+// InitiateTransferUseCase implementa el caso de uso para ofrecer un ESP32 a otro usuario
+type InitiateTransferUseCase struct {
+	transferRepository repositories.ESP32TransferRepository
+	userRepository     userRepo.UserRepository
+	authorizer         *ESP32Authorizer
+}
+
+// NewInitiateTransferUseCase crea una nueva instancia de InitiateTransferUseCase
+func NewInitiateTransferUseCase(transferRepo repositories.ESP32TransferRepository, userRepo userRepo.UserRepository, authorizer *ESP32Authorizer) *InitiateTransferUseCase {
+	return &InitiateTransferUseCase{
+		transferRepository: transferRepo,
+		userRepository:     userRepo,
+		authorizer:         authorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *InitiateTransferUseCase) Execute(ctx context.Context, esp32ID int, actor Actor, toEmail string) (*entities.ESP32Transfer, error) {
+	// Verificar que el ESP32 exista y pertenezca al actor
+	esp32, err := uc.authorizer.Authorize(ctx, esp32ID, actor)
+	if err != nil {
+		return nil, err
+	}
+	if esp32.UserID == nil {
+		return nil, errors.New("ESP32 is not assigned to any user")
+	}
+
+	// No tiene sentido transferir el ESP32 a su dueño actual
+	owner, err := uc.userRepository.FindByID(ctx, *esp32.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if owner != nil && entities.NormalizeEmail(owner.Email) == entities.NormalizeEmail(toEmail) {
+		return nil, errors.New("cannot transfer an ESP32 to its current owner")
+	}
+
+	// Solo puede haber una transferencia pendiente por ESP32
+	pending, err := uc.transferRepository.FindPendingByESP32ID(ctx, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+	if pending != nil {
+		return nil, errors.New("ESP32 already has a pending transfer")
+	}
+
+	transfer := entities.NewESP32Transfer(esp32ID, *esp32.UserID, toEmail)
+	transfer.NumeroSerie = esp32.NumeroSerie
+
+	return uc.transferRepository.Create(ctx, transfer)
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// InstallSensorUseCase implementa el caso de uso para instalar un sensor en un ESP32
+type InstallSensorUseCase struct {
+	esp32Repository        repositories.ESP32Repository
+	sensorTypeRepository   repositories.SensorTypeRepository
+	deviceSensorRepository repositories.DeviceSensorRepository
+}
+
+// NewInstallSensorUseCase crea una nueva instancia de InstallSensorUseCase
+func NewInstallSensorUseCase(
+	esp32Repo repositories.ESP32Repository,
+	sensorTypeRepo repositories.SensorTypeRepository,
+	deviceSensorRepo repositories.DeviceSensorRepository,
+) *InstallSensorUseCase {
+	return &InstallSensorUseCase{
+		esp32Repository:        esp32Repo,
+		sensorTypeRepository:   sensorTypeRepo,
+		deviceSensorRepository: deviceSensorRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *InstallSensorUseCase) Execute(ctx context.Context, esp32ID int, sensorType, numeroSerie, label string) (*entities.DeviceSensor, error) {
+	numeroSerie = strings.TrimSpace(numeroSerie)
+	label = strings.TrimSpace(label)
+	if utf8.RuneCountInString(numeroSerie) > 100 {
+		return nil, errors.New("numero_serie must be at most 100 characters")
+	}
+	if utf8.RuneCountInString(label) > 100 {
+		return nil, errors.New("label must be at most 100 characters")
+	}
+
+	esp32, err := uc.esp32Repository.FindByID(ctx, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+	if esp32 == nil {
+		return nil, ErrESP32NotFound
+	}
+	if esp32.IsDecommissioned() {
+		return nil, ErrESP32Decommissioned
+	}
+
+	registered, err := uc.sensorTypeRepository.FindByCode(ctx, entities.NormalizeSensorTypeCode(sensorType))
+	if err != nil {
+		return nil, err
+	}
+	if registered == nil {
+		return nil, ErrSensorTypeNotFound
+	}
+
+	sensor := entities.NewDeviceSensor(esp32.ID, registered.Code, numeroSerie, label)
+	return uc.deviceSensorRepository.Create(ctx, sensor)
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+var (
+	// ErrMaintenanceScheduleNotFound se devuelve cuando la tarea no existe o pertenece a otro ESP32
+	ErrMaintenanceScheduleNotFound = errors.New("maintenance schedule not found")
+	// ErrMaintenanceScheduleExists se devuelve al crear una tarea que el ESP32 o el sensor ya tiene
+	ErrMaintenanceScheduleExists = errors.New("the maintenance schedule already exists")
+)
+
+// findESP32Schedule busca una tarea y verifica que pertenezca al ESP32 indicado
+func findESP32Schedule(ctx context.Context, maintenanceRepo repositories.MaintenanceRepository, esp32ID, scheduleID int) (*entities.MaintenanceSchedule, error) {
+	schedule, err := maintenanceRepo.FindScheduleByID(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule == nil || schedule.ESP32ID != esp32ID {
+		return nil, ErrMaintenanceScheduleNotFound
+	}
+	return schedule, nil
+}
+
+// hasSchedule indica si entre las tareas ya existe una del mismo tipo para el mismo sensor
+func hasSchedule(schedules []*entities.MaintenanceSchedule, task entities.MaintenanceTask, sensorID *int) bool {
+	for _, schedule := range schedules {
+		if schedule.Task != task {
+			continue
+		}
+		if sensorID == nil || (schedule.SensorID != nil && *schedule.SensorID == *sensorID) {
+			return true
+		}
+	}
+	return false
+}
+
+// firstDueAt calcula el primer vencimiento de una tarea nueva: un intervalo después de la
+// instalación para los reemplazos de sensores y un intervalo desde ahora para el resto
+func firstDueAt(task entities.MaintenanceTask, sensor *entities.DeviceSensor, intervalDays int, now time.Time) time.Time {
+	if task == entities.MaintenanceSensorReplacement && sensor != nil {
+		return sensor.InstalledAt.AddDate(0, 0, intervalDays)
+	}
+	return now.AddDate(0, 0, intervalDays)
+}
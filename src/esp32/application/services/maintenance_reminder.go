@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+	userRepo "hex_go/src/users/domain/repositories"
+)
+
+// MaintenanceReminder avisa a los responsables de cada ESP32 cuando una tarea de mantenimiento
+// está próxima a vencer y nuevamente cuando vence. Los destinatarios son el dueño del ESP32 y
+// el dueño y los administradores de su grupo y de los grupos que lo contienen.
+type MaintenanceReminder struct {
+	maintenanceRepository repositories.MaintenanceRepository
+	esp32Repository       repositories.ESP32Repository
+	groupRepository       repositories.DeviceGroupRepository
+	userRepository        userRepo.UserRepository
+	notifier              Notifier
+}
+
+// NewMaintenanceReminder crea una nueva instancia de MaintenanceReminder
+func NewMaintenanceReminder(
+	maintenanceRepo repositories.MaintenanceRepository,
+	esp32Repo repositories.ESP32Repository,
+	groupRepo repositories.DeviceGroupRepository,
+	userRepo userRepo.UserRepository,
+	notifier Notifier,
+) *MaintenanceReminder {
+	return &MaintenanceReminder{
+		maintenanceRepository: maintenanceRepo,
+		esp32Repository:       esp32Repo,
+		groupRepository:       groupRepo,
+		userRepository:        userRepo,
+		notifier:              notifier,
+	}
+}
+
+// Run envía periódicamente los recordatorios pendientes hasta que se cancele el contexto
+func (r *MaintenanceReminder) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.remind(ctx, time.Now()); err != nil {
+				log.Printf("Warning: maintenance reminders failed: %v", err)
+			}
+		}
+	}
+}
+
+// remind envía un recordatorio por cada tarea pendiente. Si el envío falla la tarea no se marca
+// como recordada y se reintenta en la siguiente pasada.
+func (r *MaintenanceReminder) remind(ctx context.Context, now time.Time) error {
+	schedules, err := r.maintenanceRepository.FindPendingReminders(ctx, now, now.Add(entities.MaintenanceDueWindow))
+	if err != nil {
+		return err
+	}
+
+	for _, schedule := range schedules {
+		level := entities.MaintenanceDue
+		if !now.Before(schedule.DueAt) {
+			level = entities.MaintenanceOverdue
+		}
+
+		esp32, err := r.esp32Repository.FindByID(ctx, schedule.ESP32ID)
+		if err != nil {
+			return err
+		}
+		if esp32 == nil {
+			continue
+		}
+
+		recipients, err := r.recipients(ctx, esp32)
+		if err != nil {
+			return err
+		}
+		if len(recipients) > 0 {
+			notification := maintenanceNotification(esp32, schedule, level, recipients)
+			if err := r.notifier.Notify(ctx, notification); err != nil {
+				log.Printf("Warning: maintenance reminder for ESP32 %s failed: %v", esp32.NumeroSerie, err)
+				continue
+			}
+		}
+
+		if err := r.maintenanceRepository.SetReminderLevel(ctx, schedule.ID, level, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// recipients reúne, sin repetir, los emails de los responsables del ESP32
+func (r *MaintenanceReminder) recipients(ctx context.Context, esp32 *entities.ESP32) ([]string, error) {
+	userIDs := make(map[int]bool)
+	emails := make(map[string]bool)
+	if esp32.UserID != nil {
+		userIDs[*esp32.UserID] = true
+	}
+
+	groupID := esp32.GroupID
+	for depth := 0; groupID != nil && depth < maxGroupDepth; depth++ {
+		group, err := r.groupRepository.FindByID(ctx, *groupID)
+		if err != nil {
+			return nil, err
+		}
+		if group == nil {
+			break
+		}
+		userIDs[group.OwnerID] = true
+
+		members, err := r.groupRepository.FindMembers(ctx, group.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range members {
+			if member.Role == entities.GroupRoleManager && member.Email != "" {
+				emails[entities.NormalizeEmail(member.Email)] = true
+			}
+		}
+		groupID = group.ParentID
+	}
+
+	for userID := range userIDs {
+		// Un usuario eliminado no impide avisar al resto de los responsables
+		user, err := r.userRepository.FindByID(ctx, userID)
+		if err != nil || user == nil {
+			continue
+		}
+		emails[entities.NormalizeEmail(user.Email)] = true
+	}
+
+	recipients := make([]string, 0, len(emails))
+	for email := range emails {
+		recipients = append(recipients, email)
+	}
+	sort.Strings(recipients)
+
+	return recipients, nil
+}
+
+// maintenanceNotification arma el aviso de una tarea próxima a vencer o vencida
+func maintenanceNotification(esp32 *entities.ESP32, schedule *entities.MaintenanceSchedule, level entities.MaintenanceStatus, recipients []string) Notification {
+	name := esp32.NumeroSerie
+	if esp32.Nickname != "" {
+		name = fmt.Sprintf("%s (%s)", esp32.Nickname, esp32.NumeroSerie)
+	}
+	dueAt := schedule.DueAt.Format(time.DateOnly)
+
+	notification := Notification{
+		Kind:             "maintenance_" + string(level),
+		ESP32ID:          esp32.ID,
+		ESP32NumeroSerie: esp32.NumeroSerie,
+		Recipients:       recipients,
+	}
+	if level == entities.MaintenanceOverdue {
+		notification.Subject = fmt.Sprintf("Maintenance overdue: %s", name)
+		notification.Message = fmt.Sprintf("The %s of %s was due on %s and has not been performed.", scheduleLabel(schedule), name, dueAt)
+	} else {
+		notification.Subject = fmt.Sprintf("Maintenance due soon: %s", name)
+		notification.Message = fmt.Sprintf("The %s of %s is due on %s.", scheduleLabel(schedule), name, dueAt)
+	}
+
+	return notification
+}
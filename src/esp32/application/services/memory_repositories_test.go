@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// memoryESP32Repository es un ESP32Repository en memoria para las pruebas. Solo implementa
+// las operaciones que usan los casos de uso probados; las demás provocan un panic.
+type memoryESP32Repository struct {
+	repositories.ESP32Repository
+	esp32s map[int]*entities.ESP32
+}
+
+func newMemoryESP32Repository(esp32s ...*entities.ESP32) *memoryESP32Repository {
+	repo := &memoryESP32Repository{esp32s: make(map[int]*entities.ESP32)}
+	for _, esp32 := range esp32s {
+		repo.esp32s[esp32.ID] = esp32
+	}
+	return repo
+}
+
+func (r *memoryESP32Repository) FindByID(ctx context.Context, id int) (*entities.ESP32, error) {
+	esp32, ok := r.esp32s[id]
+	if !ok {
+		return nil, nil
+	}
+	clone := *esp32
+	return &clone, nil
+}
+
+func (r *memoryESP32Repository) UnassignFromUser(ctx context.Context, esp32ID int, claimCodeHash string) error {
+	esp32, ok := r.esp32s[esp32ID]
+	if !ok {
+		return nil
+	}
+	esp32.UserID = nil
+	esp32.AssignedAt = nil
+	esp32.GroupID = nil
+	esp32.ClaimCodeHash = claimCodeHash
+	return nil
+}
+
+// memoryDeviceGroupRepository es un DeviceGroupRepository en memoria para las pruebas
+type memoryDeviceGroupRepository struct {
+	repositories.DeviceGroupRepository
+	groups  map[int]*entities.DeviceGroup
+	members []*entities.GroupMember
+}
+
+func newMemoryDeviceGroupRepository() *memoryDeviceGroupRepository {
+	return &memoryDeviceGroupRepository{groups: make(map[int]*entities.DeviceGroup)}
+}
+
+func (r *memoryDeviceGroupRepository) FindByID(ctx context.Context, id int) (*entities.DeviceGroup, error) {
+	return r.groups[id], nil
+}
+
+func (r *memoryDeviceGroupRepository) FindByParentID(ctx context.Context, parentID int) ([]*entities.DeviceGroup, error) {
+	var children []*entities.DeviceGroup
+	for _, group := range r.groups {
+		if group.ParentID != nil && *group.ParentID == parentID {
+			children = append(children, group)
+		}
+	}
+	return children, nil
+}
+
+func (r *memoryDeviceGroupRepository) FindMembershipsByUserID(ctx context.Context, userID int) ([]*entities.GroupMember, error) {
+	var memberships []*entities.GroupMember
+	for _, member := range r.members {
+		if member.UserID == userID {
+			memberships = append(memberships, member)
+		}
+	}
+	return memberships, nil
+}
+
+func intPtr(v int) *int {
+	return &v
+}
@@ -0,0 +1,18 @@
+package services
+
+import "context"
+
+// Notification es un aviso dirigido a los responsables de un ESP32
+type Notification struct {
+	Kind             string   `json:"kind"` // Motivo del aviso, por ejemplo maintenance_due
+	ESP32ID          int      `json:"esp32_id"`
+	ESP32NumeroSerie string   `json:"esp32_numero_serie"`
+	Recipients       []string `json:"recipients"` // Emails de los destinatarios
+	Subject          string   `json:"subject"`
+	Message          string   `json:"message"`
+}
+
+// Notifier envía avisos por los canales de notificación configurados
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification) error
+}
@@ -0,0 +1,29 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// PollCommandsUseCase implementa el caso de uso con el que el firmware recibe sus comandos
+type PollCommandsUseCase struct {
+	deviceCommandRepository repositories.DeviceCommandRepository
+}
+
+// NewPollCommandsUseCase crea una nueva instancia de PollCommandsUseCase
+func NewPollCommandsUseCase(deviceCommandRepo repositories.DeviceCommandRepository) *PollCommandsUseCase {
+	return &PollCommandsUseCase{
+		deviceCommandRepository: deviceCommandRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso. Cada comando se entrega una sola vez: uno ya entregado no
+// vuelve a aparecer aunque el ESP32 no lo haya confirmado, así un reboot ejecutado antes de
+// confirmarse no se repite al reiniciar. El reclamo es atómico: dos consultas simultáneas
+// no reciben el mismo comando.
+func (uc *PollCommandsUseCase) Execute(ctx context.Context, esp32ID int) ([]*entities.DeviceCommand, error) {
+	return uc.deviceCommandRepository.ClaimPending(ctx, esp32ID, time.Now())
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// ProvisionESP32UseCase implementa el caso de uso para dar de alta un ESP32 de fábrica
+type ProvisionESP32UseCase struct {
+	esp32Repository      repositories.ESP32Repository
+	sensorTypeRepository repositories.SensorTypeRepository
+}
+
+// ProvisionESP32Response contiene el ESP32 creado, su código de reclamo y su token de firmware.
+// Ambos secretos solo se devuelven en este momento: el código para imprimirlo en la etiqueta QR
+// y el token para grabarlo en el firmware.
+type ProvisionESP32Response struct {
+	ESP32       *entities.ESP32 `json:"esp32"`
+	ClaimCode   string          `json:"claim_code"`
+	DeviceToken string          `json:"device_token"`
+}
+
+// NewProvisionESP32UseCase crea una nueva instancia de ProvisionESP32UseCase
+func NewProvisionESP32UseCase(esp32Repo repositories.ESP32Repository, sensorTypeRepo repositories.SensorTypeRepository) *ProvisionESP32UseCase {
+	return &ProvisionESP32UseCase{
+		esp32Repository:      esp32Repo,
+		sensorTypeRepository: sensorTypeRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *ProvisionESP32UseCase) Execute(ctx context.Context, numeroSerie, hardwareRevision string) (*ProvisionESP32Response, error) {
+	numeroSerie = strings.TrimSpace(numeroSerie)
+	if numeroSerie == "" {
+		return nil, errors.New("numero_serie is required")
+	}
+	hardwareRevision = strings.TrimSpace(hardwareRevision)
+	if utf8.RuneCountInString(hardwareRevision) > 50 {
+		return nil, errors.New("hardware_revision must be at most 50 characters")
+	}
+
+	// Verificar que el número de serie no esté registrado
+	existing, err := uc.esp32Repository.FindByNumeroSerie(ctx, numeroSerie)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, errors.New("ESP32 with this serial number already exists")
+	}
+
+	claimCode, err := entities.GenerateClaimCode()
+	if err != nil {
+		return nil, err
+	}
+	deviceToken, err := entities.GenerateDeviceToken()
+	if err != nil {
+		return nil, err
+	}
+
+	sensorTypes, err := uc.sensorTypeRepository.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Crear el ESP32 junto con los sensores del kit por defecto
+	esp32 := entities.NewESP32(numeroSerie)
+	esp32.HardwareRevision = hardwareRevision
+	esp32.Sensors = defaultKitSensors(sensorTypes)
+	esp32.SetClaimCode(claimCode)
+	esp32.SetDeviceToken(deviceToken)
+
+	created, err := uc.esp32Repository.CreateWithSensors(ctx, esp32)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProvisionESP32Response{
+		ESP32:       created,
+		ClaimCode:   claimCode,
+		DeviceToken: deviceToken,
+	}, nil
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// RecordCalibrationUseCase implementa el caso de uso para registrar la calibración que un técnico
+// realizó sobre un sensor de gas
+type RecordCalibrationUseCase struct {
+	deviceSensorRepository      repositories.DeviceSensorRepository
+	sensorTypeRepository        repositories.SensorTypeRepository
+	sensorCalibrationRepository repositories.SensorCalibrationRepository
+	authorizer                  *ESP32Authorizer
+}
+
+// NewRecordCalibrationUseCase crea una nueva instancia de RecordCalibrationUseCase
+func NewRecordCalibrationUseCase(
+	deviceSensorRepo repositories.DeviceSensorRepository,
+	sensorTypeRepo repositories.SensorTypeRepository,
+	sensorCalibrationRepo repositories.SensorCalibrationRepository,
+	authorizer *ESP32Authorizer,
+) *RecordCalibrationUseCase {
+	return &RecordCalibrationUseCase{
+		deviceSensorRepository:      deviceSensorRepo,
+		sensorTypeRepository:        sensorTypeRepo,
+		sensorCalibrationRepository: sensorCalibrationRepo,
+		authorizer:                  authorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *RecordCalibrationUseCase) Execute(ctx context.Context, esp32ID, sensorID int, actor Actor, input CalibrationInput) (*entities.SensorCalibration, error) {
+	if _, err := uc.authorizer.AuthorizeOperate(ctx, esp32ID, actor); err != nil {
+		return nil, err
+	}
+
+	sensor, err := uc.deviceSensorRepository.FindByID(ctx, sensorID)
+	if err != nil {
+		return nil, err
+	}
+	if sensor == nil || sensor.ESP32ID != esp32ID {
+		return nil, ErrSensorNotFound
+	}
+	if sensor.IsRetired() {
+		return nil, repositories.ErrSensorRetired
+	}
+	if _, err := calibratableSensorType(ctx, uc.sensorTypeRepository, sensor); err != nil {
+		return nil, err
+	}
+
+	calibration, err := newCalibration(sensor, input, entities.CalibrationTechnician)
+	if err != nil {
+		return nil, err
+	}
+	if calibration.Technician == "" {
+		return nil, errors.New("technician is required")
+	}
+	calibration.CalibratedBy = &actor.UserID
+
+	return uc.sensorCalibrationRepository.Create(ctx, calibration)
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// RecordHeartbeatUseCase implementa el caso de uso para registrar el heartbeat de un ESP32
+type RecordHeartbeatUseCase struct {
+	esp32Repository       repositories.ESP32Repository
+	deviceEventRepository repositories.DeviceEventRepository
+}
+
+// NewRecordHeartbeatUseCase crea una nueva instancia de RecordHeartbeatUseCase
+func NewRecordHeartbeatUseCase(esp32Repo repositories.ESP32Repository, deviceEventRepo repositories.DeviceEventRepository) *RecordHeartbeatUseCase {
+	return &RecordHeartbeatUseCase{
+		esp32Repository:       esp32Repo,
+		deviceEventRepository: deviceEventRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *RecordHeartbeatUseCase) Execute(ctx context.Context, esp32ID int) error {
+	esp32, err := uc.esp32Repository.FindByID(ctx, esp32ID)
+	if err != nil {
+		return err
+	}
+	if esp32 == nil {
+		return ErrESP32NotFound
+	}
+
+	cameOnline, err := uc.esp32Repository.UpdateHeartbeat(ctx, esp32ID, time.Now())
+	if err != nil {
+		return err
+	}
+
+	// Si este heartbeat sacó al ESP32 del estado desconectado, registrar que volvió a estar en línea.
+	// El primer heartbeat de un ESP32 nuevo no cuenta como reconexión.
+	if cameOnline && esp32.LastSeenAt != nil {
+		event := entities.NewDeviceEvent(esp32ID, entities.DeviceEventOnline)
+		if _, err := uc.deviceEventRepository.Create(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// maxMaintenanceClockSkew tolera relojes de los dispositivos de los técnicos levemente adelantados
+const maxMaintenanceClockSkew = time.Minute
+
+// RecordMaintenanceInput contiene los datos de una tarea de mantenimiento realizada
+type RecordMaintenanceInput struct {
+	ScheduleID  int                        `json:"schedule_id"`
+	Result      entities.MaintenanceResult `json:"result"`
+	PerformedAt *time.Time                 `json:"performed_at"` // Si se omite se usa la hora de recepción
+	Technician  string                     `json:"technician"`
+	Notes       string                     `json:"notes"`
+}
+
+// RecordMaintenanceUseCase implementa el caso de uso para registrar una prueba, inspección o
+// reemplazo realizado, lo que actualiza el vencimiento de la tarea
+type RecordMaintenanceUseCase struct {
+	maintenanceRepository repositories.MaintenanceRepository
+	authorizer            *ESP32Authorizer
+}
+
+// NewRecordMaintenanceUseCase crea una nueva instancia de RecordMaintenanceUseCase
+func NewRecordMaintenanceUseCase(maintenanceRepo repositories.MaintenanceRepository, authorizer *ESP32Authorizer) *RecordMaintenanceUseCase {
+	return &RecordMaintenanceUseCase{
+		maintenanceRepository: maintenanceRepo,
+		authorizer:            authorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *RecordMaintenanceUseCase) Execute(ctx context.Context, esp32ID int, actor Actor, input RecordMaintenanceInput) (*entities.MaintenanceRecord, error) {
+	esp32, err := uc.authorizer.AuthorizeOperate(ctx, esp32ID, actor)
+	if err != nil {
+		return nil, err
+	}
+	if esp32.IsDecommissioned() {
+		return nil, ErrESP32Decommissioned
+	}
+
+	schedule, err := findESP32Schedule(ctx, uc.maintenanceRepository, esp32ID, input.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	performedAt := now
+	if input.PerformedAt != nil {
+		if input.PerformedAt.After(now.Add(maxMaintenanceClockSkew)) {
+			return nil, errors.New("performed_at is in the future")
+		}
+		performedAt = *input.PerformedAt
+	}
+	// Un registro atrasado no puede deshacer una realización posterior ya registrada
+	if schedule.LastPerformedAt != nil && performedAt.Before(*schedule.LastPerformedAt) {
+		return nil, errors.New("performed_at is before the last time the task was performed")
+	}
+
+	record := entities.NewMaintenanceRecord(schedule, input.Result, performedAt, actor.UserID)
+	record.Technician = strings.TrimSpace(input.Technician)
+	record.Notes = strings.TrimSpace(input.Notes)
+	if err := record.Validate(); err != nil {
+		return nil, err
+	}
+
+	schedule.Apply(record)
+	return uc.maintenanceRepository.RecordMaintenance(ctx, record, schedule)
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// RegenerateClaimCodeUseCase implementa el caso de uso para reemplazar el código de reclamo
+// de un ESP32 sin asignar, por ejemplo cuando se pierde la etiqueta QR
+type RegenerateClaimCodeUseCase struct {
+	esp32Repository repositories.ESP32Repository
+}
+
+// NewRegenerateClaimCodeUseCase crea una nueva instancia de RegenerateClaimCodeUseCase
+func NewRegenerateClaimCodeUseCase(esp32Repo repositories.ESP32Repository) *RegenerateClaimCodeUseCase {
+	return &RegenerateClaimCodeUseCase{
+		esp32Repository: esp32Repo,
+	}
+}
+
+// Execute ejecuta el caso de uso y devuelve el nuevo código de reclamo
+func (uc *RegenerateClaimCodeUseCase) Execute(ctx context.Context, esp32ID int) (string, error) {
+	// Verificar si el ESP32 existe
+	esp32, err := uc.esp32Repository.FindByID(ctx, esp32ID)
+	if err != nil {
+		return "", err
+	}
+	if esp32 == nil {
+		return "", ErrESP32NotFound
+	}
+	if esp32.IsDecommissioned() {
+		return "", ErrESP32Decommissioned
+	}
+
+	// Un ESP32 asignado no tiene código vigente
+	if esp32.UserID != nil {
+		return "", errors.New("ESP32 is assigned to a user")
+	}
+
+	claimCode, err := entities.GenerateClaimCode()
+	if err != nil {
+		return "", err
+	}
+
+	esp32.SetClaimCode(claimCode)
+	if err := uc.esp32Repository.Update(ctx, esp32); err != nil {
+		return "", err
+	}
+
+	return claimCode, nil
+}
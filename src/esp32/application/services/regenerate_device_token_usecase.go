@@ -0,0 +1,47 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// RegenerateDeviceTokenUseCase implementa el caso de uso para emitir un nuevo token de firmware,
+// por ejemplo para ESP32 dados de alta antes de existir los tokens o con un token comprometido
+type RegenerateDeviceTokenUseCase struct {
+	esp32Repository repositories.ESP32Repository
+}
+
+// NewRegenerateDeviceTokenUseCase crea una nueva instancia de RegenerateDeviceTokenUseCase
+func NewRegenerateDeviceTokenUseCase(esp32Repo repositories.ESP32Repository) *RegenerateDeviceTokenUseCase {
+	return &RegenerateDeviceTokenUseCase{
+		esp32Repository: esp32Repo,
+	}
+}
+
+// Execute ejecuta el caso de uso y devuelve el nuevo token
+func (uc *RegenerateDeviceTokenUseCase) Execute(ctx context.Context, esp32ID int) (string, error) {
+	esp32, err := uc.esp32Repository.FindByID(ctx, esp32ID)
+	if err != nil {
+		return "", err
+	}
+	if esp32 == nil {
+		return "", ErrESP32NotFound
+	}
+	if esp32.IsDecommissioned() {
+		return "", ErrESP32Decommissioned
+	}
+
+	token, err := entities.GenerateDeviceToken()
+	if err != nil {
+		return "", err
+	}
+
+	esp32.SetDeviceToken(token)
+	if err := uc.esp32Repository.Update(ctx, esp32); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
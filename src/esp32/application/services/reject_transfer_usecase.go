@@ -0,0 +1,33 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+	userRepo "hex_go/src/users/domain/repositories"
+)
+
+// RejectTransferUseCase implementa el caso de uso para que el destinatario rechace un ESP32
+type RejectTransferUseCase struct {
+	transferRepository repositories.ESP32TransferRepository
+	userRepository     userRepo.UserRepository
+}
+
+// NewRejectTransferUseCase crea una nueva instancia de RejectTransferUseCase
+func NewRejectTransferUseCase(transferRepo repositories.ESP32TransferRepository, userRepo userRepo.UserRepository) *RejectTransferUseCase {
+	return &RejectTransferUseCase{
+		transferRepository: transferRepo,
+		userRepository:     userRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *RejectTransferUseCase) Execute(ctx context.Context, transferID int, actor Actor) error {
+	transfer, err := findTransferForRecipient(ctx, uc.transferRepository, uc.userRepository, transferID, actor)
+	if err != nil {
+		return err
+	}
+
+	return uc.transferRepository.UpdateStatus(ctx, transfer.ID, entities.TransferRejected)
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// RemoveESP32FromGroupUseCase implementa el caso de uso para quitar un ESP32 de un grupo
+type RemoveESP32FromGroupUseCase struct {
+	esp32Repository repositories.ESP32Repository
+	groupAuthorizer *GroupAuthorizer
+}
+
+// NewRemoveESP32FromGroupUseCase crea una nueva instancia de RemoveESP32FromGroupUseCase
+func NewRemoveESP32FromGroupUseCase(esp32Repo repositories.ESP32Repository, groupAuthorizer *GroupAuthorizer) *RemoveESP32FromGroupUseCase {
+	return &RemoveESP32FromGroupUseCase{
+		esp32Repository: esp32Repo,
+		groupAuthorizer: groupAuthorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *RemoveESP32FromGroupUseCase) Execute(ctx context.Context, groupID, esp32ID int, actor Actor) error {
+	group, err := uc.groupAuthorizer.Authorize(ctx, groupID, actor, GroupAccessOwner)
+	if err != nil {
+		return err
+	}
+
+	esp32, err := uc.esp32Repository.FindByID(ctx, esp32ID)
+	if err != nil {
+		return err
+	}
+	if esp32 == nil || esp32.GroupID == nil || *esp32.GroupID != group.ID {
+		return ErrESP32NotFound
+	}
+
+	return uc.esp32Repository.SetGroup(ctx, esp32.ID, group.OwnerID, nil)
+}
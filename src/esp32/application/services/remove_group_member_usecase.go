@@ -0,0 +1,31 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// RemoveGroupMemberUseCase implementa el caso de uso para quitar los permisos de un usuario sobre un grupo
+type RemoveGroupMemberUseCase struct {
+	groupRepository repositories.DeviceGroupRepository
+	groupAuthorizer *GroupAuthorizer
+}
+
+// NewRemoveGroupMemberUseCase crea una nueva instancia de RemoveGroupMemberUseCase
+func NewRemoveGroupMemberUseCase(groupRepo repositories.DeviceGroupRepository, groupAuthorizer *GroupAuthorizer) *RemoveGroupMemberUseCase {
+	return &RemoveGroupMemberUseCase{
+		groupRepository: groupRepo,
+		groupAuthorizer: groupAuthorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *RemoveGroupMemberUseCase) Execute(ctx context.Context, groupID, userID int, actor Actor) error {
+	group, err := uc.groupAuthorizer.Authorize(ctx, groupID, actor, GroupAccessOwner)
+	if err != nil {
+		return err
+	}
+
+	return uc.groupRepository.DeleteMember(ctx, group.ID, userID)
+}
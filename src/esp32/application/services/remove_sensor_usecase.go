@@ -0,0 +1,32 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// RemoveSensorUseCase implementa el caso de uso para retirar un sensor de un ESP32
+type RemoveSensorUseCase struct {
+	deviceSensorRepository repositories.DeviceSensorRepository
+}
+
+// NewRemoveSensorUseCase crea una nueva instancia de RemoveSensorUseCase
+func NewRemoveSensorUseCase(deviceSensorRepo repositories.DeviceSensorRepository) *RemoveSensorUseCase {
+	return &RemoveSensorUseCase{
+		deviceSensorRepository: deviceSensorRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *RemoveSensorUseCase) Execute(ctx context.Context, esp32ID, sensorID int) error {
+	sensor, err := uc.deviceSensorRepository.FindByID(ctx, sensorID)
+	if err != nil {
+		return err
+	}
+	if sensor == nil || sensor.ESP32ID != esp32ID {
+		return ErrSensorNotFound
+	}
+
+	return uc.deviceSensorRepository.Delete(ctx, sensorID)
+}
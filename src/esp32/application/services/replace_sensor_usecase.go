@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// ReplaceSensorInput contiene los datos del reemplazo de un sensor
+type ReplaceSensorInput struct {
+	NumeroSerie string  `json:"numero_serie"` // Número de serie de fábrica del sensor nuevo
+	Label       *string `json:"label"`        // Si se omite se conserva la del sensor anterior
+	Reason      string  `json:"reason"`
+	Technician  string  `json:"technician"`
+}
+
+// ReplaceSensorResult contiene el reemplazo registrado y el sensor instalado
+type ReplaceSensorResult struct {
+	Replacement *entities.SensorReplacement `json:"replacement"`
+	Sensor      *entities.DeviceSensor      `json:"sensor"`
+}
+
+// ReplaceSensorUseCase implementa el caso de uso para reemplazar un sensor averiado por otro del mismo tipo.
+// El sensor anterior queda retirado con sus lecturas y alarmas, y el nuevo empieza sin calibraciones.
+type ReplaceSensorUseCase struct {
+	deviceSensorRepository repositories.DeviceSensorRepository
+	maintenanceRepository  repositories.MaintenanceRepository
+	authorizer             *ESP32Authorizer
+}
+
+// NewReplaceSensorUseCase crea una nueva instancia de ReplaceSensorUseCase
+func NewReplaceSensorUseCase(
+	deviceSensorRepo repositories.DeviceSensorRepository,
+	maintenanceRepo repositories.MaintenanceRepository,
+	authorizer *ESP32Authorizer,
+) *ReplaceSensorUseCase {
+	return &ReplaceSensorUseCase{
+		deviceSensorRepository: deviceSensorRepo,
+		maintenanceRepository:  maintenanceRepo,
+		authorizer:             authorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso. Los instaladores y administradores pueden reemplazar sensores
+// de cualquier ESP32; el resto de los usuarios necesita poder operar el ESP32.
+func (uc *ReplaceSensorUseCase) Execute(ctx context.Context, esp32ID, sensorID int, actor Actor, input ReplaceSensorInput) (*ReplaceSensorResult, error) {
+	esp32, err := uc.authorizeReplace(ctx, esp32ID, actor)
+	if err != nil {
+		return nil, err
+	}
+	if esp32.IsDecommissioned() {
+		return nil, ErrESP32Decommissioned
+	}
+
+	oldSensor, err := uc.deviceSensorRepository.FindByID(ctx, sensorID)
+	if err != nil {
+		return nil, err
+	}
+	if oldSensor == nil || oldSensor.ESP32ID != esp32ID {
+		return nil, ErrSensorNotFound
+	}
+	if oldSensor.IsRetired() {
+		return nil, repositories.ErrSensorRetired
+	}
+
+	numeroSerie := strings.TrimSpace(input.NumeroSerie)
+	if utf8.RuneCountInString(numeroSerie) > 100 {
+		return nil, errors.New("numero_serie must be at most 100 characters")
+	}
+	if numeroSerie != "" && numeroSerie == oldSensor.NumeroSerie {
+		return nil, errors.New("the new sensor must have a different numero_serie")
+	}
+	label := oldSensor.Label
+	if input.Label != nil {
+		label = strings.TrimSpace(*input.Label)
+	}
+	if utf8.RuneCountInString(label) > 100 {
+		return nil, errors.New("label must be at most 100 characters")
+	}
+
+	replacement := entities.NewSensorReplacement(oldSensor, input.Reason, input.Technician, actor.UserID)
+	if err := replacement.Validate(); err != nil {
+		return nil, err
+	}
+
+	newSensor := entities.NewDeviceSensor(esp32ID, oldSensor.SensorType, numeroSerie, label)
+	newSensor.InstalledAt = replacement.ReplacedAt
+	replacement, err = uc.deviceSensorRepository.Replace(ctx, replacement, newSensor)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.completeReplacementSchedules(ctx, replacement); err != nil {
+		return nil, err
+	}
+
+	return &ReplaceSensorResult{Replacement: replacement, Sensor: newSensor}, nil
+}
+
+// authorizeReplace devuelve el ESP32 si el actor puede reemplazar sus sensores
+func (uc *ReplaceSensorUseCase) authorizeReplace(ctx context.Context, esp32ID int, actor Actor) (*entities.ESP32, error) {
+	if !canCommission(actor) {
+		return uc.authorizer.AuthorizeOperate(ctx, esp32ID, actor)
+	}
+
+	esp32, err := uc.authorizer.esp32Repository.FindByID(ctx, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+	if esp32 == nil {
+		return nil, ErrESP32NotFound
+	}
+	return esp32, nil
+}
+
+// completeReplacementSchedules registra el reemplazo en las tareas de reemplazo del sensor anterior
+// y las pasa al sensor nuevo, cuyo próximo vencimiento se cuenta desde hoy
+func (uc *ReplaceSensorUseCase) completeReplacementSchedules(ctx context.Context, replacement *entities.SensorReplacement) error {
+	schedules, err := uc.maintenanceRepository.FindSchedulesByESP32ID(ctx, replacement.ESP32ID)
+	if err != nil {
+		return err
+	}
+
+	for _, schedule := range schedules {
+		if schedule.Task != entities.MaintenanceSensorReplacement || schedule.SensorID == nil || *schedule.SensorID != replacement.OldSensorID {
+			continue
+		}
+
+		record := entities.NewMaintenanceRecord(schedule, entities.MaintenancePassed, replacement.ReplacedAt, *replacement.ReplacedBy)
+		record.Technician = replacement.Technician
+		record.Notes = replacement.Reason
+
+		newSensorID := replacement.NewSensorID
+		schedule.SensorID = &newSensorID
+		schedule.Apply(record)
+		if _, err := uc.maintenanceRepository.RecordMaintenance(ctx, record, schedule); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// CommissioningTestInput es el resultado de la prueba de un sensor tal como lo envía el firmware.
+// El sensor se identifica por sensor_id o, si el ESP32 tiene un solo sensor de ese tipo, por sensor_type.
+type CommissioningTestInput struct {
+	SensorID   *int              `json:"sensor_id"`
+	SensorType string            `json:"sensor_type"`
+	Kind       entities.TestKind `json:"kind"` // Si se omite se usa la prueba por defecto del tipo de sensor
+	Passed     *bool             `json:"passed"`
+	Value      *float64          `json:"value"`
+	Detail     string            `json:"detail"`
+}
+
+// ReportCommissioningTestUseCase implementa el caso de uso con el que un ESP32 informa el
+// resultado de disparar uno de sus sensores durante el comisionamiento
+type ReportCommissioningTestUseCase struct {
+	deviceSensorRepository  repositories.DeviceSensorRepository
+	commissioningRepository repositories.CommissioningRepository
+}
+
+// NewReportCommissioningTestUseCase crea una nueva instancia de ReportCommissioningTestUseCase
+func NewReportCommissioningTestUseCase(
+	deviceSensorRepo repositories.DeviceSensorRepository,
+	commissioningRepo repositories.CommissioningRepository,
+) *ReportCommissioningTestUseCase {
+	return &ReportCommissioningTestUseCase{
+		deviceSensorRepository:  deviceSensorRepo,
+		commissioningRepository: commissioningRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso para el ESP32 autenticado
+func (uc *ReportCommissioningTestUseCase) Execute(ctx context.Context, esp32ID int, input CommissioningTestInput) (*entities.CommissioningTest, error) {
+	if input.Passed == nil {
+		return nil, errors.New("passed is required")
+	}
+	if input.Kind != "" && !entities.IsValidTestKind(input.Kind) {
+		return nil, fmt.Errorf("invalid test kind %q", input.Kind)
+	}
+	detail := strings.TrimSpace(input.Detail)
+	if len(detail) > 255 {
+		return nil, errors.New("detail must be at most 255 characters")
+	}
+
+	session, err := uc.commissioningRepository.FindOpenByESP32ID(ctx, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, ErrNoOpenCommissioning
+	}
+
+	sensors, err := uc.deviceSensorRepository.FindByESP32ID(ctx, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+	sensor, err := findReportedSensor(sensors, input.SensorID, input.SensorType)
+	if err != nil {
+		return nil, err
+	}
+
+	test := session.FindTest(sensor.ID)
+	if test == nil {
+		return nil, fmt.Errorf("sensor %d was installed after the commissioning session started", sensor.ID)
+	}
+
+	switch {
+	case input.Kind == "" && test.Kind == "":
+		return nil, fmt.Errorf("kind is required for %s sensors", sensor.SensorType)
+	case input.Kind != "" && test.Kind != "" && input.Kind != test.Kind:
+		return nil, fmt.Errorf("%s sensors are tested with %s, not %s", sensor.SensorType, test.Kind, input.Kind)
+	case input.Kind != "":
+		test.Kind = input.Kind
+	}
+
+	// Una prueba repetida reemplaza el resultado anterior, así el instalador puede volver a disparar un sensor que falló
+	now := time.Now()
+	test.Status = entities.TestFailed
+	if *input.Passed {
+		test.Status = entities.TestPassed
+	}
+	test.Value = input.Value
+	test.Detail = detail
+	test.ReportedAt = &now
+
+	if err := uc.commissioningRepository.SaveTest(ctx, test); err != nil {
+		return nil, err
+	}
+
+	return test, nil
+}
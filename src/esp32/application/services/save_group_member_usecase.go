@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+	userRepo "hex_go/src/users/domain/repositories"
+)
+
+// SaveGroupMemberUseCase implementa el caso de uso para dar a un usuario permisos sobre un grupo
+type SaveGroupMemberUseCase struct {
+	groupRepository repositories.DeviceGroupRepository
+	userRepository  userRepo.UserRepository
+	groupAuthorizer *GroupAuthorizer
+}
+
+// NewSaveGroupMemberUseCase crea una nueva instancia de SaveGroupMemberUseCase
+func NewSaveGroupMemberUseCase(groupRepo repositories.DeviceGroupRepository, userRepo userRepo.UserRepository, groupAuthorizer *GroupAuthorizer) *SaveGroupMemberUseCase {
+	return &SaveGroupMemberUseCase{
+		groupRepository: groupRepo,
+		userRepository:  userRepo,
+		groupAuthorizer: groupAuthorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso. Si el usuario ya era miembro, se reemplaza su rol.
+func (uc *SaveGroupMemberUseCase) Execute(ctx context.Context, groupID int, actor Actor, email string, role entities.GroupRole) (*entities.GroupMember, error) {
+	if !entities.IsValidGroupRole(role) {
+		return nil, fmt.Errorf("invalid role %q, use manager or viewer", role)
+	}
+
+	group, err := uc.groupAuthorizer.Authorize(ctx, groupID, actor, GroupAccessOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepository.FindByEmail(ctx, entities.NormalizeEmail(email))
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+	if user.ID == group.OwnerID {
+		return nil, errors.New("the owner of the group already has full access")
+	}
+
+	member := &entities.GroupMember{
+		GroupID:   group.ID,
+		UserID:    user.ID,
+		Email:     user.Email,
+		Role:      role,
+		CreatedAt: time.Now(),
+	}
+	if err := uc.groupRepository.SaveMember(ctx, member); err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
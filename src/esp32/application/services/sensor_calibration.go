@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// ErrSensorNotCalibratable se devuelve al calibrar un sensor cuyo tipo no usa calibraciones
+var ErrSensorNotCalibratable = errors.New("sensor type does not use calibrations")
+
+// maxCalibrationClockSkew tolera relojes de ESP32 levemente adelantados
+const maxCalibrationClockSkew = time.Minute
+
+// CalibrationInput contiene los datos de una calibración, tal como los envía un técnico o el ESP32
+type CalibrationInput struct {
+	R0           float64    `json:"r0"`
+	TemperatureC *float64   `json:"temperature_c"`
+	HumidityPct  *float64   `json:"humidity_pct"`
+	CalibratedAt *time.Time `json:"calibrated_at"` // Si se omite se usa la hora de recepción
+	Technician   string     `json:"technician"`
+	Notes        string     `json:"notes"`
+}
+
+// CalibrationStatus resume el estado de calibración de un sensor junto con su historial
+type CalibrationStatus struct {
+	SensorID     int                           `json:"sensor_id"`
+	SensorType   string                        `json:"sensor_type"`
+	IntervalDays int                           `json:"calibration_interval_days"`
+	DueAt        time.Time                     `json:"due_at"`
+	Overdue      bool                          `json:"overdue"`
+	Calibrations []*entities.SensorCalibration `json:"calibrations"`
+}
+
+// calibratableSensorType devuelve el tipo del sensor si sus sensores se calibran
+func calibratableSensorType(ctx context.Context, sensorTypeRepo repositories.SensorTypeRepository, sensor *entities.DeviceSensor) (*entities.SensorType, error) {
+	sensorType, err := sensorTypeRepo.FindByCode(ctx, sensor.SensorType)
+	if err != nil {
+		return nil, err
+	}
+	if sensorType == nil || !sensorType.RequiresCalibration() {
+		return nil, ErrSensorNotCalibratable
+	}
+	return sensorType, nil
+}
+
+// newCalibration valida los datos recibidos y crea la calibración del sensor
+func newCalibration(sensor *entities.DeviceSensor, input CalibrationInput, source entities.CalibrationSource) (*entities.SensorCalibration, error) {
+	now := time.Now()
+	calibratedAt := now
+	if input.CalibratedAt != nil {
+		if input.CalibratedAt.After(now.Add(maxCalibrationClockSkew)) {
+			return nil, errors.New("calibrated_at is in the future")
+		}
+		calibratedAt = *input.CalibratedAt
+	}
+
+	calibration := entities.NewSensorCalibration(sensor.ID, input.R0, source, calibratedAt)
+	calibration.TemperatureC = input.TemperatureC
+	calibration.HumidityPct = input.HumidityPct
+	calibration.Technician = strings.TrimSpace(input.Technician)
+	calibration.Notes = strings.TrimSpace(input.Notes)
+	if err := calibration.Validate(); err != nil {
+		return nil, err
+	}
+
+	return calibration, nil
+}
+
+// calibrationDueAt calcula cuándo vence la calibración de un sensor; un sensor nunca
+// calibrado vence un intervalo después de su instalación
+func calibrationDueAt(sensor *entities.DeviceSensor, sensorType *entities.SensorType, latest *entities.SensorCalibration) time.Time {
+	if latest != nil {
+		return latest.DueAt(*sensorType.CalibrationIntervalDays)
+	}
+	return sensor.InstalledAt.AddDate(0, 0, *sensorType.CalibrationIntervalDays)
+}
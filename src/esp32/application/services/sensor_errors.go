@@ -0,0 +1,27 @@
+package services
+
+import (
+	"errors"
+
+	"hex_go/src/esp32/domain/entities"
+)
+
+var (
+	// ErrSensorTypeNotFound se devuelve cuando el tipo de sensor no está registrado
+	ErrSensorTypeNotFound = errors.New("sensor type not found")
+	// ErrSensorTypeExists se devuelve al registrar un tipo de sensor con un código ya usado
+	ErrSensorTypeExists = errors.New("sensor type already exists")
+	// ErrSensorNotFound se devuelve cuando el sensor no existe o está instalado en otro ESP32
+	ErrSensorNotFound = errors.New("sensor not found")
+)
+
+// defaultKitSensors crea los sensores del kit por defecto para un ESP32 que se da de alta
+func defaultKitSensors(sensorTypes []*entities.SensorType) []*entities.DeviceSensor {
+	var sensors []*entities.DeviceSensor
+	for _, sensorType := range sensorTypes {
+		if sensorType.DefaultKit {
+			sensors = append(sensors, entities.NewDeviceSensor(0, sensorType.Code, "", ""))
+		}
+	}
+	return sensors
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// StartCommissioningUseCase implementa el caso de uso para que un instalador inicie el
+// comisionamiento de un ESP32, con una prueba pendiente por cada sensor instalado
+type StartCommissioningUseCase struct {
+	esp32Repository         repositories.ESP32Repository
+	deviceSensorRepository  repositories.DeviceSensorRepository
+	commissioningRepository repositories.CommissioningRepository
+}
+
+// NewStartCommissioningUseCase crea una nueva instancia de StartCommissioningUseCase
+func NewStartCommissioningUseCase(
+	esp32Repo repositories.ESP32Repository,
+	deviceSensorRepo repositories.DeviceSensorRepository,
+	commissioningRepo repositories.CommissioningRepository,
+) *StartCommissioningUseCase {
+	return &StartCommissioningUseCase{
+		esp32Repository:         esp32Repo,
+		deviceSensorRepository:  deviceSensorRepo,
+		commissioningRepository: commissioningRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *StartCommissioningUseCase) Execute(ctx context.Context, esp32ID int, actor Actor) (*entities.CommissioningSession, error) {
+	if !canCommission(actor) {
+		return nil, ErrInstallerRoleRequired
+	}
+
+	esp32, err := uc.esp32Repository.FindByID(ctx, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+	if esp32 == nil {
+		return nil, ErrESP32NotFound
+	}
+	if esp32.IsDecommissioned() {
+		return nil, ErrESP32Decommissioned
+	}
+
+	open, err := uc.commissioningRepository.FindOpenByESP32ID(ctx, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+	if open != nil {
+		return nil, ErrCommissioningInProgress
+	}
+
+	sensors, err := uc.deviceSensorRepository.FindByESP32ID(ctx, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(sensors) == 0 {
+		return nil, errors.New("the ESP32 has no sensors to test")
+	}
+
+	session := entities.NewCommissioningSession(esp32ID, actor.UserID, sensors)
+	return uc.commissioningRepository.Create(ctx, session)
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// SelfCalibrationInput es el resultado de una autocalibración tal como lo envía el firmware.
+// El sensor se identifica por sensor_id o, si el ESP32 tiene un solo sensor de ese tipo, por sensor_type.
+type SelfCalibrationInput struct {
+	SensorID   *int   `json:"sensor_id"`
+	SensorType string `json:"sensor_type"`
+	CalibrationInput
+}
+
+// SubmitSelfCalibrationUseCase implementa el caso de uso para registrar la calibración que un ESP32
+// realizó por su cuenta midiendo R0 en aire limpio
+type SubmitSelfCalibrationUseCase struct {
+	deviceSensorRepository      repositories.DeviceSensorRepository
+	sensorTypeRepository        repositories.SensorTypeRepository
+	sensorCalibrationRepository repositories.SensorCalibrationRepository
+}
+
+// NewSubmitSelfCalibrationUseCase crea una nueva instancia de SubmitSelfCalibrationUseCase
+func NewSubmitSelfCalibrationUseCase(
+	deviceSensorRepo repositories.DeviceSensorRepository,
+	sensorTypeRepo repositories.SensorTypeRepository,
+	sensorCalibrationRepo repositories.SensorCalibrationRepository,
+) *SubmitSelfCalibrationUseCase {
+	return &SubmitSelfCalibrationUseCase{
+		deviceSensorRepository:      deviceSensorRepo,
+		sensorTypeRepository:        sensorTypeRepo,
+		sensorCalibrationRepository: sensorCalibrationRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso para el ESP32 autenticado
+func (uc *SubmitSelfCalibrationUseCase) Execute(ctx context.Context, esp32ID int, input SelfCalibrationInput) (*entities.SensorCalibration, error) {
+	sensors, err := uc.deviceSensorRepository.FindByESP32ID(ctx, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+
+	sensor, err := findReportedSensor(sensors, input.SensorID, input.SensorType)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := calibratableSensorType(ctx, uc.sensorTypeRepository, sensor); err != nil {
+		return nil, err
+	}
+
+	// El técnico no aplica a una autocalibración
+	input.Technician = ""
+	calibration, err := newCalibration(sensor, input.CalibrationInput, entities.CalibrationSelf)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.sensorCalibrationRepository.Create(ctx, calibration)
+}
+
+// findReportedSensor identifica el sensor del ESP32 al que se refiere un mensaje del firmware
+func findReportedSensor(sensors []*entities.DeviceSensor, sensorID *int, sensorType string) (*entities.DeviceSensor, error) {
+	if sensorID != nil {
+		for _, sensor := range sensors {
+			if sensor.ID == *sensorID {
+				return sensor, nil
+			}
+		}
+		return nil, ErrSensorNotFound
+	}
+
+	sensorType = entities.NormalizeSensorTypeCode(sensorType)
+	if sensorType == "" {
+		return nil, errors.New("sensor_id or sensor_type is required")
+	}
+
+	var match *entities.DeviceSensor
+	for _, sensor := range sensors {
+		if sensor.SensorType != sensorType {
+			continue
+		}
+		if match != nil {
+			return nil, fmt.Errorf("the ESP32 has several %s sensors, sensor_id is required", sensorType)
+		}
+		match = sensor
+	}
+	if match == nil {
+		return nil, ErrSensorNotFound
+	}
+
+	return match, nil
+}
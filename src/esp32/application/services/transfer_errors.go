@@ -0,0 +1,12 @@
+package services
+
+import "errors"
+
+var (
+	// ErrTransferNotFound se devuelve cuando la transferencia solicitada no existe
+	ErrTransferNotFound = errors.New("transfer not found")
+	// ErrTransferForbidden se devuelve cuando el usuario no participa en la transferencia
+	ErrTransferForbidden = errors.New("you do not have access to this transfer")
+	// ErrTransferNotPending se devuelve al operar sobre una transferencia ya resuelta o vencida
+	ErrTransferNotPending = errors.New("transfer is no longer pending")
+)
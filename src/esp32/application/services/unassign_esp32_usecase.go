@@ -4,37 +4,47 @@ import (
 	"context"
 	"errors"
 
+	"hex_go/src/esp32/domain/entities"
 	"hex_go/src/esp32/domain/repositories"
 )
 
 // UnassignESP32UseCase implementa el caso de uso para desasignar un ESP32 de un usuario
 type UnassignESP32UseCase struct {
 	esp32Repository repositories.ESP32Repository
+	authorizer      *ESP32Authorizer
 }
 
 // NewUnassignESP32UseCase crea una nueva instancia de UnassignESP32UseCase
-func NewUnassignESP32UseCase(esp32Repo repositories.ESP32Repository) *UnassignESP32UseCase {
+func NewUnassignESP32UseCase(esp32Repo repositories.ESP32Repository, authorizer *ESP32Authorizer) *UnassignESP32UseCase {
 	return &UnassignESP32UseCase{
 		esp32Repository: esp32Repo,
+		authorizer:      authorizer,
 	}
 }
 
-// Execute ejecuta el caso de uso
-func (uc *UnassignESP32UseCase) Execute(ctx context.Context, esp32ID int) error {
-	// Verificar si el ESP32 existe
-	esp32, err := uc.esp32Repository.FindByID(ctx, esp32ID)
+// Execute ejecuta el caso de uso y devuelve el nuevo código de reclamo del ESP32
+func (uc *UnassignESP32UseCase) Execute(ctx context.Context, esp32ID int, actor Actor) (string, error) {
+	// Verificar que el ESP32 exista y pertenezca al actor
+	esp32, err := uc.authorizer.Authorize(ctx, esp32ID, actor)
 	if err != nil {
-		return err
-	}
-	if esp32 == nil {
-		return errors.New("ESP32 not found")
+		return "", err
 	}
 
 	// Verificar si el ESP32 está asignado a algún usuario
 	if esp32.UserID == nil {
-		return errors.New("ESP32 is not assigned to any user")
+		return "", errors.New("ESP32 is not assigned to any user")
+	}
+
+	// Generar un nuevo código de reclamo para el siguiente dueño
+	claimCode, err := entities.GenerateClaimCode()
+	if err != nil {
+		return "", err
 	}
 
 	// Desasignar el ESP32
-	return uc.esp32Repository.UnassignFromUser(ctx, esp32ID)
-}
\ No newline at end of file
+	if err := uc.esp32Repository.UnassignFromUser(ctx, esp32ID, entities.HashClaimCode(claimCode)); err != nil {
+		return "", err
+	}
+
+	return claimCode, nil
+}
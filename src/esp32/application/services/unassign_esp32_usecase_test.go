@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"hex_go/src/esp32/domain/entities"
+	userEntities "hex_go/src/users/domain/entities"
+)
+
+func TestUnassignESP32UseCase(t *testing.T) {
+	tests := []struct {
+		name         string
+		esp32ID      int
+		actor        Actor
+		wantErr      error
+		wantUnassign bool
+	}{
+		{"dueño desasigna su ESP32", ownedESP32ID, Actor{UserID: ownerID, Role: userEntities.RoleUser}, nil, true},
+		{"administrador desasigna cualquier ESP32", ownedESP32ID, Actor{UserID: adminID, Role: userEntities.RoleAdmin}, nil, true},
+		{"otro usuario no puede desasignarlo", ownedESP32ID, Actor{UserID: otherID, Role: userEntities.RoleUser}, ErrESP32Forbidden, false},
+		{"manager del grupo no puede desasignarlo", ownedESP32ID, Actor{UserID: managerID, Role: userEntities.RoleUser}, ErrESP32Forbidden, false},
+		{"ESP32 desconocido", unknownESP32ID, Actor{UserID: ownerID, Role: userEntities.RoleUser}, ErrESP32NotFound, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authorizer, esp32Repo := newTestAuthorizer()
+			useCase := NewUnassignESP32UseCase(esp32Repo, authorizer)
+
+			claimCode, err := useCase.Execute(context.Background(), tt.esp32ID, tt.actor)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+
+			esp32 := esp32Repo.esp32s[ownedESP32ID]
+			if !tt.wantUnassign {
+				if esp32.UserID == nil || *esp32.UserID != ownerID {
+					t.Fatalf("ESP32 owner changed to %v, want %d", esp32.UserID, ownerID)
+				}
+				return
+			}
+
+			if esp32.UserID != nil || esp32.GroupID != nil {
+				t.Fatalf("ESP32 still assigned: user %v, group %v", esp32.UserID, esp32.GroupID)
+			}
+			if claimCode == "" || esp32.ClaimCodeHash != entities.HashClaimCode(claimCode) {
+				t.Fatalf("claim code %q was not stored as the new claim code", claimCode)
+			}
+		})
+	}
+}
+
+func TestUnassignESP32UseCaseRejectsUnassignedESP32(t *testing.T) {
+	authorizer, esp32Repo := newTestAuthorizer()
+	esp32Repo.esp32s[ownedESP32ID].UserID = nil
+	useCase := NewUnassignESP32UseCase(esp32Repo, authorizer)
+
+	if _, err := useCase.Execute(context.Background(), ownedESP32ID, Actor{UserID: adminID, Role: userEntities.RoleAdmin}); err == nil {
+		t.Fatal("Execute() succeeded on an ESP32 without owner")
+	}
+}
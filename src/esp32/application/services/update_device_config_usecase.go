@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// UpdateDeviceConfigUseCase implementa el caso de uso para publicar una nueva versión
+// de la configuración remota de un ESP32
+type UpdateDeviceConfigUseCase struct {
+	deviceConfigRepository repositories.DeviceConfigRepository
+	sensorTypeRepository   repositories.SensorTypeRepository
+	authorizer             *ESP32Authorizer
+}
+
+// NewUpdateDeviceConfigUseCase crea una nueva instancia de UpdateDeviceConfigUseCase
+func NewUpdateDeviceConfigUseCase(
+	deviceConfigRepo repositories.DeviceConfigRepository,
+	sensorTypeRepo repositories.SensorTypeRepository,
+	authorizer *ESP32Authorizer,
+) *UpdateDeviceConfigUseCase {
+	return &UpdateDeviceConfigUseCase{
+		deviceConfigRepository: deviceConfigRepo,
+		sensorTypeRepository:   sensorTypeRepo,
+		authorizer:             authorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso. Si expectedVersion no es nulo, la configuración solo se
+// reemplaza cuando la versión vigente coincide, para no pisar cambios de otro usuario.
+func (uc *UpdateDeviceConfigUseCase) Execute(ctx context.Context, esp32ID int, actor Actor, settings entities.DeviceSettings, expectedVersion *int) (*entities.DeviceConfig, error) {
+	if _, err := uc.authorizer.AuthorizeOperate(ctx, esp32ID, actor); err != nil {
+		return nil, err
+	}
+
+	if settings.Thresholds == nil {
+		settings.Thresholds = map[string]entities.SensorThreshold{}
+	}
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+	for sensorType := range settings.Thresholds {
+		registered, err := uc.sensorTypeRepository.FindByCode(ctx, sensorType)
+		if err != nil {
+			return nil, err
+		}
+		if registered == nil {
+			return nil, fmt.Errorf("unknown sensor type %q", sensorType)
+		}
+	}
+
+	config, err := findDeviceConfig(ctx, uc.deviceConfigRepository, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+	if expectedVersion != nil && *expectedVersion != config.Version {
+		return nil, repositories.ErrConfigVersionConflict
+	}
+
+	previousVersion := config.Version
+	now := time.Now()
+	config.Version++
+	config.Settings = settings
+	config.UpdatedAt = &now
+	config.UpdatedBy = &actor.UserID
+
+	if err := uc.deviceConfigRepository.Save(ctx, config, previousVersion); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// ESP32MetadataChanges contiene los datos descriptivos a modificar; los campos nulos no se modifican
+// y una cadena vacía borra el valor guardado
+type ESP32MetadataChanges struct {
+	Nickname  *string
+	Room      *string
+	Address   *string
+	Latitude  *float64
+	Longitude *float64
+}
+
+// UpdateESP32MetadataUseCase implementa el caso de uso para editar el apodo, la ubicación
+// y la geolocalización de un ESP32
+type UpdateESP32MetadataUseCase struct {
+	esp32Repository repositories.ESP32Repository
+	authorizer      *ESP32Authorizer
+}
+
+// NewUpdateESP32MetadataUseCase crea una nueva instancia de UpdateESP32MetadataUseCase
+func NewUpdateESP32MetadataUseCase(esp32Repo repositories.ESP32Repository, authorizer *ESP32Authorizer) *UpdateESP32MetadataUseCase {
+	return &UpdateESP32MetadataUseCase{
+		esp32Repository: esp32Repo,
+		authorizer:      authorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *UpdateESP32MetadataUseCase) Execute(ctx context.Context, esp32ID int, actor Actor, changes ESP32MetadataChanges) (*entities.ESP32, error) {
+	// Verificar que el ESP32 exista y pertenezca al actor
+	esp32, err := uc.authorizer.AuthorizeOperate(ctx, esp32ID, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	// Las coordenadas solo tienen sentido juntas
+	if (changes.Latitude == nil) != (changes.Longitude == nil) {
+		return nil, errors.New("latitude and longitude must be provided together")
+	}
+	if changes.Latitude != nil {
+		if *changes.Latitude < -90 || *changes.Latitude > 90 {
+			return nil, errors.New("latitude must be between -90 and 90")
+		}
+		if *changes.Longitude < -180 || *changes.Longitude > 180 {
+			return nil, errors.New("longitude must be between -180 and 180")
+		}
+		esp32.Latitude = changes.Latitude
+		esp32.Longitude = changes.Longitude
+	}
+
+	if changes.Nickname != nil {
+		esp32.Nickname = strings.TrimSpace(*changes.Nickname)
+	}
+	if changes.Room != nil {
+		esp32.Room = strings.TrimSpace(*changes.Room)
+	}
+	if changes.Address != nil {
+		esp32.Address = strings.TrimSpace(*changes.Address)
+	}
+
+	if utf8.RuneCountInString(esp32.Nickname) > 100 || utf8.RuneCountInString(esp32.Room) > 100 ||
+		utf8.RuneCountInString(esp32.Address) > 255 {
+		return nil, errors.New("nickname and room must be at most 100 characters and address at most 255")
+	}
+
+	if err := uc.esp32Repository.UpdateMetadata(ctx, esp32); err != nil {
+		return nil, err
+	}
+
+	return esp32, nil
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// UpdateESP32UseCase implementa el caso de uso para editar los datos de fábrica de un ESP32
+type UpdateESP32UseCase struct {
+	esp32Repository repositories.ESP32Repository
+}
+
+// NewUpdateESP32UseCase crea una nueva instancia de UpdateESP32UseCase
+func NewUpdateESP32UseCase(esp32Repo repositories.ESP32Repository) *UpdateESP32UseCase {
+	return &UpdateESP32UseCase{
+		esp32Repository: esp32Repo,
+	}
+}
+
+// Execute ejecuta el caso de uso; si hardwareRevision está vacío se conserva el modelo de placa registrado
+func (uc *UpdateESP32UseCase) Execute(ctx context.Context, esp32ID int, numeroSerie, hardwareRevision string) (*entities.ESP32, error) {
+	numeroSerie = strings.TrimSpace(numeroSerie)
+	if numeroSerie == "" {
+		return nil, errors.New("numero_serie is required")
+	}
+	hardwareRevision = strings.TrimSpace(hardwareRevision)
+	if utf8.RuneCountInString(hardwareRevision) > 50 {
+		return nil, errors.New("hardware_revision must be at most 50 characters")
+	}
+
+	// Verificar si el ESP32 existe
+	esp32, err := uc.esp32Repository.FindByID(ctx, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+	if esp32 == nil {
+		return nil, ErrESP32NotFound
+	}
+	if esp32.IsDecommissioned() {
+		return nil, ErrESP32Decommissioned
+	}
+
+	// Verificar que el nuevo número de serie no pertenezca a otro ESP32
+	existing, err := uc.esp32Repository.FindByNumeroSerie(ctx, numeroSerie)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && existing.ID != esp32.ID {
+		return nil, errors.New("ESP32 with this serial number already exists")
+	}
+
+	esp32.NumeroSerie = numeroSerie
+	if hardwareRevision != "" {
+		esp32.HardwareRevision = hardwareRevision
+	}
+	if err := uc.esp32Repository.Update(ctx, esp32); err != nil {
+		return nil, err
+	}
+
+	return esp32, nil
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// UpdateGroupUseCase implementa el caso de uso para renombrar un grupo
+type UpdateGroupUseCase struct {
+	groupRepository repositories.DeviceGroupRepository
+	groupAuthorizer *GroupAuthorizer
+}
+
+// NewUpdateGroupUseCase crea una nueva instancia de UpdateGroupUseCase
+func NewUpdateGroupUseCase(groupRepo repositories.DeviceGroupRepository, groupAuthorizer *GroupAuthorizer) *UpdateGroupUseCase {
+	return &UpdateGroupUseCase{
+		groupRepository: groupRepo,
+		groupAuthorizer: groupAuthorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *UpdateGroupUseCase) Execute(ctx context.Context, groupID int, actor Actor, name string) (*entities.DeviceGroup, error) {
+	if err := entities.ValidateGroupName(name); err != nil {
+		return nil, err
+	}
+
+	group, err := uc.groupAuthorizer.Authorize(ctx, groupID, actor, GroupAccessOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	group.Name = strings.TrimSpace(name)
+	if err := uc.groupRepository.Update(ctx, group); err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
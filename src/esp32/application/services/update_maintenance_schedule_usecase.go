@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// UpdateMaintenanceScheduleUseCase implementa el caso de uso para cambiar el intervalo o el vencimiento de una tarea
+type UpdateMaintenanceScheduleUseCase struct {
+	maintenanceRepository repositories.MaintenanceRepository
+	authorizer            *ESP32Authorizer
+}
+
+// NewUpdateMaintenanceScheduleUseCase crea una nueva instancia de UpdateMaintenanceScheduleUseCase
+func NewUpdateMaintenanceScheduleUseCase(maintenanceRepo repositories.MaintenanceRepository, authorizer *ESP32Authorizer) *UpdateMaintenanceScheduleUseCase {
+	return &UpdateMaintenanceScheduleUseCase{
+		maintenanceRepository: maintenanceRepo,
+		authorizer:            authorizer,
+	}
+}
+
+// Execute ejecuta el caso de uso. El tipo de tarea y el sensor no se pueden cambiar.
+func (uc *UpdateMaintenanceScheduleUseCase) Execute(ctx context.Context, esp32ID, scheduleID int, actor Actor, input MaintenanceScheduleInput) (*entities.MaintenanceSchedule, error) {
+	if _, err := uc.authorizer.AuthorizeOperate(ctx, esp32ID, actor); err != nil {
+		return nil, err
+	}
+
+	schedule, err := findESP32Schedule(ctx, uc.maintenanceRepository, esp32ID, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.IntervalDays != nil {
+		schedule.IntervalDays = *input.IntervalDays
+	}
+	if input.DueAt != nil && !input.DueAt.Equal(schedule.DueAt) {
+		schedule.DueAt = *input.DueAt
+		// El nuevo vencimiento merece sus propios recordatorios
+		schedule.ReminderLevel = ""
+	}
+	if err := schedule.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.maintenanceRepository.UpdateSchedule(ctx, schedule); err != nil {
+		return nil, err
+	}
+	schedule.Status = schedule.StatusAt(time.Now())
+
+	return schedule, nil
+}
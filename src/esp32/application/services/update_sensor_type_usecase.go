@@ -0,0 +1,52 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// UpdateSensorTypeUseCase implementa el caso de uso para editar la definición de un tipo de sensor.
+// El código no se puede cambiar porque lo referencian los sensores instalados y las lecturas.
+type UpdateSensorTypeUseCase struct {
+	sensorTypeRepository repositories.SensorTypeRepository
+}
+
+// NewUpdateSensorTypeUseCase crea una nueva instancia de UpdateSensorTypeUseCase
+func NewUpdateSensorTypeUseCase(sensorTypeRepo repositories.SensorTypeRepository) *UpdateSensorTypeUseCase {
+	return &UpdateSensorTypeUseCase{
+		sensorTypeRepository: sensorTypeRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *UpdateSensorTypeUseCase) Execute(ctx context.Context, code string, changes *entities.SensorType) (*entities.SensorType, error) {
+	sensorType, err := uc.sensorTypeRepository.FindByCode(ctx, entities.NormalizeSensorTypeCode(code))
+	if err != nil {
+		return nil, err
+	}
+	if sensorType == nil {
+		return nil, ErrSensorTypeNotFound
+	}
+
+	sensorType.Name = strings.TrimSpace(changes.Name)
+	sensorType.Unit = strings.TrimSpace(changes.Unit)
+	sensorType.MinValue = changes.MinValue
+	sensorType.MaxValue = changes.MaxValue
+	sensorType.Digital = changes.Digital
+	sensorType.DefaultKit = changes.DefaultKit
+	sensorType.CalibrationIntervalDays = changes.CalibrationIntervalDays
+	sensorType.PPMCurveA = changes.PPMCurveA
+	sensorType.PPMCurveB = changes.PPMCurveB
+	if err := sensorType.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.sensorTypeRepository.Update(ctx, sensorType); err != nil {
+		return nil, err
+	}
+
+	return sensorType, nil
+}
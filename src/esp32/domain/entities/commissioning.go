@@ -0,0 +1,157 @@
+package entities
+
+import (
+	"time"
+)
+
+// CommissioningStatus representa el estado de una sesión de comisionamiento
+type CommissioningStatus string
+
+const (
+	CommissioningInProgress CommissioningStatus = "in_progress" // El instalador está probando los sensores
+	CommissioningPassed     CommissioningStatus = "passed"      // Todos los sensores pasaron y se emitió el certificado
+	CommissioningCancelled  CommissioningStatus = "cancelled"
+	CommissioningExpired    CommissioningStatus = "expired"
+)
+
+// TestKind representa el estímulo con el que el instalador dispara un sensor durante la prueba
+type TestKind string
+
+const (
+	TestSmoke       TestKind = "smoke"
+	TestGas         TestKind = "gas"
+	TestFlame       TestKind = "flame"
+	TestTemperature TestKind = "temperature"
+)
+
+// TestStatus representa el resultado de la prueba de un sensor
+type TestStatus string
+
+const (
+	TestPending TestStatus = "pending" // El ESP32 todavía no informó la prueba
+	TestPassed  TestStatus = "passed"
+	TestFailed  TestStatus = "failed"
+)
+
+// CommissioningSessionTTL es el plazo que tiene el instalador para probar todos los sensores
+const CommissioningSessionTTL = 4 * time.Hour
+
+// CommissioningSession representa la puesta en servicio de un ESP32 por parte de un instalador
+type CommissioningSession struct {
+	ID          int                 `json:"id"`
+	ESP32ID     int                 `json:"esp32_id"`
+	InstallerID *int                `json:"installer_id"`
+	Status      CommissioningStatus `json:"status"`
+	Notes       string              `json:"notes,omitempty"`
+	StartedAt   time.Time           `json:"started_at"`
+	ExpiresAt   time.Time           `json:"expires_at"`
+	CompletedAt *time.Time          `json:"completed_at"`
+	// Una prueba por cada sensor instalado al iniciar la sesión
+	Tests []*CommissioningTest `json:"tests,omitempty"`
+	// Certificado firmado, solo presente en las sesiones aprobadas
+	Certificate *SignedCertificate `json:"-"`
+}
+
+// CommissioningTest representa la prueba de un sensor dentro de una sesión de comisionamiento
+type CommissioningTest struct {
+	ID         int        `json:"id"`
+	SessionID  int        `json:"session_id"`
+	SensorID   int        `json:"sensor_id"`
+	SensorType string     `json:"sensor_type"`
+	Kind       TestKind   `json:"kind"`
+	Status     TestStatus `json:"status"`
+	Value      *float64   `json:"value"`            // Valor medido al disparar el sensor, si lo tiene
+	Detail     string     `json:"detail,omitempty"` // Mensaje del firmware
+	ReportedAt *time.Time `json:"reported_at"`
+}
+
+// CommissioningCertificate es el contenido del certificado que recibe el residente al finalizar el comisionamiento
+type CommissioningCertificate struct {
+	SessionID        int                     `json:"session_id"`
+	ESP32ID          int                     `json:"esp32_id"`
+	ESP32NumeroSerie string                  `json:"esp32_numero_serie"`
+	InstallerID      int                     `json:"installer_id"`
+	InstallerName    string                  `json:"installer_name"`
+	StartedAt        time.Time               `json:"started_at"`
+	IssuedAt         time.Time               `json:"issued_at"`
+	Notes            string                  `json:"notes,omitempty"`
+	Tests            []CertificateTestResult `json:"tests"`
+}
+
+// CertificateTestResult es el resultado de la prueba de un sensor tal como figura en el certificado
+type CertificateTestResult struct {
+	SensorID          int       `json:"sensor_id"`
+	SensorType        string    `json:"sensor_type"`
+	SensorNumeroSerie string    `json:"sensor_numero_serie,omitempty"`
+	Kind              TestKind  `json:"kind"`
+	Value             *float64  `json:"value,omitempty"`
+	TestedAt          time.Time `json:"tested_at"`
+}
+
+// SignedCertificate guarda el certificado serializado junto con su firma. La firma cubre
+// exactamente los bytes de Payload, por lo que se guardan y se devuelven sin volver a serializarlos.
+type SignedCertificate struct {
+	Payload   []byte
+	Signature string // Firma en base64
+}
+
+// NewCommissioningSession crea una sesión en curso con una prueba pendiente por sensor
+func NewCommissioningSession(esp32ID, installerID int, sensors []*DeviceSensor) *CommissioningSession {
+	now := time.Now()
+	session := &CommissioningSession{
+		ESP32ID:     esp32ID,
+		InstallerID: &installerID,
+		Status:      CommissioningInProgress,
+		StartedAt:   now,
+		ExpiresAt:   now.Add(CommissioningSessionTTL),
+	}
+	for _, sensor := range sensors {
+		session.Tests = append(session.Tests, &CommissioningTest{
+			SensorID:   sensor.ID,
+			SensorType: sensor.SensorType,
+			Kind:       DefaultTestKind(sensor.SensorType),
+			Status:     TestPending,
+		})
+	}
+	return session
+}
+
+// IsOpen indica si la sesión todavía acepta resultados de pruebas
+func (s *CommissioningSession) IsOpen() bool {
+	return s.Status == CommissioningInProgress
+}
+
+// FindTest devuelve la prueba del sensor indicado, o nil si el sensor no forma parte de la sesión
+func (s *CommissioningSession) FindTest(sensorID int) *CommissioningTest {
+	for _, test := range s.Tests {
+		if test.SensorID == sensorID {
+			return test
+		}
+	}
+	return nil
+}
+
+// DefaultTestKind devuelve la prueba con la que se dispara cada sensor del kit;
+// para otros tipos la informa el firmware
+func DefaultTestKind(sensorType string) TestKind {
+	switch sensorType {
+	case SensorKY026:
+		return TestFlame
+	case SensorMQ2:
+		return TestSmoke
+	case SensorMQ135:
+		return TestGas
+	case SensorDHT22:
+		return TestTemperature
+	}
+	return ""
+}
+
+// IsValidTestKind indica si la prueba es una de las que soporta el firmware
+func IsValidTestKind(kind TestKind) bool {
+	switch kind {
+	case TestSmoke, TestGas, TestFlame, TestTemperature:
+		return true
+	}
+	return false
+}
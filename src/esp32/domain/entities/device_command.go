@@ -0,0 +1,77 @@
+package entities
+
+import (
+	"time"
+)
+
+// CommandType representa la acción que se le pide ejecutar a un ESP32
+type CommandType string
+
+const (
+	CommandSilence     CommandType = "silence"     // Silencia el buzzer de una alarma en curso
+	CommandTestSiren   CommandType = "test_siren"  // Hace sonar el buzzer para probarlo
+	CommandReboot      CommandType = "reboot"      // Reinicia el ESP32
+	CommandRecalibrate CommandType = "recalibrate" // Recalibra los sensores de gas
+)
+
+// CommandStatus representa el estado de entrega y ejecución de un comando
+type CommandStatus string
+
+const (
+	CommandPending   CommandStatus = "pending"   // En cola, el ESP32 todavía no lo recibió
+	CommandDelivered CommandStatus = "delivered" // El ESP32 lo recibió pero no confirmó su ejecución
+	CommandSucceeded CommandStatus = "succeeded"
+	CommandFailed    CommandStatus = "failed"
+	CommandExpired   CommandStatus = "expired"
+)
+
+// Duraciones aceptadas para los comandos
+const (
+	DefaultCommandTTL         = 10 * time.Minute
+	MaxCommandTTL             = 24 * time.Hour
+	MaxCommandDurationSeconds = 3600
+)
+
+// DeviceCommand representa un comando encolado para un ESP32
+type DeviceCommand struct {
+	ID      int         `json:"id"`
+	ESP32ID int         `json:"esp32_id"`
+	Type    CommandType `json:"type"`
+	// Segundos que dura el silencio o la prueba de sirena; 0 usa el valor del firmware
+	DurationSeconds int           `json:"duration_seconds,omitempty"`
+	IssuedBy        *int          `json:"issued_by"`
+	Status          CommandStatus `json:"status"`
+	Result          string        `json:"result,omitempty"` // Mensaje devuelto por el firmware
+	CreatedAt       time.Time     `json:"created_at"`
+	ExpiresAt       time.Time     `json:"expires_at"`
+	DeliveredAt     *time.Time    `json:"delivered_at"`
+	CompletedAt     *time.Time    `json:"completed_at"`
+}
+
+// NewDeviceCommand crea una nueva instancia de DeviceCommand pendiente
+func NewDeviceCommand(esp32ID int, commandType CommandType, durationSeconds int, issuedBy int, ttl time.Duration) *DeviceCommand {
+	now := time.Now()
+	return &DeviceCommand{
+		ESP32ID:         esp32ID,
+		Type:            commandType,
+		DurationSeconds: durationSeconds,
+		IssuedBy:        &issuedBy,
+		Status:          CommandPending,
+		CreatedAt:       now,
+		ExpiresAt:       now.Add(ttl),
+	}
+}
+
+// IsOpen indica si el comando todavía espera la confirmación del ESP32
+func (c *DeviceCommand) IsOpen() bool {
+	return c.Status == CommandPending || c.Status == CommandDelivered
+}
+
+// IsValidCommandType indica si el tipo de comando es soportado por el firmware
+func IsValidCommandType(commandType CommandType) bool {
+	switch commandType {
+	case CommandSilence, CommandTestSiren, CommandReboot, CommandRecalibrate:
+		return true
+	}
+	return false
+}
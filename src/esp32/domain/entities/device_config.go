@@ -0,0 +1,101 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Límites aceptados para los parámetros de configuración
+const (
+	DefaultHeartbeatIntervalSeconds = 60
+	MinHeartbeatIntervalSeconds     = 10
+	MaxHeartbeatIntervalSeconds     = 3600
+	MaxBuzzerDurationSeconds        = 300
+)
+
+// SensorThreshold define los valores a partir de los cuales un sensor se considera en alerta;
+// un límite nulo no se evalúa
+type SensorThreshold struct {
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+}
+
+// Exceeds indica si una lectura queda fuera del rango permitido
+func (t SensorThreshold) Exceeds(value float64) bool {
+	return (t.Min != nil && value < *t.Min) || (t.Max != nil && value > *t.Max)
+}
+
+// BuzzerConfig define cómo suena el buzzer del ESP32 cuando un sensor entra en alerta
+type BuzzerConfig struct {
+	Enabled         bool `json:"enabled"`
+	DurationSeconds int  `json:"duration_seconds"` // 0 suena hasta que la alerta se normaliza
+}
+
+// DeviceSettings es el documento de configuración que descarga el firmware
+type DeviceSettings struct {
+	Thresholds               map[string]SensorThreshold `json:"thresholds"`
+	HeartbeatIntervalSeconds int                        `json:"heartbeat_interval_seconds"`
+	Buzzer                   BuzzerConfig               `json:"buzzer"`
+}
+
+// DefaultDeviceSettings devuelve la configuración que usa un ESP32 que nunca fue configurado
+func DefaultDeviceSettings() DeviceSettings {
+	return DeviceSettings{
+		Thresholds:               map[string]SensorThreshold{},
+		HeartbeatIntervalSeconds: DefaultHeartbeatIntervalSeconds,
+		Buzzer:                   BuzzerConfig{Enabled: true},
+	}
+}
+
+// Validate comprueba que el documento de configuración sea aplicable por el firmware.
+// Que los umbrales correspondan a tipos de sensor registrados lo verifica el caso de uso.
+func (s DeviceSettings) Validate() error {
+	for sensor, threshold := range s.Thresholds {
+		if threshold.Min != nil && threshold.Max != nil && *threshold.Min > *threshold.Max {
+			return fmt.Errorf("threshold min for %s must not be greater than max", sensor)
+		}
+	}
+
+	if s.HeartbeatIntervalSeconds < MinHeartbeatIntervalSeconds || s.HeartbeatIntervalSeconds > MaxHeartbeatIntervalSeconds {
+		return fmt.Errorf("heartbeat_interval_seconds must be between %d and %d",
+			MinHeartbeatIntervalSeconds, MaxHeartbeatIntervalSeconds)
+	}
+
+	if s.Buzzer.DurationSeconds < 0 || s.Buzzer.DurationSeconds > MaxBuzzerDurationSeconds {
+		return errors.New("buzzer duration_seconds must be between 0 and " + strconv.Itoa(MaxBuzzerDurationSeconds))
+	}
+
+	return nil
+}
+
+// DeviceConfig representa la configuración versionada de un ESP32
+type DeviceConfig struct {
+	ESP32ID   int            `json:"esp32_id"`
+	Version   int            `json:"version"` // 0 indica que el ESP32 usa la configuración por defecto
+	Settings  DeviceSettings `json:"config"`
+	UpdatedAt *time.Time     `json:"updated_at"`
+	UpdatedBy *int           `json:"updated_by"`
+	// Última versión que el ESP32 confirmó haber aplicado
+	AppliedVersion *int       `json:"applied_version"`
+	AppliedAt      *time.Time `json:"applied_at"`
+}
+
+// NewDefaultDeviceConfig crea la configuración por defecto de un ESP32
+func NewDefaultDeviceConfig(esp32ID int) *DeviceConfig {
+	return &DeviceConfig{
+		ESP32ID:  esp32ID,
+		Settings: DefaultDeviceSettings(),
+	}
+}
+
+// ETag devuelve el identificador de la versión que el firmware envía en If-None-Match
+func (c *DeviceConfig) ETag() string {
+	return `"` + strconv.Itoa(c.Version) + `"`
+}
+
+// InSync indica si el ESP32 ya aplicó la versión vigente de la configuración
+func (c *DeviceConfig) InSync() bool {
+	return c.AppliedVersion != nil && *c.AppliedVersion == c.Version
+}
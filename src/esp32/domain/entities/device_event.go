@@ -0,0 +1,30 @@
+package entities
+
+import (
+	"time"
+)
+
+// DeviceEventType representa el tipo de evento de conectividad de un ESP32
+type DeviceEventType string
+
+const (
+	DeviceEventOffline DeviceEventType = "offline"
+	DeviceEventOnline  DeviceEventType = "online"
+)
+
+// DeviceEvent representa un cambio de conectividad de un ESP32
+type DeviceEvent struct {
+	ID        int             `json:"id"`
+	ESP32ID   int             `json:"esp32_id"`
+	Type      DeviceEventType `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// NewDeviceEvent crea una nueva instancia de DeviceEvent
+func NewDeviceEvent(esp32ID int, eventType DeviceEventType) *DeviceEvent {
+	return &DeviceEvent{
+		ESP32ID:   esp32ID,
+		Type:      eventType,
+		CreatedAt: time.Now(),
+	}
+}
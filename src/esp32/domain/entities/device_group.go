@@ -0,0 +1,95 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// GroupKind representa el nivel de un grupo dentro de la jerarquía de un cliente
+type GroupKind string
+
+const (
+	GroupSite     GroupKind = "site"
+	GroupBuilding GroupKind = "building"
+	GroupFloor    GroupKind = "floor"
+	GroupZone     GroupKind = "zone"
+)
+
+// groupKindLevels ordena los niveles de la jerarquía, del más general al más específico
+var groupKindLevels = map[GroupKind]int{
+	GroupSite:     0,
+	GroupBuilding: 1,
+	GroupFloor:    2,
+	GroupZone:     3,
+}
+
+// GroupRole representa el permiso que un miembro tiene sobre un grupo y sus subgrupos
+type GroupRole string
+
+const (
+	GroupRoleManager GroupRole = "manager" // Ve y opera los ESP32 del grupo
+	GroupRoleViewer  GroupRole = "viewer"  // Solo ve los ESP32 del grupo
+)
+
+// DeviceGroup representa un grupo de ESP32 (sitio, edificio, piso o zona)
+type DeviceGroup struct {
+	ID        int       `json:"id"`
+	ParentID  *int      `json:"parent_id"`
+	Kind      GroupKind `json:"kind"`
+	Name      string    `json:"name"`
+	OwnerID   int       `json:"owner_id"` // Cliente dueño de la jerarquía y de los ESP32 que contiene
+	CreatedAt time.Time `json:"created_at"`
+	// Subgrupos directos; solo se cargan al consultar un grupo
+	Children []*DeviceGroup `json:"children,omitempty"`
+}
+
+// GroupMember representa el permiso de un usuario sobre un grupo
+type GroupMember struct {
+	GroupID   int       `json:"group_id"`
+	UserID    int       `json:"user_id"`
+	Email     string    `json:"email,omitempty"`
+	Role      GroupRole `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewDeviceGroup crea una nueva instancia de DeviceGroup
+func NewDeviceGroup(parentID *int, kind GroupKind, name string, ownerID int) *DeviceGroup {
+	return &DeviceGroup{
+		ParentID:  parentID,
+		Kind:      kind,
+		Name:      strings.TrimSpace(name),
+		OwnerID:   ownerID,
+		CreatedAt: time.Now(),
+	}
+}
+
+// IsValidGroupKind indica si el nivel es uno de los soportados
+func IsValidGroupKind(kind GroupKind) bool {
+	_, ok := groupKindLevels[kind]
+	return ok
+}
+
+// IsValidGroupRole indica si el rol es uno de los soportados
+func IsValidGroupRole(role GroupRole) bool {
+	return role == GroupRoleManager || role == GroupRoleViewer
+}
+
+// CanContain indica si un grupo de este nivel puede tener un subgrupo del nivel indicado.
+// Los niveles intermedios se pueden omitir, por ejemplo un edificio puede tener zonas sin pisos.
+func (k GroupKind) CanContain(child GroupKind) bool {
+	return groupKindLevels[child] > groupKindLevels[k]
+}
+
+// ValidateGroupName comprueba que el nombre del grupo no esté vacío ni sea demasiado largo
+func ValidateGroupName(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.New("name is required")
+	}
+	if utf8.RuneCountInString(name) > 100 {
+		return errors.New("name must be at most 100 characters")
+	}
+	return nil
+}
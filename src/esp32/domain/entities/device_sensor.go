@@ -0,0 +1,36 @@
+package entities
+
+import (
+	"time"
+)
+
+// DeviceSensor representa un sensor instalado en un ESP32
+type DeviceSensor struct {
+	ID          int    `json:"id"`
+	ESP32ID     int    `json:"esp32_id"`
+	SensorType  string `json:"sensor_type"`
+	NumeroSerie string `json:"numero_serie"` // Número de serie de fábrica; puede estar vacío
+	Label       string `json:"label"`        // Permite distinguir varios sensores del mismo tipo
+	Alarm       bool   `json:"alarm"`
+	// Momento en que se activó la alarma vigente o la última que hubo
+	FechaActivacion *time.Time `json:"fecha_activacion"`
+	InstalledAt     time.Time  `json:"installed_at"`
+	// Momento en que el sensor se reemplazó; los sensores retirados se conservan para el historial
+	RetiredAt *time.Time `json:"retired_at,omitempty"`
+}
+
+// NewDeviceSensor crea una nueva instancia de DeviceSensor
+func NewDeviceSensor(esp32ID int, sensorType, numeroSerie, label string) *DeviceSensor {
+	return &DeviceSensor{
+		ESP32ID:     esp32ID,
+		SensorType:  sensorType,
+		NumeroSerie: numeroSerie,
+		Label:       label,
+		InstalledAt: time.Now(),
+	}
+}
+
+// IsRetired indica si el sensor fue reemplazado y ya no está instalado
+func (s *DeviceSensor) IsRetired() bool {
+	return s.RetiredAt != nil
+}
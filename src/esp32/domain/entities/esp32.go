@@ -1,33 +1,56 @@
 package entities
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
 	"time"
 )
 
+// claimCodeAlphabet excluye caracteres fáciles de confundir al leer la etiqueta (0/O, 1/I)
+const claimCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
 // ESP32 representa la entidad de dominio para un dispositivo ESP32
 type ESP32 struct {
-	ID          int       `json:"id"`
-	IDKY026     int       `json:"id_ky_026"`
-	IDMQ2       int       `json:"id_mq_2"`
-	IDMQ135     int       `json:"id_mq_135"`
-	IDDHT22     int       `json:"id_dht_22"`
-	NumeroSerie string    `json:"numero_serie"`
-	UserID      *int      `json:"user_id"` // Puede ser nulo si no está asignado
-	CreatedAt   time.Time `json:"created_at"`
+	ID               int        `json:"id"`
+	NumeroSerie      string     `json:"numero_serie"`
+	HardwareRevision string     `json:"hardware_revision"` // Modelo de placa; decide qué imágenes de firmware recibe
+	UserID           *int       `json:"user_id"`           // Puede ser nulo si no está asignado
+	AssignedAt       *time.Time `json:"assigned_at"`       // Momento en que el dueño actual recibió el ESP32
+	CreatedAt        time.Time  `json:"created_at"`        // Momento en que el ESP32 se dio de alta en el inventario
+	DecommissionedAt *time.Time `json:"decommissioned_at"` // Momento en que se retiró de servicio
+	PurgedAt         *time.Time `json:"purged_at"`         // Momento en que se purgaron sus lecturas y ubicación
+	LastSeenAt       *time.Time `json:"last_seen_at"`      // Último heartbeat recibido
+	Online           bool       `json:"online"`
+	Nickname         string     `json:"nickname"`
+	Room             string     `json:"room"` // Habitación o ubicación dentro de la vivienda
+	Address          string     `json:"address"`
+	Latitude         *float64   `json:"latitude"`
+	Longitude        *float64   `json:"longitude"`
+	GroupID          *int       `json:"group_id"` // Grupo (sitio, edificio, piso o zona) en el que está instalado
+	// Sensores instalados; solo se cargan en las consultas que los necesitan
+	Sensors []*DeviceSensor `json:"sensors,omitempty"`
+	// Hash del código de reclamo impreso en la etiqueta QR; vacío si el ESP32 ya fue reclamado
+	ClaimCodeHash string `json:"-"`
+	// Hash del token con el que el firmware se autentica ante la API
+	DeviceTokenHash string `json:"-"`
 }
 
 // NewESP32 crea una nueva instancia de ESP32
-func NewESP32(idKY026, idMQ2, idMQ135, idDHT22 int, numeroSerie string) *ESP32 {
+func NewESP32(numeroSerie string) *ESP32 {
 	return &ESP32{
-		IDKY026:     idKY026,
-		IDMQ2:       idMQ2,
-		IDMQ135:     idMQ135,
-		IDDHT22:     idDHT22,
 		NumeroSerie: numeroSerie,
 		CreatedAt:   time.Now(),
 	}
 }
 
+// IsDecommissioned indica si el ESP32 fue retirado de servicio
+func (e *ESP32) IsDecommissioned() bool {
+	return e.DecommissionedAt != nil
+}
+
 // AssignToUser asigna el ESP32 a un usuario
 func (e *ESP32) AssignToUser(userID int) {
 	e.UserID = &userID
@@ -36,4 +59,69 @@ func (e *ESP32) AssignToUser(userID int) {
 // UnassignFromUser desasigna el ESP32 de cualquier usuario
 func (e *ESP32) UnassignFromUser() {
 	e.UserID = nil
-}
\ No newline at end of file
+}
+
+// GenerateClaimCode genera un nuevo código de reclamo con el formato XXXX-XXXX
+func GenerateClaimCode() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	code := make([]byte, 0, 9)
+	for i, b := range buf {
+		if i == 4 {
+			code = append(code, '-')
+		}
+		code = append(code, claimCodeAlphabet[int(b)%len(claimCodeAlphabet)])
+	}
+	return string(code), nil
+}
+
+// HashClaimCode normaliza un código de reclamo y devuelve su hash SHA-256 en hexadecimal
+func HashClaimCode(code string) string {
+	normalized := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// SetClaimCode guarda el hash de un nuevo código de reclamo
+func (e *ESP32) SetClaimCode(code string) {
+	e.ClaimCodeHash = HashClaimCode(code)
+}
+
+// ClaimCodeMatches indica si el código recibido corresponde al código de reclamo vigente
+func (e *ESP32) ClaimCodeMatches(code string) bool {
+	if e.ClaimCodeHash == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(e.ClaimCodeHash), []byte(HashClaimCode(code))) == 1
+}
+
+// GenerateDeviceToken genera un nuevo token secreto para el firmware del ESP32
+func GenerateDeviceToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// HashDeviceToken devuelve el hash SHA-256 en hexadecimal de un token de dispositivo
+func HashDeviceToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// SetDeviceToken guarda el hash de un nuevo token de dispositivo
+func (e *ESP32) SetDeviceToken(token string) {
+	e.DeviceTokenHash = HashDeviceToken(token)
+}
+
+// DeviceTokenMatches indica si el token recibido corresponde al token vigente del ESP32
+func (e *ESP32) DeviceTokenMatches(token string) bool {
+	if e.DeviceTokenHash == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(e.DeviceTokenHash), []byte(HashDeviceToken(token))) == 1
+}
@@ -0,0 +1,37 @@
+package entities
+
+import (
+	"time"
+)
+
+// AssignmentReason representa el motivo por el que comenzó o terminó una asignación de un ESP32
+type AssignmentReason string
+
+const (
+	// AssignmentClaim indica que el usuario reclamó el ESP32 con su código de reclamo
+	AssignmentClaim AssignmentReason = "claim"
+	// AssignmentTransfer indica que el ESP32 cambió de dueño por una transferencia aceptada
+	AssignmentTransfer AssignmentReason = "transfer"
+	// AssignmentUnassign indica que el dueño o un administrador desasignó el ESP32
+	AssignmentUnassign AssignmentReason = "unassign"
+	// AssignmentLegacy marca las asignaciones existentes antes de que se guardara el historial
+	AssignmentLegacy AssignmentReason = "legacy"
+)
+
+// ESP32Assignment representa el período durante el que un usuario fue dueño de un ESP32
+type ESP32Assignment struct {
+	ID      int `json:"id"`
+	ESP32ID int `json:"esp32_id"`
+	// UserID es nulo si la cuenta del usuario fue eliminada después de la asignación
+	UserID         *int             `json:"user_id"`
+	Email          string           `json:"email,omitempty"`
+	AssignedAt     time.Time        `json:"assigned_at"`
+	UnassignedAt   *time.Time       `json:"unassigned_at"` // Nulo mientras la asignación sigue vigente
+	AssignReason   AssignmentReason `json:"assign_reason"`
+	UnassignReason AssignmentReason `json:"unassign_reason,omitempty"`
+}
+
+// IsCurrent indica si la asignación sigue vigente
+func (a *ESP32Assignment) IsCurrent() bool {
+	return a.UnassignedAt == nil
+}
@@ -0,0 +1,62 @@
+package entities
+
+import (
+	"strings"
+	"time"
+)
+
+// TransferStatus representa el estado de una transferencia de propiedad
+type TransferStatus string
+
+const (
+	TransferPending   TransferStatus = "pending"
+	TransferAccepted  TransferStatus = "accepted"
+	TransferRejected  TransferStatus = "rejected"
+	TransferCancelled TransferStatus = "cancelled"
+	TransferExpired   TransferStatus = "expired"
+)
+
+// TransferTTL es el tiempo que tiene el destinatario para aceptar una transferencia
+const TransferTTL = 7 * 24 * time.Hour
+
+// ESP32Transfer representa la transferencia de un ESP32 de su dueño actual a otro usuario
+type ESP32Transfer struct {
+	ID          int            `json:"id"`
+	ESP32ID     int            `json:"esp32_id"`
+	NumeroSerie string         `json:"numero_serie"`
+	FromUserID  int            `json:"from_user_id"`
+	ToEmail     string         `json:"to_email"`
+	ToUserID    *int           `json:"to_user_id"` // Se completa cuando el destinatario acepta
+	Status      TransferStatus `json:"status"`
+	CreatedAt   time.Time      `json:"created_at"`
+	ExpiresAt   time.Time      `json:"expires_at"`
+	ResolvedAt  *time.Time     `json:"resolved_at"`
+}
+
+// NewESP32Transfer crea una nueva instancia de ESP32Transfer pendiente
+func NewESP32Transfer(esp32ID, fromUserID int, toEmail string) *ESP32Transfer {
+	now := time.Now()
+	return &ESP32Transfer{
+		ESP32ID:    esp32ID,
+		FromUserID: fromUserID,
+		ToEmail:    NormalizeEmail(toEmail),
+		Status:     TransferPending,
+		CreatedAt:  now,
+		ExpiresAt:  now.Add(TransferTTL),
+	}
+}
+
+// IsPending indica si la transferencia todavía puede aceptarse, rechazarse o cancelarse
+func (t *ESP32Transfer) IsPending() bool {
+	return t.Status == TransferPending && time.Now().Before(t.ExpiresAt)
+}
+
+// IsAddressedTo indica si la transferencia está dirigida al email indicado
+func (t *ESP32Transfer) IsAddressedTo(email string) bool {
+	return t.ToEmail == NormalizeEmail(email)
+}
+
+// NormalizeEmail normaliza un email para poder compararlo
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
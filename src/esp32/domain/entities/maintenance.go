@@ -0,0 +1,179 @@
+package entities
+
+import (
+	"errors"
+	"time"
+	"unicode/utf8"
+)
+
+// MaintenanceTask representa una tarea periódica exigida por la normativa contra incendios
+type MaintenanceTask string
+
+const (
+	MaintenanceMonthlyTest       MaintenanceTask = "monthly_test"       // Prueba funcional de la alarma
+	MaintenanceAnnualInspection  MaintenanceTask = "annual_inspection"  // Inspección completa por un técnico
+	MaintenanceSensorReplacement MaintenanceTask = "sensor_replacement" // Reemplazo de un sensor al final de su vida útil
+)
+
+// MaintenanceStatus representa el estado de cumplimiento de una tarea de mantenimiento
+type MaintenanceStatus string
+
+const (
+	MaintenanceOK      MaintenanceStatus = "ok"
+	MaintenanceDue     MaintenanceStatus = "due"     // Vence dentro de MaintenanceDueWindow
+	MaintenanceOverdue MaintenanceStatus = "overdue" // Venció sin realizarse
+	MaintenanceFailed  MaintenanceStatus = "failed"  // La última vez que se realizó no fue aprobada
+)
+
+// MaintenanceResult representa el resultado de una tarea realizada
+type MaintenanceResult string
+
+const (
+	MaintenancePassed       MaintenanceResult = "passed"
+	MaintenanceResultFailed MaintenanceResult = "failed"
+)
+
+// MaintenanceDueWindow es la anticipación con la que una tarea se considera próxima a vencer
+const MaintenanceDueWindow = 7 * 24 * time.Hour
+
+// MaxMaintenanceIntervalDays limita el intervalo de una tarea a diez años
+const MaxMaintenanceIntervalDays = 3650
+
+// MaintenanceSchedule representa una tarea periódica de mantenimiento de un ESP32 o de uno de sus sensores
+type MaintenanceSchedule struct {
+	ID           int             `json:"id"`
+	ESP32ID      int             `json:"esp32_id"`
+	SensorID     *int            `json:"sensor_id"` // Solo en los reemplazos de sensores
+	Task         MaintenanceTask `json:"task"`
+	IntervalDays int             `json:"interval_days"`
+	DueAt        time.Time       `json:"due_at"`
+	// Última vez que se realizó la tarea y su resultado
+	LastPerformedAt *time.Time        `json:"last_performed_at"`
+	LastResult      MaintenanceResult `json:"last_result,omitempty"`
+	Status          MaintenanceStatus `json:"status"` // Se calcula al consultar la tarea
+	// Último recordatorio enviado para el vencimiento actual: due, overdue o vacío
+	ReminderLevel MaintenanceStatus `json:"-"`
+	CreatedAt     time.Time         `json:"created_at"`
+}
+
+// MaintenanceRecord representa una tarea de mantenimiento realizada
+type MaintenanceRecord struct {
+	ID          int               `json:"id"`
+	ScheduleID  *int              `json:"schedule_id"` // Nulo si la tarea se eliminó después
+	ESP32ID     int               `json:"esp32_id"`
+	SensorID    *int              `json:"sensor_id"`
+	Task        MaintenanceTask   `json:"task"`
+	Result      MaintenanceResult `json:"result"`
+	PerformedAt time.Time         `json:"performed_at"`
+	PerformedBy *int              `json:"performed_by"` // Usuario que registró la tarea
+	Technician  string            `json:"technician,omitempty"`
+	Notes       string            `json:"notes,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+}
+
+// NewMaintenanceSchedule crea una nueva instancia de MaintenanceSchedule
+func NewMaintenanceSchedule(esp32ID int, sensorID *int, task MaintenanceTask, intervalDays int, dueAt time.Time) *MaintenanceSchedule {
+	return &MaintenanceSchedule{
+		ESP32ID:      esp32ID,
+		SensorID:     sensorID,
+		Task:         task,
+		IntervalDays: intervalDays,
+		DueAt:        dueAt,
+		CreatedAt:    time.Now(),
+	}
+}
+
+// Validate comprueba que la tarea tenga un tipo y un intervalo válidos
+func (s *MaintenanceSchedule) Validate() error {
+	if !IsValidMaintenanceTask(s.Task) {
+		return errors.New("invalid maintenance task")
+	}
+	if s.Task == MaintenanceSensorReplacement && s.SensorID == nil {
+		return errors.New("sensor_id is required for sensor replacements")
+	}
+	if s.Task != MaintenanceSensorReplacement && s.SensorID != nil {
+		return errors.New("sensor_id is only allowed for sensor replacements")
+	}
+	if s.IntervalDays <= 0 || s.IntervalDays > MaxMaintenanceIntervalDays {
+		return errors.New("interval_days must be between 1 and 3650")
+	}
+	return nil
+}
+
+// StatusAt calcula el estado de la tarea en el momento indicado. Una tarea vencida se informa
+// como tal aunque la última vez haya fallado, ya que es lo más urgente de resolver.
+func (s *MaintenanceSchedule) StatusAt(now time.Time) MaintenanceStatus {
+	switch {
+	case !now.Before(s.DueAt):
+		return MaintenanceOverdue
+	case s.LastResult == MaintenanceResultFailed:
+		return MaintenanceFailed
+	case !now.Before(s.DueAt.Add(-MaintenanceDueWindow)):
+		return MaintenanceDue
+	}
+	return MaintenanceOK
+}
+
+// Apply registra en la tarea una realización. Si fue aprobada el vencimiento avanza un intervalo
+// desde ese momento; si falló se mantiene, porque la tarea debe repetirse hasta aprobarse.
+func (s *MaintenanceSchedule) Apply(record *MaintenanceRecord) {
+	performedAt := record.PerformedAt
+	s.LastPerformedAt = &performedAt
+	s.LastResult = record.Result
+	if record.Result == MaintenancePassed {
+		s.DueAt = performedAt.AddDate(0, 0, s.IntervalDays)
+		s.ReminderLevel = ""
+	}
+}
+
+// NewMaintenanceRecord crea el registro de una realización de la tarea
+func NewMaintenanceRecord(schedule *MaintenanceSchedule, result MaintenanceResult, performedAt time.Time, performedBy int) *MaintenanceRecord {
+	scheduleID := schedule.ID
+	return &MaintenanceRecord{
+		ScheduleID:  &scheduleID,
+		ESP32ID:     schedule.ESP32ID,
+		SensorID:    schedule.SensorID,
+		Task:        schedule.Task,
+		Result:      result,
+		PerformedAt: performedAt,
+		PerformedBy: &performedBy,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// Validate comprueba el resultado y la longitud de los textos del registro
+func (r *MaintenanceRecord) Validate() error {
+	if r.Result != MaintenancePassed && r.Result != MaintenanceResultFailed {
+		return errors.New("result must be passed or failed")
+	}
+	if utf8.RuneCountInString(r.Technician) > 100 {
+		return errors.New("technician must be at most 100 characters")
+	}
+	if utf8.RuneCountInString(r.Notes) > 255 {
+		return errors.New("notes must be at most 255 characters")
+	}
+	return nil
+}
+
+// IsValidMaintenanceTask indica si la tarea es una de las tareas de mantenimiento soportadas
+func IsValidMaintenanceTask(task MaintenanceTask) bool {
+	switch task {
+	case MaintenanceMonthlyTest, MaintenanceAnnualInspection, MaintenanceSensorReplacement:
+		return true
+	}
+	return false
+}
+
+// DefaultMaintenanceIntervalDays devuelve el intervalo habitual de cada tarea; los sensores
+// de gas y humo se reemplazan a los cinco años
+func DefaultMaintenanceIntervalDays(task MaintenanceTask) int {
+	switch task {
+	case MaintenanceMonthlyTest:
+		return 30
+	case MaintenanceAnnualInspection:
+		return 365
+	case MaintenanceSensorReplacement:
+		return 5 * 365
+	}
+	return 0
+}
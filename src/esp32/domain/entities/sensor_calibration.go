@@ -0,0 +1,70 @@
+package entities
+
+import (
+	"errors"
+	"time"
+	"unicode/utf8"
+)
+
+// CalibrationSource indica quién realizó una calibración
+type CalibrationSource string
+
+const (
+	// CalibrationTechnician es una calibración realizada y registrada por un técnico
+	CalibrationTechnician CalibrationSource = "technician"
+	// CalibrationSelf es una calibración que el ESP32 realizó por su cuenta en aire limpio
+	CalibrationSelf CalibrationSource = "self"
+)
+
+// SensorCalibration representa una calibración de la resistencia de referencia (R0) de un sensor de gas
+type SensorCalibration struct {
+	ID       int               `json:"id"`
+	SensorID int               `json:"sensor_id"`
+	R0       float64           `json:"r0"` // Resistencia del sensor en aire limpio, en kΩ
+	Source   CalibrationSource `json:"source"`
+	// Nombre del técnico y usuario que registró la calibración; vacíos en las autocalibraciones
+	Technician   string    `json:"technician,omitempty"`
+	CalibratedBy *int      `json:"calibrated_by,omitempty"`
+	CalibratedAt time.Time `json:"calibrated_at"`
+	// Condiciones ambientales durante la calibración, que afectan la lectura de los sensores MQ
+	TemperatureC *float64  `json:"temperature_c"`
+	HumidityPct  *float64  `json:"humidity_pct"`
+	Notes        string    `json:"notes,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// NewSensorCalibration crea una nueva instancia de SensorCalibration
+func NewSensorCalibration(sensorID int, r0 float64, source CalibrationSource, calibratedAt time.Time) *SensorCalibration {
+	return &SensorCalibration{
+		SensorID:     sensorID,
+		R0:           r0,
+		Source:       source,
+		CalibratedAt: calibratedAt,
+		CreatedAt:    time.Now(),
+	}
+}
+
+// Validate comprueba que los valores de la calibración sean físicamente posibles
+func (c *SensorCalibration) Validate() error {
+	if c.R0 <= 0 {
+		return errors.New("r0 must be positive")
+	}
+	if c.TemperatureC != nil && (*c.TemperatureC < -40 || *c.TemperatureC > 85) {
+		return errors.New("temperature_c must be between -40 and 85")
+	}
+	if c.HumidityPct != nil && (*c.HumidityPct < 0 || *c.HumidityPct > 100) {
+		return errors.New("humidity_pct must be between 0 and 100")
+	}
+	if utf8.RuneCountInString(c.Technician) > 100 {
+		return errors.New("technician must be at most 100 characters")
+	}
+	if utf8.RuneCountInString(c.Notes) > 255 {
+		return errors.New("notes must be at most 255 characters")
+	}
+	return nil
+}
+
+// DueAt devuelve el momento en que vence la calibración según el intervalo del tipo de sensor
+func (c *SensorCalibration) DueAt(intervalDays int) time.Time {
+	return c.CalibratedAt.AddDate(0, 0, intervalDays)
+}
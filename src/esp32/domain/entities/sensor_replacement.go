@@ -0,0 +1,56 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// SensorReplacement registra el reemplazo de un sensor averiado o al final de su vida útil.
+// El sensor retirado se conserva, por lo que sus lecturas y alarmas siguen asociadas a él.
+type SensorReplacement struct {
+	ID          int    `json:"id"`
+	ESP32ID     int    `json:"esp32_id"`
+	SensorType  string `json:"sensor_type"`
+	OldSensorID int    `json:"old_sensor_id"`
+	NewSensorID int    `json:"new_sensor_id"`
+	// Números de serie de fábrica de ambos sensores; se leen de los sensores al consultar
+	OldNumeroSerie string    `json:"old_numero_serie"`
+	NewNumeroSerie string    `json:"new_numero_serie"`
+	Reason         string    `json:"reason"`
+	Technician     string    `json:"technician"`
+	ReplacedBy     *int      `json:"replaced_by"` // Usuario que registró el reemplazo
+	ReplacedAt     time.Time `json:"replaced_at"`
+}
+
+// NewSensorReplacement crea el registro del reemplazo de un sensor
+func NewSensorReplacement(oldSensor *DeviceSensor, reason, technician string, replacedBy int) *SensorReplacement {
+	return &SensorReplacement{
+		ESP32ID:        oldSensor.ESP32ID,
+		SensorType:     oldSensor.SensorType,
+		OldSensorID:    oldSensor.ID,
+		OldNumeroSerie: oldSensor.NumeroSerie,
+		Reason:         strings.TrimSpace(reason),
+		Technician:     strings.TrimSpace(technician),
+		ReplacedBy:     &replacedBy,
+		ReplacedAt:     time.Now(),
+	}
+}
+
+// Validate comprueba que el reemplazo tenga motivo y técnico
+func (r *SensorReplacement) Validate() error {
+	if r.Reason == "" {
+		return errors.New("reason is required")
+	}
+	if utf8.RuneCountInString(r.Reason) > 255 {
+		return errors.New("reason must be at most 255 characters")
+	}
+	if r.Technician == "" {
+		return errors.New("technician is required")
+	}
+	if utf8.RuneCountInString(r.Technician) > 100 {
+		return errors.New("technician must be at most 100 characters")
+	}
+	return nil
+}
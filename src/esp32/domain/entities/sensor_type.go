@@ -0,0 +1,105 @@
+package entities
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DefaultMQCalibrationIntervalDays es el intervalo de recalibración de los sensores de gas del kit
+const DefaultMQCalibrationIntervalDays = 90
+
+// Tipos de sensor del kit con el que se fabricaron los primeros ESP32
+const (
+	SensorKY026 = "KY_026"
+	SensorMQ2   = "MQ_2"
+	SensorMQ135 = "MQ_135"
+	SensorDHT22 = "DHT_22"
+)
+
+// sensorTypeCodePattern define el formato de los códigos de tipo de sensor, por ejemplo MQ_7
+var sensorTypeCodePattern = regexp.MustCompile(`^[A-Z0-9_]{2,20}$`)
+
+// SensorType representa un tipo de sensor registrado que se puede instalar en un ESP32
+type SensorType struct {
+	Code     string   `json:"code"`
+	Name     string   `json:"name"`
+	Unit     string   `json:"unit"` // Vacío para sensores digitales
+	MinValue *float64 `json:"min_value"`
+	MaxValue *float64 `json:"max_value"`
+	Digital  bool     `json:"digital"` // Solo informa si está en alarma, sin valor medido
+	// Los tipos del kit por defecto se instalan en cada ESP32 al darlo de alta
+	DefaultKit bool `json:"default_kit"`
+	// Cada cuántos días hay que recalibrar los sensores de este tipo; nulo si no se calibran
+	CalibrationIntervalDays *int `json:"calibration_interval_days"`
+	// Curva del fabricante para convertir la resistencia medida en ppm: ppm = a * (Rs/R0)^b
+	PPMCurveA *float64  `json:"ppm_curve_a"`
+	PPMCurveB *float64  `json:"ppm_curve_b"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DefaultSensorTypes son los tipos que se registran al crear la base de datos
+func DefaultSensorTypes() []*SensorType {
+	return []*SensorType{
+		{Code: SensorKY026, Name: "Flame sensor", Digital: true, DefaultKit: true},
+		{Code: SensorMQ2, Name: "Smoke and LPG sensor", Unit: "ppm", MinValue: floatPtr(200), MaxValue: floatPtr(10000), DefaultKit: true,
+			CalibrationIntervalDays: intPtr(DefaultMQCalibrationIntervalDays), PPMCurveA: floatPtr(574.25), PPMCurveB: floatPtr(-2.222)},
+		{Code: SensorMQ135, Name: "Air quality sensor", Unit: "ppm", MinValue: floatPtr(10), MaxValue: floatPtr(1000), DefaultKit: true,
+			CalibrationIntervalDays: intPtr(DefaultMQCalibrationIntervalDays), PPMCurveA: floatPtr(116.6020682), PPMCurveB: floatPtr(-2.769034857)},
+		{Code: SensorDHT22, Name: "Temperature sensor", Unit: "°C", MinValue: floatPtr(-40), MaxValue: floatPtr(80), DefaultKit: true},
+	}
+}
+
+// NormalizeSensorTypeCode normaliza un código de tipo de sensor para poder compararlo
+func NormalizeSensorTypeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Validate comprueba que la definición del tipo de sensor sea consistente
+func (t *SensorType) Validate() error {
+	if !sensorTypeCodePattern.MatchString(t.Code) {
+		return errors.New("code must be 2 to 20 uppercase letters, digits or underscores")
+	}
+	if strings.TrimSpace(t.Name) == "" {
+		return errors.New("name is required")
+	}
+	if t.MinValue != nil && t.MaxValue != nil && *t.MinValue > *t.MaxValue {
+		return errors.New("min_value must not be greater than max_value")
+	}
+	if t.CalibrationIntervalDays != nil && *t.CalibrationIntervalDays <= 0 {
+		return errors.New("calibration_interval_days must be positive")
+	}
+	if (t.PPMCurveA == nil) != (t.PPMCurveB == nil) {
+		return errors.New("ppm_curve_a and ppm_curve_b must be set together")
+	}
+	if t.PPMCurveA != nil && *t.PPMCurveA <= 0 {
+		return errors.New("ppm_curve_a must be positive")
+	}
+	return nil
+}
+
+// RequiresCalibration indica si los sensores de este tipo necesitan calibraciones periódicas
+func (t *SensorType) RequiresCalibration() bool {
+	return t.CalibrationIntervalDays != nil
+}
+
+// PPM convierte la resistencia medida por el sensor (Rs) en ppm usando la resistencia de
+// referencia R0 de su calibración; devuelve false si el tipo no tiene curva de conversión
+func (t *SensorType) PPM(rs, r0 float64) (float64, bool) {
+	if t.PPMCurveA == nil || t.PPMCurveB == nil || rs <= 0 || r0 <= 0 {
+		return 0, false
+	}
+	return *t.PPMCurveA * math.Pow(rs/r0, *t.PPMCurveB), true
+}
+
+// floatPtr devuelve un puntero al valor indicado
+func floatPtr(value float64) *float64 {
+	return &value
+}
+
+// intPtr devuelve un puntero al valor indicado
+func intPtr(value int) *int {
+	return &value
+}
@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"hex_go/src/esp32/domain/entities"
+)
+
+// CommissioningRepository define las operaciones sobre las sesiones de comisionamiento y sus pruebas
+type CommissioningRepository interface {
+	// Create guarda la sesión junto con sus pruebas pendientes
+	Create(ctx context.Context, session *entities.CommissioningSession) (*entities.CommissioningSession, error)
+	// FindByID devuelve la sesión con sus pruebas y su certificado
+	FindByID(ctx context.Context, id int) (*entities.CommissioningSession, error)
+	// FindOpenByESP32ID devuelve la sesión en curso y no vencida del ESP32, con sus pruebas
+	FindOpenByESP32ID(ctx context.Context, esp32ID int) (*entities.CommissioningSession, error)
+	// FindByESP32ID devuelve las sesiones del ESP32, de la más reciente a la más antigua, sin sus pruebas
+	FindByESP32ID(ctx context.Context, esp32ID int) ([]*entities.CommissioningSession, error)
+	// SaveTest registra el resultado de una prueba; una prueba repetida reemplaza el resultado anterior
+	SaveTest(ctx context.Context, test *entities.CommissioningTest) error
+	// Close cierra una sesión en curso con el estado indicado; devuelve false si ya estaba cerrada o vencida
+	Close(ctx context.Context, session *entities.CommissioningSession, completedAt time.Time) (bool, error)
+}
@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"hex_go/src/esp32/domain/entities"
+)
+
+// DeviceCommandRepository define las operaciones sobre la cola de comandos de los ESP32
+type DeviceCommandRepository interface {
+	Create(ctx context.Context, command *entities.DeviceCommand) (*entities.DeviceCommand, error)
+	FindByID(ctx context.Context, id int) (*entities.DeviceCommand, error)
+	FindByESP32ID(ctx context.Context, esp32ID, limit int) ([]*entities.DeviceCommand, error)
+	// ClaimPending marca como entregados los comandos no vencidos que el ESP32 todavía no recibió
+	// y devuelve solo los que reclamó esta llamada
+	ClaimPending(ctx context.Context, esp32ID int, deliveredAt time.Time) ([]*entities.DeviceCommand, error)
+	// Complete registra el resultado de un comando abierto; devuelve false si ya estaba cerrado
+	Complete(ctx context.Context, id int, status entities.CommandStatus, result string, completedAt time.Time) (bool, error)
+	// ExpireStale marca como vencidos los comandos no entregados cuyo plazo terminó
+	ExpireStale(ctx context.Context, now time.Time) (int64, error)
+}
@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"hex_go/src/esp32/domain/entities"
+)
+
+// ErrConfigVersionConflict se devuelve cuando la configuración cambió desde que se leyó
+var ErrConfigVersionConflict = errors.New("device configuration was modified concurrently")
+
+// DeviceConfigRepository define las operaciones sobre la configuración remota de los ESP32
+type DeviceConfigRepository interface {
+	// FindByESP32ID devuelve nil si el ESP32 nunca fue configurado
+	FindByESP32ID(ctx context.Context, esp32ID int) (*entities.DeviceConfig, error)
+	// Save guarda una nueva versión si la vigente sigue siendo previousVersion;
+	// en caso contrario devuelve ErrConfigVersionConflict
+	Save(ctx context.Context, config *entities.DeviceConfig, previousVersion int) error
+	MarkApplied(ctx context.Context, esp32ID, version int, appliedAt time.Time) error
+}
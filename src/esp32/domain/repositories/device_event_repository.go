@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/entities"
+)
+
+// DeviceEventRepository define las operaciones sobre los eventos de conectividad de los ESP32
+type DeviceEventRepository interface {
+	Create(ctx context.Context, event *entities.DeviceEvent) (*entities.DeviceEvent, error)
+	FindByESP32ID(ctx context.Context, esp32ID int) ([]*entities.DeviceEvent, error)
+}
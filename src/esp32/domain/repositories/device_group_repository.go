@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/entities"
+)
+
+// DeviceGroupRepository define las operaciones sobre los grupos de ESP32 y sus miembros
+type DeviceGroupRepository interface {
+	Create(ctx context.Context, group *entities.DeviceGroup) (*entities.DeviceGroup, error)
+	FindByID(ctx context.Context, id int) (*entities.DeviceGroup, error)
+	FindByParentID(ctx context.Context, parentID int) ([]*entities.DeviceGroup, error)
+	FindByOwnerID(ctx context.Context, ownerID int) ([]*entities.DeviceGroup, error)
+	FindByIDs(ctx context.Context, ids []int) ([]*entities.DeviceGroup, error)
+	Update(ctx context.Context, group *entities.DeviceGroup) error
+	Delete(ctx context.Context, id int) error
+	// SaveMember agrega un miembro al grupo o cambia su rol si ya lo era
+	SaveMember(ctx context.Context, member *entities.GroupMember) error
+	DeleteMember(ctx context.Context, groupID, userID int) error
+	FindMembers(ctx context.Context, groupID int) ([]*entities.GroupMember, error)
+	FindMembershipsByUserID(ctx context.Context, userID int) ([]*entities.GroupMember, error)
+}
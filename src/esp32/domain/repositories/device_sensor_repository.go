@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"hex_go/src/esp32/domain/entities"
+)
+
+// ErrSensorRetired se devuelve al reemplazar un sensor que ya fue retirado por otra solicitud
+var ErrSensorRetired = errors.New("sensor was already replaced")
+
+// DeviceSensorRepository define las operaciones sobre los sensores instalados en los ESP32
+type DeviceSensorRepository interface {
+	Create(ctx context.Context, sensor *entities.DeviceSensor) (*entities.DeviceSensor, error)
+	// FindByID busca un sensor por su ID, incluso si fue retirado
+	FindByID(ctx context.Context, id int) (*entities.DeviceSensor, error)
+	// FindByESP32ID y FindByESP32IDs devuelven solo los sensores instalados actualmente
+	FindByESP32ID(ctx context.Context, esp32ID int) ([]*entities.DeviceSensor, error)
+	FindByESP32IDs(ctx context.Context, esp32IDs []int) ([]*entities.DeviceSensor, error)
+	Delete(ctx context.Context, id int) error
+	// Replace retira el sensor anterior, instala el nuevo y guarda el reemplazo en una misma
+	// transacción. Devuelve ErrSensorRetired si el sensor anterior ya no estaba instalado.
+	Replace(ctx context.Context, replacement *entities.SensorReplacement, newSensor *entities.DeviceSensor) (*entities.SensorReplacement, error)
+	// FindReplacementsByESP32ID devuelve los reemplazos del ESP32, del más reciente al más antiguo
+	FindReplacementsByESP32ID(ctx context.Context, esp32ID int) ([]*entities.SensorReplacement, error)
+}
@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/entities"
+)
+
+// ESP32AssignmentRepository define las consultas sobre el historial de dueños de los ESP32.
+// Las asignaciones se registran desde ESP32Repository y ESP32TransferRepository, en la misma
+// transacción que cambia el dueño del ESP32.
+type ESP32AssignmentRepository interface {
+	FindByESP32ID(ctx context.Context, esp32ID int) ([]*entities.ESP32Assignment, error)
+}
@@ -2,19 +2,46 @@ package repositories
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"hex_go/src/esp32/domain/entities"
 )
 
+// ErrClaimConflict se devuelve cuando el ESP32 fue reclamado, retirado o perdió su código de reclamo antes de asignarse
+var ErrClaimConflict = errors.New("ESP32 was claimed concurrently, it is no longer available")
+
+// ErrGroupOwnerConflict se devuelve cuando el ESP32 cambió de dueño antes de moverse de grupo
+var ErrGroupOwnerConflict = errors.New("ESP32 owner changed, it no longer belongs to the owner of the group")
+
 // ESP32Repository define las operaciones que se pueden realizar con la entidad ESP32
 type ESP32Repository interface {
 	Create(ctx context.Context, esp32 *entities.ESP32) (*entities.ESP32, error)
+	CreateWithSensors(ctx context.Context, esp32 *entities.ESP32) (*entities.ESP32, error)
+	CreateManyWithSensors(ctx context.Context, esp32s []*entities.ESP32) error
 	FindByID(ctx context.Context, id int) (*entities.ESP32, error)
 	FindByNumeroSerie(ctx context.Context, numeroSerie string) (*entities.ESP32, error)
+	FindExistingNumerosSerie(ctx context.Context, numerosSerie []string) (map[string]bool, error)
 	FindByUserID(ctx context.Context, userID int) ([]*entities.ESP32, error)
+	FindByGroupIDs(ctx context.Context, groupIDs []int) ([]*entities.ESP32, error)
 	FindUnassigned(ctx context.Context) ([]*entities.ESP32, error)
+	FindDecommissioned(ctx context.Context) ([]*entities.ESP32, error)
 	Update(ctx context.Context, esp32 *entities.ESP32) error
-	Delete(ctx context.Context, id int) error
+	UpdateMetadata(ctx context.Context, esp32 *entities.ESP32) error
+	// SetGroup mueve un ESP32 a un grupo, o lo saca si groupID es nulo, solo si sigue perteneciendo
+	// a ownerID; en caso contrario devuelve ErrGroupOwnerConflict
+	SetGroup(ctx context.Context, esp32ID, ownerID int, groupID *int) error
+	// Decommission retira de servicio un ESP32 sin dueño conservando su historial;
+	// devuelve false si ya estaba retirado o si alguien lo reclamó mientras tanto
+	Decommission(ctx context.Context, id int, at time.Time) (bool, error)
+	// PurgeDecommissioned elimina los datos operativos y la ubicación de los ESP32 retirados antes
+	// de cutoff; el ESP32 y su historial de alertas, asignaciones y comisionamientos se conservan.
+	// Devuelve cuántos ESP32 se purgaron.
+	PurgeDecommissioned(ctx context.Context, cutoff time.Time) (int64, error)
+	// AssignToUser asigna un ESP32 sin dueño; devuelve ErrClaimConflict si ya no está disponible
 	AssignToUser(ctx context.Context, esp32ID, userID int) error
-	UnassignFromUser(ctx context.Context, esp32ID int) error
+	UnassignFromUser(ctx context.Context, esp32ID int, claimCodeHash string) error
+	UpdateHeartbeat(ctx context.Context, esp32ID int, seenAt time.Time) (bool, error)
+	FindStaleOnline(ctx context.Context, cutoff time.Time) ([]*entities.ESP32, error)
+	MarkOffline(ctx context.Context, esp32ID int, cutoff time.Time) (bool, error)
 }
\ No newline at end of file
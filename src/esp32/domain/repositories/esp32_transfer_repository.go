@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"hex_go/src/esp32/domain/entities"
+)
+
+// ErrTransferConflict se devuelve cuando el ESP32 cambió de dueño antes de aceptarse la transferencia
+var ErrTransferConflict = errors.New("ESP32 owner changed, the transfer is no longer valid")
+
+// ESP32TransferRepository define las operaciones sobre las transferencias de propiedad de ESP32
+type ESP32TransferRepository interface {
+	Create(ctx context.Context, transfer *entities.ESP32Transfer) (*entities.ESP32Transfer, error)
+	FindByID(ctx context.Context, id int) (*entities.ESP32Transfer, error)
+	FindPendingByESP32ID(ctx context.Context, esp32ID int) (*entities.ESP32Transfer, error)
+	FindPendingByEmail(ctx context.Context, email string) ([]*entities.ESP32Transfer, error)
+	FindByFromUserID(ctx context.Context, userID int) ([]*entities.ESP32Transfer, error)
+	UpdateStatus(ctx context.Context, id int, status entities.TransferStatus) error
+	// Accept asigna el ESP32 al destinatario y marca la transferencia como aceptada en una
+	// misma transacción. Devuelve ErrTransferConflict si el ESP32 ya no pertenece al remitente.
+	Accept(ctx context.Context, transfer *entities.ESP32Transfer, toUserID int) error
+}
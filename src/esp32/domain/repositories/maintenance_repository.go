@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"hex_go/src/esp32/domain/entities"
+)
+
+// MaintenanceRepository define las operaciones sobre las tareas de mantenimiento de los ESP32 y su historial
+type MaintenanceRepository interface {
+	CreateSchedule(ctx context.Context, schedule *entities.MaintenanceSchedule) (*entities.MaintenanceSchedule, error)
+	FindScheduleByID(ctx context.Context, id int) (*entities.MaintenanceSchedule, error)
+	FindSchedulesByESP32ID(ctx context.Context, esp32ID int) ([]*entities.MaintenanceSchedule, error)
+	FindSchedulesByESP32IDs(ctx context.Context, esp32IDs []int) ([]*entities.MaintenanceSchedule, error)
+	UpdateSchedule(ctx context.Context, schedule *entities.MaintenanceSchedule) error
+	DeleteSchedule(ctx context.Context, id int) error
+	// RecordMaintenance guarda la realización y actualiza la tarea en una misma transacción. El sensor
+	// de la tarea también se actualiza, para que el reemplazo de un sensor pase la tarea al sensor nuevo.
+	RecordMaintenance(ctx context.Context, record *entities.MaintenanceRecord, schedule *entities.MaintenanceSchedule) (*entities.MaintenanceRecord, error)
+	// FindRecordsByESP32ID devuelve las realizaciones del ESP32, de la más reciente a la más antigua
+	FindRecordsByESP32ID(ctx context.Context, esp32ID, limit int) ([]*entities.MaintenanceRecord, error)
+	// FindLatestRecords devuelve la última realización de la tarea en cada ESP32, indexada por ESP32
+	FindLatestRecords(ctx context.Context, esp32IDs []int, task entities.MaintenanceTask) (map[int]*entities.MaintenanceRecord, error)
+	// FindPendingReminders devuelve las tareas de ESP32 en servicio que vencen antes de dueBefore y
+	// todavía no recibieron el recordatorio que corresponde a su estado en now
+	FindPendingReminders(ctx context.Context, now, dueBefore time.Time) ([]*entities.MaintenanceSchedule, error)
+	// SetReminderLevel registra el último recordatorio enviado para el vencimiento actual de la tarea
+	SetReminderLevel(ctx context.Context, id int, level entities.MaintenanceStatus, at time.Time) error
+}
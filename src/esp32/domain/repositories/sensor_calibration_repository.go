@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/entities"
+)
+
+// SensorCalibrationRepository define las operaciones sobre las calibraciones de los sensores de gas
+type SensorCalibrationRepository interface {
+	Create(ctx context.Context, calibration *entities.SensorCalibration) (*entities.SensorCalibration, error)
+	// FindBySensorID devuelve las calibraciones del sensor de la más reciente a la más antigua
+	FindBySensorID(ctx context.Context, sensorID int) ([]*entities.SensorCalibration, error)
+	// FindLatestByESP32ID devuelve la última calibración de cada sensor del ESP32, indexada por sensor
+	FindLatestByESP32ID(ctx context.Context, esp32ID int) (map[int]*entities.SensorCalibration, error)
+}
@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+
+	"hex_go/src/esp32/domain/entities"
+)
+
+// SensorTypeRepository define las operaciones sobre el registro de tipos de sensor
+type SensorTypeRepository interface {
+	FindAll(ctx context.Context) ([]*entities.SensorType, error)
+	FindByCode(ctx context.Context, code string) (*entities.SensorType, error)
+	Create(ctx context.Context, sensorType *entities.SensorType) error
+	Update(ctx context.Context, sensorType *entities.SensorType) error
+}
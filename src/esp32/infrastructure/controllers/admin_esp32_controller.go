@@ -0,0 +1,247 @@
+package controllers
+
+import (
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"hex_go/src/esp32/application/services"
+)
+
+// maxManifestSize limita el tamaño de un manifiesto de fábrica enviado en el cuerpo
+const maxManifestSize = 5 << 20
+
+// AdminESP32Controller maneja las solicitudes HTTP de administración del inventario de ESP32
+type AdminESP32Controller struct {
+	provisionESP32UseCase        *services.ProvisionESP32UseCase
+	getUnassignedESP32sUseCase   *services.GetUnassignedESP32sUseCase
+	updateESP32UseCase           *services.UpdateESP32UseCase
+	decommissionESP32UseCase     *services.DecommissionESP32UseCase
+	getDecommissionedUseCase     *services.GetDecommissionedESP32sUseCase
+	regenerateClaimCodeUseCase   *services.RegenerateClaimCodeUseCase
+	regenerateDeviceTokenUseCase *services.RegenerateDeviceTokenUseCase
+	importESP32sUseCase          *services.ImportESP32sUseCase
+}
+
+// NewAdminESP32Controller crea una nueva instancia de AdminESP32Controller
+func NewAdminESP32Controller(
+	provisionESP32UseCase *services.ProvisionESP32UseCase,
+	getUnassignedESP32sUseCase *services.GetUnassignedESP32sUseCase,
+	updateESP32UseCase *services.UpdateESP32UseCase,
+	decommissionESP32UseCase *services.DecommissionESP32UseCase,
+	getDecommissionedUseCase *services.GetDecommissionedESP32sUseCase,
+	regenerateClaimCodeUseCase *services.RegenerateClaimCodeUseCase,
+	regenerateDeviceTokenUseCase *services.RegenerateDeviceTokenUseCase,
+	importESP32sUseCase *services.ImportESP32sUseCase,
+) *AdminESP32Controller {
+	return &AdminESP32Controller{
+		provisionESP32UseCase:        provisionESP32UseCase,
+		getUnassignedESP32sUseCase:   getUnassignedESP32sUseCase,
+		updateESP32UseCase:           updateESP32UseCase,
+		decommissionESP32UseCase:     decommissionESP32UseCase,
+		getDecommissionedUseCase:     getDecommissionedUseCase,
+		regenerateClaimCodeUseCase:   regenerateClaimCodeUseCase,
+		regenerateDeviceTokenUseCase: regenerateDeviceTokenUseCase,
+		importESP32sUseCase:          importESP32sUseCase,
+	}
+}
+
+// ProvisionESP32Request representa la estructura de la solicitud para dar de alta un ESP32
+type ProvisionESP32Request struct {
+	NumeroSerie      string `json:"numero_serie" binding:"required"`
+	HardwareRevision string `json:"hardware_revision"`
+}
+
+// UpdateESP32Request representa la estructura de la solicitud para editar un ESP32
+type UpdateESP32Request struct {
+	NumeroSerie      string `json:"numero_serie" binding:"required"`
+	HardwareRevision string `json:"hardware_revision"`
+}
+
+// ProvisionESP32 maneja la solicitud HTTP para dar de alta un ESP32 con sus sensores
+func (c *AdminESP32Controller) ProvisionESP32(ctx *gin.Context) {
+	var req ProvisionESP32Request
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	esp32, err := c.provisionESP32UseCase.Execute(ctx, req.NumeroSerie, req.HardwareRevision)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, esp32)
+}
+
+// GetUnassignedESP32s maneja la solicitud HTTP para listar los ESP32 sin asignar
+func (c *AdminESP32Controller) GetUnassignedESP32s(ctx *gin.Context) {
+	esp32s, err := c.getUnassignedESP32sUseCase.Execute(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, esp32s)
+}
+
+// UpdateESP32 maneja la solicitud HTTP para editar un ESP32
+func (c *AdminESP32Controller) UpdateESP32(ctx *gin.Context) {
+	esp32ID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ESP32 ID"})
+		return
+	}
+
+	var req UpdateESP32Request
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	esp32, err := c.updateESP32UseCase.Execute(ctx, esp32ID, req.NumeroSerie, req.HardwareRevision)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, esp32)
+}
+
+// GetDecommissionedESP32s maneja la solicitud HTTP para listar los ESP32 retirados de servicio
+func (c *AdminESP32Controller) GetDecommissionedESP32s(ctx *gin.Context) {
+	esp32s, err := c.getDecommissionedUseCase.Execute(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, esp32s)
+}
+
+// DecommissionESP32 maneja la solicitud HTTP para retirar un ESP32 de servicio
+func (c *AdminESP32Controller) DecommissionESP32(ctx *gin.Context) {
+	esp32ID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ESP32 ID"})
+		return
+	}
+
+	esp32, err := c.decommissionESP32UseCase.Execute(ctx, esp32ID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, esp32)
+}
+
+// RegenerateClaimCode maneja la solicitud HTTP para emitir un nuevo código de reclamo
+func (c *AdminESP32Controller) RegenerateClaimCode(ctx *gin.Context) {
+	esp32ID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ESP32 ID"})
+		return
+	}
+
+	claimCode, err := c.regenerateClaimCodeUseCase.Execute(ctx, esp32ID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"claim_code": claimCode})
+}
+
+// RegenerateDeviceToken maneja la solicitud HTTP para emitir un nuevo token de firmware
+func (c *AdminESP32Controller) RegenerateDeviceToken(ctx *gin.Context) {
+	esp32ID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ESP32 ID"})
+		return
+	}
+
+	token, err := c.regenerateDeviceTokenUseCase.Execute(ctx, esp32ID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"device_token": token})
+}
+
+// ImportESP32s maneja la solicitud HTTP para importar un manifiesto de fábrica en CSV o JSON.
+// El manifiesto se envía como archivo multipart (campo file) o como cuerpo con Content-Type
+// text/csv o application/json. Por defecto solo se valida; con dry_run=false se crean los ESP32.
+func (c *AdminESP32Controller) ImportESP32s(ctx *gin.Context) {
+	dryRun := ctx.DefaultQuery("dry_run", "true") != "false"
+
+	var format string
+	var body io.Reader
+	if fileHeader, err := ctx.FormFile("file"); err == nil {
+		file, err := fileHeader.Open()
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		defer file.Close()
+
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(fileHeader.Filename)), ".")
+		body = file
+	} else {
+		switch ctx.ContentType() {
+		case "text/csv":
+			format = services.ManifestFormatCSV
+		case "application/json":
+			format = services.ManifestFormatJSON
+		}
+		body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxManifestSize)
+	}
+	if query := ctx.Query("format"); query != "" {
+		format = query
+	}
+
+	rows, err := services.ParseManifest(format, body)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := c.importESP32sUseCase.Execute(ctx, rows, dryRun)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	status := http.StatusOK
+	if report.Created > 0 {
+		status = http.StatusCreated
+	} else if !dryRun && report.Invalid > 0 {
+		status = http.StatusUnprocessableEntity
+	}
+	ctx.JSON(status, report)
+}
+
+// SetupRoutes configura las rutas de administración de ESP32
+func (c *AdminESP32Controller) SetupRoutes(router *gin.Engine, authMiddleware, adminMiddleware gin.HandlerFunc) {
+	api := router.Group("/api")
+	{
+		admin := api.Group("/admin/esp32s")
+		// Rutas de administración (requieren autenticación y rol de administrador)
+		admin.Use(authMiddleware, adminMiddleware)
+		{
+			admin.POST("", c.ProvisionESP32)
+			admin.POST("/import", c.ImportESP32s)
+			admin.GET("/unassigned", c.GetUnassignedESP32s)
+			admin.GET("/decommissioned", c.GetDecommissionedESP32s)
+			admin.PUT("/:id", c.UpdateESP32)
+			admin.DELETE("/:id", c.DecommissionESP32)
+			admin.POST("/:id/claim-code", c.RegenerateClaimCode)
+			admin.POST("/:id/device-token", c.RegenerateDeviceToken)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"hex_go/src/esp32/application/services"
+)
+
+// CalibrationController maneja las solicitudes HTTP de las calibraciones de los sensores de gas
+type CalibrationController struct {
+	recordCalibrationUseCase     *services.RecordCalibrationUseCase
+	getSensorCalibrationsUseCase *services.GetSensorCalibrationsUseCase
+	submitSelfCalibrationUseCase *services.SubmitSelfCalibrationUseCase
+}
+
+// NewCalibrationController crea una nueva instancia de CalibrationController
+func NewCalibrationController(
+	recordCalibrationUseCase *services.RecordCalibrationUseCase,
+	getSensorCalibrationsUseCase *services.GetSensorCalibrationsUseCase,
+	submitSelfCalibrationUseCase *services.SubmitSelfCalibrationUseCase,
+) *CalibrationController {
+	return &CalibrationController{
+		recordCalibrationUseCase:     recordCalibrationUseCase,
+		getSensorCalibrationsUseCase: getSensorCalibrationsUseCase,
+		submitSelfCalibrationUseCase: submitSelfCalibrationUseCase,
+	}
+}
+
+// RecordCalibration maneja la solicitud HTTP para registrar la calibración hecha por un técnico
+func (c *CalibrationController) RecordCalibration(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	esp32ID, sensorID, ok := sensorParams(ctx)
+	if !ok {
+		return
+	}
+
+	var req services.CalibrationInput
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	calibration, err := c.recordCalibrationUseCase.Execute(ctx, esp32ID, sensorID, actor, req)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, calibration)
+}
+
+// GetSensorCalibrations maneja la solicitud HTTP para consultar las calibraciones de un sensor
+func (c *CalibrationController) GetSensorCalibrations(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	esp32ID, sensorID, ok := sensorParams(ctx)
+	if !ok {
+		return
+	}
+
+	status, err := c.getSensorCalibrationsUseCase.Execute(ctx, esp32ID, sensorID, actor)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, status)
+}
+
+// SubmitSelfCalibration maneja la solicitud HTTP con la que un ESP32 informa una autocalibración
+func (c *CalibrationController) SubmitSelfCalibration(ctx *gin.Context) {
+	var req services.SelfCalibrationInput
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	calibration, err := c.submitSelfCalibrationUseCase.Execute(ctx, ctx.GetInt("esp32ID"), req)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, calibration)
+}
+
+// sensorParams lee el ESP32 y el sensor de la URL; si alguno es inválido ya respondió la solicitud
+func sensorParams(ctx *gin.Context) (int, int, bool) {
+	esp32ID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ESP32 ID"})
+		return 0, 0, false
+	}
+
+	sensorID, err := strconv.Atoi(ctx.Param("sensorId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid sensor ID"})
+		return 0, 0, false
+	}
+
+	return esp32ID, sensorID, true
+}
+
+// SetupRoutes configura las rutas de calibración de sensores
+func (c *CalibrationController) SetupRoutes(router *gin.Engine, authMiddleware, deviceAuthMiddleware gin.HandlerFunc) {
+	api := router.Group("/api")
+	{
+		esp32s := api.Group("/esp32s")
+		// Rutas de usuarios (requieren autenticación)
+		esp32s.Use(authMiddleware)
+		{
+			esp32s.GET("/:id/sensors/:sensorId/calibrations", c.GetSensorCalibrations)
+			esp32s.POST("/:id/sensors/:sensorId/calibrations", c.RecordCalibration)
+		}
+
+		devices := api.Group("/devices")
+		// Rutas de dispositivos (requieren número de serie y token del ESP32)
+		devices.Use(deviceAuthMiddleware)
+		{
+			devices.POST("/calibrations", c.SubmitSelfCalibration)
+		}
+	}
+}
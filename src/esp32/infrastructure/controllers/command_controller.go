@@ -0,0 +1,159 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"hex_go/src/esp32/application/services"
+	"hex_go/src/esp32/domain/entities"
+)
+
+// CommandController maneja las solicitudes HTTP de la cola de comandos de los ESP32
+type CommandController struct {
+	enqueueCommandUseCase     *services.EnqueueCommandUseCase
+	getCommandHistoryUseCase  *services.GetCommandHistoryUseCase
+	pollCommandsUseCase       *services.PollCommandsUseCase
+	acknowledgeCommandUseCase *services.AcknowledgeCommandUseCase
+}
+
+// NewCommandController crea una nueva instancia de CommandController
+func NewCommandController(
+	enqueueCommandUseCase *services.EnqueueCommandUseCase,
+	getCommandHistoryUseCase *services.GetCommandHistoryUseCase,
+	pollCommandsUseCase *services.PollCommandsUseCase,
+	acknowledgeCommandUseCase *services.AcknowledgeCommandUseCase,
+) *CommandController {
+	return &CommandController{
+		enqueueCommandUseCase:     enqueueCommandUseCase,
+		getCommandHistoryUseCase:  getCommandHistoryUseCase,
+		pollCommandsUseCase:       pollCommandsUseCase,
+		acknowledgeCommandUseCase: acknowledgeCommandUseCase,
+	}
+}
+
+// EnqueueCommandRequest representa la estructura de la solicitud para encolar un comando
+type EnqueueCommandRequest struct {
+	Type            entities.CommandType `json:"type" binding:"required"`
+	DurationSeconds int                  `json:"duration_seconds"`
+	TTLSeconds      int                  `json:"ttl_seconds"` // 0 usa el plazo por defecto
+}
+
+// AcknowledgeCommandRequest representa la estructura de la solicitud con el resultado de un comando
+type AcknowledgeCommandRequest struct {
+	Status  entities.CommandStatus `json:"status" binding:"required"`
+	Message string                 `json:"message"`
+}
+
+// EnqueueCommand maneja la solicitud HTTP para encolar un comando para un ESP32
+func (c *CommandController) EnqueueCommand(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	esp32ID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ESP32 ID"})
+		return
+	}
+
+	var req EnqueueCommandRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	command, err := c.enqueueCommandUseCase.Execute(ctx, esp32ID, actor, req.Type, req.DurationSeconds,
+		time.Duration(req.TTLSeconds)*time.Second)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, command)
+}
+
+// GetCommandHistory maneja la solicitud HTTP para consultar los comandos enviados a un ESP32
+func (c *CommandController) GetCommandHistory(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	esp32ID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ESP32 ID"})
+		return
+	}
+
+	commands, err := c.getCommandHistoryUseCase.Execute(ctx, esp32ID, actor)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, commands)
+}
+
+// PollCommands maneja la solicitud HTTP con la que un ESP32 recibe sus comandos pendientes
+func (c *CommandController) PollCommands(ctx *gin.Context) {
+	commands, err := c.pollCommandsUseCase.Execute(ctx, ctx.GetInt("esp32ID"))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	if commands == nil {
+		commands = []*entities.DeviceCommand{}
+	}
+	ctx.JSON(http.StatusOK, commands)
+}
+
+// AcknowledgeCommand maneja la solicitud HTTP con la que un ESP32 informa el resultado de un comando
+func (c *CommandController) AcknowledgeCommand(ctx *gin.Context) {
+	commandID, err := strconv.Atoi(ctx.Param("commandId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid command ID"})
+		return
+	}
+
+	var req AcknowledgeCommandRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	command, err := c.acknowledgeCommandUseCase.Execute(ctx, ctx.GetInt("esp32ID"), commandID, req.Status, req.Message)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, command)
+}
+
+// SetupRoutes configura las rutas de la cola de comandos
+func (c *CommandController) SetupRoutes(router *gin.Engine, authMiddleware, deviceAuthMiddleware gin.HandlerFunc) {
+	api := router.Group("/api")
+	{
+		esp32s := api.Group("/esp32s")
+		// Rutas de usuarios (requieren autenticación)
+		esp32s.Use(authMiddleware)
+		{
+			esp32s.POST("/:id/commands", c.EnqueueCommand)
+			esp32s.GET("/:id/commands", c.GetCommandHistory)
+		}
+
+		devices := api.Group("/devices")
+		// Rutas de dispositivos (requieren número de serie y token del ESP32)
+		devices.Use(deviceAuthMiddleware)
+		{
+			devices.GET("/commands", c.PollCommands)
+			devices.POST("/commands/:commandId/ack", c.AcknowledgeCommand)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"hex_go/src/esp32/application/services"
+)
+
+// CommissioningController maneja las solicitudes HTTP del comisionamiento de los ESP32
+type CommissioningController struct {
+	startCommissioningUseCase          *services.StartCommissioningUseCase
+	getESP32CommissioningUseCase       *services.GetESP32CommissioningUseCase
+	getCommissioningSessionUseCase     *services.GetCommissioningSessionUseCase
+	completeCommissioningUseCase       *services.CompleteCommissioningUseCase
+	cancelCommissioningUseCase         *services.CancelCommissioningUseCase
+	getCommissioningCertificateUseCase *services.GetCommissioningCertificateUseCase
+	getDeviceCommissioningUseCase      *services.GetDeviceCommissioningUseCase
+	reportCommissioningTestUseCase     *services.ReportCommissioningTestUseCase
+}
+
+// NewCommissioningController crea una nueva instancia de CommissioningController
+func NewCommissioningController(
+	startCommissioningUseCase *services.StartCommissioningUseCase,
+	getESP32CommissioningUseCase *services.GetESP32CommissioningUseCase,
+	getCommissioningSessionUseCase *services.GetCommissioningSessionUseCase,
+	completeCommissioningUseCase *services.CompleteCommissioningUseCase,
+	cancelCommissioningUseCase *services.CancelCommissioningUseCase,
+	getCommissioningCertificateUseCase *services.GetCommissioningCertificateUseCase,
+	getDeviceCommissioningUseCase *services.GetDeviceCommissioningUseCase,
+	reportCommissioningTestUseCase *services.ReportCommissioningTestUseCase,
+) *CommissioningController {
+	return &CommissioningController{
+		startCommissioningUseCase:          startCommissioningUseCase,
+		getESP32CommissioningUseCase:       getESP32CommissioningUseCase,
+		getCommissioningSessionUseCase:     getCommissioningSessionUseCase,
+		completeCommissioningUseCase:       completeCommissioningUseCase,
+		cancelCommissioningUseCase:         cancelCommissioningUseCase,
+		getCommissioningCertificateUseCase: getCommissioningCertificateUseCase,
+		getDeviceCommissioningUseCase:      getDeviceCommissioningUseCase,
+		reportCommissioningTestUseCase:     reportCommissioningTestUseCase,
+	}
+}
+
+// CompleteCommissioningRequest representa la estructura de la solicitud para finalizar un comisionamiento
+type CompleteCommissioningRequest struct {
+	Notes string `json:"notes"`
+}
+
+// StartCommissioning maneja la solicitud HTTP para iniciar el comisionamiento de un ESP32
+func (c *CommissioningController) StartCommissioning(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	esp32ID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ESP32 ID"})
+		return
+	}
+
+	session, err := c.startCommissioningUseCase.Execute(ctx, esp32ID, actor)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, session)
+}
+
+// GetESP32Commissioning maneja la solicitud HTTP para listar los comisionamientos de un ESP32
+func (c *CommissioningController) GetESP32Commissioning(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	esp32ID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ESP32 ID"})
+		return
+	}
+
+	sessions, err := c.getESP32CommissioningUseCase.Execute(ctx, esp32ID, actor)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, sessions)
+}
+
+// GetCommissioningSession maneja la solicitud HTTP para consultar una sesión con sus pruebas
+func (c *CommissioningController) GetCommissioningSession(ctx *gin.Context) {
+	actor, sessionID, ok := commissioningRequestContext(ctx)
+	if !ok {
+		return
+	}
+
+	session, err := c.getCommissioningSessionUseCase.Execute(ctx, sessionID, actor)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, session)
+}
+
+// CompleteCommissioning maneja la solicitud HTTP para finalizar un comisionamiento y emitir su certificado
+func (c *CommissioningController) CompleteCommissioning(ctx *gin.Context) {
+	actor, sessionID, ok := commissioningRequestContext(ctx)
+	if !ok {
+		return
+	}
+
+	var req CompleteCommissioningRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	certificate, err := c.completeCommissioningUseCase.Execute(ctx, sessionID, actor, req.Notes)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, certificate)
+}
+
+// CancelCommissioning maneja la solicitud HTTP para cancelar un comisionamiento en curso
+func (c *CommissioningController) CancelCommissioning(ctx *gin.Context) {
+	actor, sessionID, ok := commissioningRequestContext(ctx)
+	if !ok {
+		return
+	}
+
+	session, err := c.cancelCommissioningUseCase.Execute(ctx, sessionID, actor)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, session)
+}
+
+// GetCommissioningCertificate maneja la solicitud HTTP para descargar el certificado firmado de un comisionamiento
+func (c *CommissioningController) GetCommissioningCertificate(ctx *gin.Context) {
+	actor, sessionID, ok := commissioningRequestContext(ctx)
+	if !ok {
+		return
+	}
+
+	certificate, err := c.getCommissioningCertificateUseCase.Execute(ctx, sessionID, actor)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, certificate)
+}
+
+// GetDeviceCommissioning maneja la solicitud HTTP con la que un ESP32 consulta las pruebas pendientes
+func (c *CommissioningController) GetDeviceCommissioning(ctx *gin.Context) {
+	session, err := c.getDeviceCommissioningUseCase.Execute(ctx, ctx.GetInt("esp32ID"))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, session)
+}
+
+// ReportCommissioningTest maneja la solicitud HTTP con la que un ESP32 informa el resultado de la prueba de un sensor
+func (c *CommissioningController) ReportCommissioningTest(ctx *gin.Context) {
+	var req services.CommissioningTestInput
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	test, err := c.reportCommissioningTestUseCase.Execute(ctx, ctx.GetInt("esp32ID"), req)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, test)
+}
+
+// commissioningRequestContext obtiene el usuario autenticado y la sesión de la URL; si falla ya respondió la solicitud
+func commissioningRequestContext(ctx *gin.Context) (services.Actor, int, bool) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return services.Actor{}, 0, false
+	}
+
+	sessionID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid commissioning session ID"})
+		return services.Actor{}, 0, false
+	}
+
+	return actor, sessionID, true
+}
+
+// SetupRoutes configura las rutas de comisionamiento
+func (c *CommissioningController) SetupRoutes(router *gin.Engine, authMiddleware, deviceAuthMiddleware gin.HandlerFunc) {
+	api := router.Group("/api")
+	{
+		esp32s := api.Group("/esp32s")
+		// Rutas de usuarios (requieren autenticación)
+		esp32s.Use(authMiddleware)
+		{
+			esp32s.POST("/:id/commissioning", c.StartCommissioning)
+			esp32s.GET("/:id/commissioning", c.GetESP32Commissioning)
+		}
+
+		commissioning := api.Group("/commissioning")
+		// Rutas de usuarios (requieren autenticación)
+		commissioning.Use(authMiddleware)
+		{
+			commissioning.GET("/:id", c.GetCommissioningSession)
+			commissioning.POST("/:id/complete", c.CompleteCommissioning)
+			commissioning.POST("/:id/cancel", c.CancelCommissioning)
+			commissioning.GET("/:id/certificate", c.GetCommissioningCertificate)
+		}
+
+		devices := api.Group("/devices")
+		// Rutas de dispositivos (requieren número de serie y token del ESP32)
+		devices.Use(deviceAuthMiddleware)
+		{
+			devices.GET("/commissioning", c.GetDeviceCommissioning)
+			devices.POST("/commissioning/tests", c.ReportCommissioningTest)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"hex_go/src/esp32/application/services"
+	"hex_go/src/esp32/domain/entities"
+)
+
+// ConfigController maneja las solicitudes HTTP de configuración remota de los ESP32
+type ConfigController struct {
+	getDeviceConfigUseCase         *services.GetDeviceConfigUseCase
+	updateDeviceConfigUseCase      *services.UpdateDeviceConfigUseCase
+	fetchDeviceConfigUseCase       *services.FetchDeviceConfigUseCase
+	acknowledgeDeviceConfigUseCase *services.AcknowledgeDeviceConfigUseCase
+}
+
+// NewConfigController crea una nueva instancia de ConfigController
+func NewConfigController(
+	getDeviceConfigUseCase *services.GetDeviceConfigUseCase,
+	updateDeviceConfigUseCase *services.UpdateDeviceConfigUseCase,
+	fetchDeviceConfigUseCase *services.FetchDeviceConfigUseCase,
+	acknowledgeDeviceConfigUseCase *services.AcknowledgeDeviceConfigUseCase,
+) *ConfigController {
+	return &ConfigController{
+		getDeviceConfigUseCase:         getDeviceConfigUseCase,
+		updateDeviceConfigUseCase:      updateDeviceConfigUseCase,
+		fetchDeviceConfigUseCase:       fetchDeviceConfigUseCase,
+		acknowledgeDeviceConfigUseCase: acknowledgeDeviceConfigUseCase,
+	}
+}
+
+// AcknowledgeConfigRequest representa la estructura de la solicitud con la versión aplicada por el ESP32
+type AcknowledgeConfigRequest struct {
+	Version *int `json:"version" binding:"required"`
+}
+
+// GetConfig maneja la solicitud HTTP para consultar la configuración de un ESP32
+func (c *ConfigController) GetConfig(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	esp32ID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ESP32 ID"})
+		return
+	}
+
+	config, err := c.getDeviceConfigUseCase.Execute(ctx, esp32ID, actor)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Header("ETag", config.ETag())
+	ctx.JSON(http.StatusOK, config)
+}
+
+// UpdateConfig maneja la solicitud HTTP para publicar una nueva versión de la configuración.
+// Si se envía If-Match con el ETag leído, la actualización falla con 409 cuando otra
+// persona modificó la configuración en el intervalo.
+func (c *ConfigController) UpdateConfig(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	esp32ID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ESP32 ID"})
+		return
+	}
+
+	var expectedVersion *int
+	if ifMatch := ctx.GetHeader("If-Match"); ifMatch != "" {
+		version, ok := parseConfigETag(ifMatch)
+		if !ok {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid If-Match header"})
+			return
+		}
+		expectedVersion = &version
+	}
+
+	settings := entities.DefaultDeviceSettings()
+	if err := ctx.ShouldBindJSON(&settings); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	config, err := c.updateDeviceConfigUseCase.Execute(ctx, esp32ID, actor, settings, expectedVersion)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Header("ETag", config.ETag())
+	ctx.JSON(http.StatusOK, config)
+}
+
+// FetchConfig maneja la solicitud HTTP con la que un ESP32 consulta su configuración.
+// Responde 304 si la versión indicada en If-None-Match sigue vigente.
+func (c *ConfigController) FetchConfig(ctx *gin.Context) {
+	config, err := c.fetchDeviceConfigUseCase.Execute(ctx, ctx.GetInt("esp32ID"))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Header("ETag", config.ETag())
+	if ctx.GetHeader("If-None-Match") == config.ETag() {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"version": config.Version,
+		"config":  config.Settings,
+	})
+}
+
+// AcknowledgeConfig maneja la solicitud HTTP con la que un ESP32 confirma la versión aplicada
+func (c *ConfigController) AcknowledgeConfig(ctx *gin.Context) {
+	var req AcknowledgeConfigRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.acknowledgeDeviceConfigUseCase.Execute(ctx, ctx.GetInt("esp32ID"), *req.Version); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// parseConfigETag obtiene la versión de un ETag de configuración, con o sin comillas
+func parseConfigETag(etag string) (int, bool) {
+	etag = strings.Trim(strings.TrimPrefix(strings.TrimSpace(etag), "W/"), `"`)
+	version, err := strconv.Atoi(etag)
+	if err != nil || version < 0 {
+		return 0, false
+	}
+	return version, true
+}
+
+// SetupRoutes configura las rutas de configuración remota
+func (c *ConfigController) SetupRoutes(router *gin.Engine, authMiddleware, deviceAuthMiddleware gin.HandlerFunc) {
+	api := router.Group("/api")
+	{
+		esp32s := api.Group("/esp32s")
+		// Rutas de usuarios (requieren autenticación)
+		esp32s.Use(authMiddleware)
+		{
+			esp32s.GET("/:id/config", c.GetConfig)
+			esp32s.PUT("/:id/config", c.UpdateConfig)
+		}
+
+		devices := api.Group("/devices")
+		// Rutas de dispositivos (requieren número de serie y token del ESP32)
+		devices.Use(deviceAuthMiddleware)
+		{
+			devices.GET("/config", c.FetchConfig)
+			devices.POST("/config/ack", c.AcknowledgeConfig)
+		}
+	}
+}
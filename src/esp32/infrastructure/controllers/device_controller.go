@@ -0,0 +1,46 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"hex_go/src/esp32/application/services"
+)
+
+// DeviceController maneja las solicitudes HTTP que realiza el firmware de los ESP32
+type DeviceController struct {
+	recordHeartbeatUseCase *services.RecordHeartbeatUseCase
+}
+
+// NewDeviceController crea una nueva instancia de DeviceController
+func NewDeviceController(recordHeartbeatUseCase *services.RecordHeartbeatUseCase) *DeviceController {
+	return &DeviceController{
+		recordHeartbeatUseCase: recordHeartbeatUseCase,
+	}
+}
+
+// Heartbeat maneja la solicitud HTTP con la que un ESP32 informa que sigue en línea
+func (c *DeviceController) Heartbeat(ctx *gin.Context) {
+	// Obtener el ID del ESP32 del contexto (establecido por el middleware de dispositivos)
+	esp32ID := ctx.GetInt("esp32ID")
+
+	if err := c.recordHeartbeatUseCase.Execute(ctx, esp32ID); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// SetupRoutes configura las rutas de los dispositivos
+func (c *DeviceController) SetupRoutes(router *gin.Engine, deviceAuthMiddleware gin.HandlerFunc) {
+	api := router.Group("/api")
+	{
+		devices := api.Group("/devices")
+		// Rutas de dispositivos (requieren número de serie y token del ESP32)
+		devices.Use(deviceAuthMiddleware)
+		{
+			devices.POST("/heartbeat", c.Heartbeat)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"hex_go/src/esp32/application/services"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// respondError traduce los errores de los casos de uso a respuestas HTTP
+func respondError(ctx *gin.Context, err error) {
+	status := http.StatusBadRequest
+	switch {
+	case errors.Is(err, services.ErrESP32NotFound), errors.Is(err, services.ErrTransferNotFound),
+		errors.Is(err, services.ErrCommandNotFound), errors.Is(err, services.ErrSensorTypeNotFound),
+		errors.Is(err, services.ErrSensorNotFound), errors.Is(err, services.ErrGroupNotFound),
+		errors.Is(err, services.ErrCommissioningNotFound), errors.Is(err, services.ErrNoOpenCommissioning),
+		errors.Is(err, services.ErrCertificateNotIssued), errors.Is(err, services.ErrMaintenanceScheduleNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, services.ErrESP32Forbidden), errors.Is(err, services.ErrTransferForbidden),
+		errors.Is(err, services.ErrGroupForbidden), errors.Is(err, services.ErrCommissioningForbidden),
+		errors.Is(err, services.ErrInstallerRoleRequired):
+		status = http.StatusForbidden
+	case errors.Is(err, services.ErrTransferNotPending), errors.Is(err, repositories.ErrTransferConflict),
+		errors.Is(err, repositories.ErrConfigVersionConflict), errors.Is(err, services.ErrCommandNotOpen),
+		errors.Is(err, services.ErrSensorTypeExists), errors.Is(err, services.ErrGroupHasChildren),
+		errors.Is(err, services.ErrESP32Decommissioned), errors.Is(err, services.ErrCommissioningInProgress),
+		errors.Is(err, services.ErrCommissioningClosed), errors.Is(err, services.ErrCommissioningIncomplete),
+		errors.Is(err, services.ErrMaintenanceScheduleExists), errors.Is(err, repositories.ErrSensorRetired),
+		errors.Is(err, repositories.ErrClaimConflict), errors.Is(err, repositories.ErrGroupOwnerConflict):
+		status = http.StatusConflict
+	case errors.Is(err, services.ErrTooManyClaimAttempts):
+		status = http.StatusTooManyRequests
+	case errors.Is(err, services.ErrInvalidDeviceCredentials):
+		status = http.StatusUnauthorized
+	case errors.Is(err, services.ErrCertificateSigningDisabled):
+		status = http.StatusServiceUnavailable
+	}
+
+	ctx.JSON(status, gin.H{"error": err.Error()})
+}
+
+// actorFromContext obtiene el usuario autenticado guardado por el middleware de autenticación
+func actorFromContext(ctx *gin.Context) (services.Actor, bool) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		return services.Actor{}, false
+	}
+
+	return services.Actor{
+		UserID: userID.(int),
+		Role:   ctx.GetString("role"),
+	}, true
+}
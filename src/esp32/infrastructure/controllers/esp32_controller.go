@@ -6,15 +6,17 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"hex_go/src/esp32/application/services"
-	"hex_go/src/esp32/domain/repositories"
 )
 
 // ESP32Controller maneja las solicitudes HTTP para ESP32
 type ESP32Controller struct {
-	assignESP32UseCase   *services.AssignESP32UseCase
-	unassignESP32UseCase *services.UnassignESP32UseCase
-	getUserESP32sUseCase *services.GetUserESP32sUseCase
-	esp32Repository      repositories.ESP32Repository
+	assignESP32UseCase          *services.AssignESP32UseCase
+	unassignESP32UseCase        *services.UnassignESP32UseCase
+	getUserESP32sUseCase        *services.GetUserESP32sUseCase
+	getESP32UseCase             *services.GetESP32UseCase
+	getDeviceEventsUseCase      *services.GetDeviceEventsUseCase
+	updateMetadataUseCase       *services.UpdateESP32MetadataUseCase
+	getAssignmentHistoryUseCase *services.GetAssignmentHistoryUseCase
 }
 
 // NewESP32Controller crea una nueva instancia de ESP32Controller
@@ -22,19 +24,36 @@ func NewESP32Controller(
 	assignESP32UseCase *services.AssignESP32UseCase,
 	unassignESP32UseCase *services.UnassignESP32UseCase,
 	getUserESP32sUseCase *services.GetUserESP32sUseCase,
-	esp32Repository repositories.ESP32Repository,
+	getESP32UseCase *services.GetESP32UseCase,
+	getDeviceEventsUseCase *services.GetDeviceEventsUseCase,
+	updateMetadataUseCase *services.UpdateESP32MetadataUseCase,
+	getAssignmentHistoryUseCase *services.GetAssignmentHistoryUseCase,
 ) *ESP32Controller {
 	return &ESP32Controller{
-		assignESP32UseCase:   assignESP32UseCase,
-		unassignESP32UseCase: unassignESP32UseCase,
-		getUserESP32sUseCase: getUserESP32sUseCase,
-		esp32Repository:      esp32Repository,
+		assignESP32UseCase:          assignESP32UseCase,
+		unassignESP32UseCase:        unassignESP32UseCase,
+		getUserESP32sUseCase:        getUserESP32sUseCase,
+		getESP32UseCase:             getESP32UseCase,
+		getDeviceEventsUseCase:      getDeviceEventsUseCase,
+		updateMetadataUseCase:       updateMetadataUseCase,
+		getAssignmentHistoryUseCase: getAssignmentHistoryUseCase,
 	}
 }
 
 // AssignESP32Request representa la estructura de la solicitud para asignar un ESP32
 type AssignESP32Request struct {
 	NumeroSerie string `json:"numero_serie" binding:"required"`
+	ClaimCode   string `json:"claim_code" binding:"required"`
+}
+
+// UpdateESP32MetadataRequest representa la estructura de la solicitud para editar los datos
+// descriptivos de un ESP32; los campos omitidos no se modifican
+type UpdateESP32MetadataRequest struct {
+	Nickname  *string  `json:"nickname"`
+	Room      *string  `json:"room"`
+	Address   *string  `json:"address"`
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
 }
 
 // AssignESP32 maneja la solicitud HTTP para asignar un ESP32 a un usuario
@@ -52,22 +71,10 @@ func (c *ESP32Controller) AssignESP32(ctx *gin.Context) {
 		return
 	}
 
-	// Buscar el ESP32 por número de serie
-	esp32, err := c.esp32Repository.FindByNumeroSerie(ctx, req.NumeroSerie)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-	
-	if esp32 == nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "ESP32 not found"})
-		return
-	}
-
 	// Asignar el ESP32 al usuario
-	err = c.assignESP32UseCase.Execute(ctx, esp32.ID, userID.(int))
+	err := c.assignESP32UseCase.Execute(ctx, req.NumeroSerie, req.ClaimCode, userID.(int))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, err)
 		return
 	}
 
@@ -76,6 +83,12 @@ func (c *ESP32Controller) AssignESP32(ctx *gin.Context) {
 
 // UnassignESP32 maneja la solicitud HTTP para desasignar un ESP32 de un usuario
 func (c *ESP32Controller) UnassignESP32(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
 	// Obtener el ID del ESP32 de la URL
 	esp32IDStr := ctx.Param("id")
 	esp32ID, err := strconv.Atoi(esp32IDStr)
@@ -84,13 +97,16 @@ func (c *ESP32Controller) UnassignESP32(ctx *gin.Context) {
 		return
 	}
 
-	err = c.unassignESP32UseCase.Execute(ctx, esp32ID)
+	claimCode, err := c.unassignESP32UseCase.Execute(ctx, esp32ID, actor)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"message": "ESP32 unassigned successfully"})
+	ctx.JSON(http.StatusOK, gin.H{
+		"message":    "ESP32 unassigned successfully",
+		"claim_code": claimCode,
+	})
 }
 
 // GetUserESP32s maneja la solicitud HTTP para obtener todos los ESP32 de un usuario
@@ -111,6 +127,110 @@ func (c *ESP32Controller) GetUserESP32s(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, esp32s)
 }
 
+// GetESP32 maneja la solicitud HTTP para obtener un ESP32 del usuario
+func (c *ESP32Controller) GetESP32(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	esp32ID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ESP32 ID"})
+		return
+	}
+
+	esp32, err := c.getESP32UseCase.Execute(ctx, esp32ID, actor)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, esp32)
+}
+
+// GetDeviceEvents maneja la solicitud HTTP para obtener el historial de conectividad de un ESP32
+func (c *ESP32Controller) GetDeviceEvents(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	esp32ID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ESP32 ID"})
+		return
+	}
+
+	events, err := c.getDeviceEventsUseCase.Execute(ctx, esp32ID, actor)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, events)
+}
+
+// GetAssignmentHistory maneja la solicitud HTTP para obtener el historial de dueños de un ESP32
+func (c *ESP32Controller) GetAssignmentHistory(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	esp32ID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ESP32 ID"})
+		return
+	}
+
+	assignments, err := c.getAssignmentHistoryUseCase.Execute(ctx, esp32ID, actor)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, assignments)
+}
+
+// UpdateESP32Metadata maneja la solicitud HTTP para editar el apodo y la ubicación de un ESP32
+func (c *ESP32Controller) UpdateESP32Metadata(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	esp32ID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ESP32 ID"})
+		return
+	}
+
+	var req UpdateESP32MetadataRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	esp32, err := c.updateMetadataUseCase.Execute(ctx, esp32ID, actor, services.ESP32MetadataChanges{
+		Nickname:  req.Nickname,
+		Room:      req.Room,
+		Address:   req.Address,
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+	})
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, esp32)
+}
+
 // SetupRoutes configura las rutas para el controlador de ESP32
 func (c *ESP32Controller) SetupRoutes(router *gin.Engine, authMiddleware gin.HandlerFunc) {
 	api := router.Group("/api")
@@ -124,7 +244,11 @@ func (c *ESP32Controller) SetupRoutes(router *gin.Engine, authMiddleware gin.Han
 				protected.POST("/assign", c.AssignESP32)
 				protected.DELETE("/:id/unassign", c.UnassignESP32)
 				protected.GET("/user", c.GetUserESP32s)
+				protected.GET("/:id", c.GetESP32)
+				protected.PATCH("/:id", c.UpdateESP32Metadata)
+				protected.GET("/:id/events", c.GetDeviceEvents)
+				protected.GET("/:id/assignments", c.GetAssignmentHistory)
 			}
 		}
 	}
-}
\ No newline at end of file
+}
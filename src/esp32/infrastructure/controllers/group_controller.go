@@ -0,0 +1,328 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"hex_go/src/esp32/application/services"
+	"hex_go/src/esp32/domain/entities"
+)
+
+// GroupController maneja las solicitudes HTTP de grupos de ESP32 (sitios, edificios, pisos y zonas)
+type GroupController struct {
+	createGroupUseCase          *services.CreateGroupUseCase
+	getGroupsUseCase            *services.GetGroupsUseCase
+	getGroupUseCase             *services.GetGroupUseCase
+	updateGroupUseCase          *services.UpdateGroupUseCase
+	deleteGroupUseCase          *services.DeleteGroupUseCase
+	getGroupStatusUseCase       *services.GetGroupStatusUseCase
+	addESP32ToGroupUseCase      *services.AddESP32ToGroupUseCase
+	removeESP32FromGroupUseCase *services.RemoveESP32FromGroupUseCase
+	getGroupMembersUseCase      *services.GetGroupMembersUseCase
+	saveGroupMemberUseCase      *services.SaveGroupMemberUseCase
+	removeGroupMemberUseCase    *services.RemoveGroupMemberUseCase
+}
+
+// NewGroupController crea una nueva instancia de GroupController
+func NewGroupController(
+	createGroupUseCase *services.CreateGroupUseCase,
+	getGroupsUseCase *services.GetGroupsUseCase,
+	getGroupUseCase *services.GetGroupUseCase,
+	updateGroupUseCase *services.UpdateGroupUseCase,
+	deleteGroupUseCase *services.DeleteGroupUseCase,
+	getGroupStatusUseCase *services.GetGroupStatusUseCase,
+	addESP32ToGroupUseCase *services.AddESP32ToGroupUseCase,
+	removeESP32FromGroupUseCase *services.RemoveESP32FromGroupUseCase,
+	getGroupMembersUseCase *services.GetGroupMembersUseCase,
+	saveGroupMemberUseCase *services.SaveGroupMemberUseCase,
+	removeGroupMemberUseCase *services.RemoveGroupMemberUseCase,
+) *GroupController {
+	return &GroupController{
+		createGroupUseCase:          createGroupUseCase,
+		getGroupsUseCase:            getGroupsUseCase,
+		getGroupUseCase:             getGroupUseCase,
+		updateGroupUseCase:          updateGroupUseCase,
+		deleteGroupUseCase:          deleteGroupUseCase,
+		getGroupStatusUseCase:       getGroupStatusUseCase,
+		addESP32ToGroupUseCase:      addESP32ToGroupUseCase,
+		removeESP32FromGroupUseCase: removeESP32FromGroupUseCase,
+		getGroupMembersUseCase:      getGroupMembersUseCase,
+		saveGroupMemberUseCase:      saveGroupMemberUseCase,
+		removeGroupMemberUseCase:    removeGroupMemberUseCase,
+	}
+}
+
+// CreateGroupRequest representa la estructura de la solicitud para crear un grupo
+type CreateGroupRequest struct {
+	Name     string             `json:"name" binding:"required"`
+	Kind     entities.GroupKind `json:"kind" binding:"required"`
+	ParentID *int               `json:"parent_id"`
+}
+
+// UpdateGroupRequest representa la estructura de la solicitud para renombrar un grupo
+type UpdateGroupRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// AddGroupDeviceRequest representa la estructura de la solicitud para agregar un ESP32 a un grupo
+type AddGroupDeviceRequest struct {
+	ESP32ID int `json:"esp32_id" binding:"required"`
+}
+
+// SaveGroupMemberRequest representa la estructura de la solicitud para dar permisos sobre un grupo
+type SaveGroupMemberRequest struct {
+	Email string             `json:"email" binding:"required,email"`
+	Role  entities.GroupRole `json:"role" binding:"required"`
+}
+
+// CreateGroup maneja la solicitud HTTP para crear un grupo
+func (c *GroupController) CreateGroup(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	var req CreateGroupRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	group, err := c.createGroupUseCase.Execute(ctx, actor, req.Name, req.Kind, req.ParentID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, group)
+}
+
+// GetGroups maneja la solicitud HTTP para listar los grupos del usuario
+func (c *GroupController) GetGroups(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	groups, err := c.getGroupsUseCase.Execute(ctx, actor)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, groups)
+}
+
+// GetGroup maneja la solicitud HTTP para consultar un grupo con sus subgrupos
+func (c *GroupController) GetGroup(ctx *gin.Context) {
+	actor, groupID, ok := groupRequestContext(ctx)
+	if !ok {
+		return
+	}
+
+	group, err := c.getGroupUseCase.Execute(ctx, groupID, actor)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, group)
+}
+
+// UpdateGroup maneja la solicitud HTTP para renombrar un grupo
+func (c *GroupController) UpdateGroup(ctx *gin.Context) {
+	actor, groupID, ok := groupRequestContext(ctx)
+	if !ok {
+		return
+	}
+
+	var req UpdateGroupRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	group, err := c.updateGroupUseCase.Execute(ctx, groupID, actor, req.Name)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, group)
+}
+
+// DeleteGroup maneja la solicitud HTTP para eliminar un grupo
+func (c *GroupController) DeleteGroup(ctx *gin.Context) {
+	actor, groupID, ok := groupRequestContext(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.deleteGroupUseCase.Execute(ctx, groupID, actor); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetGroupStatus maneja la solicitud HTTP para consultar el estado de los ESP32 de un grupo y sus subgrupos
+func (c *GroupController) GetGroupStatus(ctx *gin.Context) {
+	actor, groupID, ok := groupRequestContext(ctx)
+	if !ok {
+		return
+	}
+
+	status, err := c.getGroupStatusUseCase.Execute(ctx, groupID, actor)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, status)
+}
+
+// AddDevice maneja la solicitud HTTP para agregar un ESP32 a un grupo
+func (c *GroupController) AddDevice(ctx *gin.Context) {
+	actor, groupID, ok := groupRequestContext(ctx)
+	if !ok {
+		return
+	}
+
+	var req AddGroupDeviceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.addESP32ToGroupUseCase.Execute(ctx, groupID, req.ESP32ID, actor); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// RemoveDevice maneja la solicitud HTTP para quitar un ESP32 de un grupo
+func (c *GroupController) RemoveDevice(ctx *gin.Context) {
+	actor, groupID, ok := groupRequestContext(ctx)
+	if !ok {
+		return
+	}
+
+	esp32ID, err := strconv.Atoi(ctx.Param("esp32Id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ESP32 ID"})
+		return
+	}
+
+	if err := c.removeESP32FromGroupUseCase.Execute(ctx, groupID, esp32ID, actor); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetMembers maneja la solicitud HTTP para listar los miembros de un grupo
+func (c *GroupController) GetMembers(ctx *gin.Context) {
+	actor, groupID, ok := groupRequestContext(ctx)
+	if !ok {
+		return
+	}
+
+	members, err := c.getGroupMembersUseCase.Execute(ctx, groupID, actor)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, members)
+}
+
+// SaveMember maneja la solicitud HTTP para dar a un usuario permisos sobre un grupo
+func (c *GroupController) SaveMember(ctx *gin.Context) {
+	actor, groupID, ok := groupRequestContext(ctx)
+	if !ok {
+		return
+	}
+
+	var req SaveGroupMemberRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	member, err := c.saveGroupMemberUseCase.Execute(ctx, groupID, actor, req.Email, req.Role)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, member)
+}
+
+// RemoveMember maneja la solicitud HTTP para quitar los permisos de un usuario sobre un grupo
+func (c *GroupController) RemoveMember(ctx *gin.Context) {
+	actor, groupID, ok := groupRequestContext(ctx)
+	if !ok {
+		return
+	}
+
+	userID, err := strconv.Atoi(ctx.Param("userId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	if err := c.removeGroupMemberUseCase.Execute(ctx, groupID, userID, actor); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// groupRequestContext obtiene el usuario autenticado y el ID del grupo de la ruta;
+// si alguno falta ya respondió con el error correspondiente
+func groupRequestContext(ctx *gin.Context) (services.Actor, int, bool) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return services.Actor{}, 0, false
+	}
+
+	groupID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid group ID"})
+		return services.Actor{}, 0, false
+	}
+
+	return actor, groupID, true
+}
+
+// SetupRoutes configura las rutas de grupos de ESP32
+func (c *GroupController) SetupRoutes(router *gin.Engine, authMiddleware gin.HandlerFunc) {
+	api := router.Group("/api")
+	{
+		groups := api.Group("/groups")
+		// Rutas de usuarios (requieren autenticación)
+		groups.Use(authMiddleware)
+		{
+			groups.POST("", c.CreateGroup)
+			groups.GET("", c.GetGroups)
+			groups.GET("/:id", c.GetGroup)
+			groups.PUT("/:id", c.UpdateGroup)
+			groups.DELETE("/:id", c.DeleteGroup)
+			groups.GET("/:id/status", c.GetGroupStatus)
+			groups.POST("/:id/devices", c.AddDevice)
+			groups.DELETE("/:id/devices/:esp32Id", c.RemoveDevice)
+			groups.GET("/:id/members", c.GetMembers)
+			groups.POST("/:id/members", c.SaveMember)
+			groups.DELETE("/:id/members/:userId", c.RemoveMember)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"hex_go/src/esp32/application/services"
+)
+
+// MaintenanceController maneja las solicitudes HTTP del mantenimiento periódico de los ESP32
+type MaintenanceController struct {
+	getESP32MaintenanceUseCase       *services.GetESP32MaintenanceUseCase
+	createMaintenanceScheduleUseCase *services.CreateMaintenanceScheduleUseCase
+	applyDefaultMaintenanceUseCase   *services.ApplyDefaultMaintenanceUseCase
+	updateMaintenanceScheduleUseCase *services.UpdateMaintenanceScheduleUseCase
+	deleteMaintenanceScheduleUseCase *services.DeleteMaintenanceScheduleUseCase
+	recordMaintenanceUseCase         *services.RecordMaintenanceUseCase
+	getGroupComplianceUseCase        *services.GetGroupComplianceUseCase
+}
+
+// NewMaintenanceController crea una nueva instancia de MaintenanceController
+func NewMaintenanceController(
+	getESP32MaintenanceUseCase *services.GetESP32MaintenanceUseCase,
+	createMaintenanceScheduleUseCase *services.CreateMaintenanceScheduleUseCase,
+	applyDefaultMaintenanceUseCase *services.ApplyDefaultMaintenanceUseCase,
+	updateMaintenanceScheduleUseCase *services.UpdateMaintenanceScheduleUseCase,
+	deleteMaintenanceScheduleUseCase *services.DeleteMaintenanceScheduleUseCase,
+	recordMaintenanceUseCase *services.RecordMaintenanceUseCase,
+	getGroupComplianceUseCase *services.GetGroupComplianceUseCase,
+) *MaintenanceController {
+	return &MaintenanceController{
+		getESP32MaintenanceUseCase:       getESP32MaintenanceUseCase,
+		createMaintenanceScheduleUseCase: createMaintenanceScheduleUseCase,
+		applyDefaultMaintenanceUseCase:   applyDefaultMaintenanceUseCase,
+		updateMaintenanceScheduleUseCase: updateMaintenanceScheduleUseCase,
+		deleteMaintenanceScheduleUseCase: deleteMaintenanceScheduleUseCase,
+		recordMaintenanceUseCase:         recordMaintenanceUseCase,
+		getGroupComplianceUseCase:        getGroupComplianceUseCase,
+	}
+}
+
+// GetESP32Maintenance maneja la solicitud HTTP para consultar las tareas y el historial de mantenimiento de un ESP32
+func (c *MaintenanceController) GetESP32Maintenance(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	esp32ID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ESP32 ID"})
+		return
+	}
+
+	maintenance, err := c.getESP32MaintenanceUseCase.Execute(ctx, esp32ID, actor)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, maintenance)
+}
+
+// CreateSchedule maneja la solicitud HTTP para programar una tarea de mantenimiento
+func (c *MaintenanceController) CreateSchedule(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	esp32ID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ESP32 ID"})
+		return
+	}
+
+	var req services.MaintenanceScheduleInput
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	schedule, err := c.createMaintenanceScheduleUseCase.Execute(ctx, esp32ID, actor, req)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, schedule)
+}
+
+// ApplyDefaults maneja la solicitud HTTP para programar las tareas habituales que le faltan a un ESP32
+func (c *MaintenanceController) ApplyDefaults(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	esp32ID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ESP32 ID"})
+		return
+	}
+
+	schedules, err := c.applyDefaultMaintenanceUseCase.Execute(ctx, esp32ID, actor)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, schedules)
+}
+
+// UpdateSchedule maneja la solicitud HTTP para cambiar el intervalo o el vencimiento de una tarea
+func (c *MaintenanceController) UpdateSchedule(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	esp32ID, scheduleID, ok := scheduleParams(ctx)
+	if !ok {
+		return
+	}
+
+	var req services.MaintenanceScheduleInput
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	schedule, err := c.updateMaintenanceScheduleUseCase.Execute(ctx, esp32ID, scheduleID, actor, req)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, schedule)
+}
+
+// DeleteSchedule maneja la solicitud HTTP para dejar de programar una tarea
+func (c *MaintenanceController) DeleteSchedule(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	esp32ID, scheduleID, ok := scheduleParams(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.deleteMaintenanceScheduleUseCase.Execute(ctx, esp32ID, scheduleID, actor); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "maintenance schedule deleted successfully"})
+}
+
+// RecordMaintenance maneja la solicitud HTTP para registrar una tarea realizada
+func (c *MaintenanceController) RecordMaintenance(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	esp32ID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ESP32 ID"})
+		return
+	}
+
+	var req services.RecordMaintenanceInput
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	record, err := c.recordMaintenanceUseCase.Execute(ctx, esp32ID, actor, req)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, record)
+}
+
+// GetGroupCompliance maneja la solicitud HTTP para generar el reporte de cumplimiento de un grupo
+func (c *MaintenanceController) GetGroupCompliance(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	groupID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid group ID"})
+		return
+	}
+
+	report, err := c.getGroupComplianceUseCase.Execute(ctx, groupID, actor)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// scheduleParams lee el ESP32 y la tarea de la URL; si alguno es inválido ya respondió la solicitud
+func scheduleParams(ctx *gin.Context) (int, int, bool) {
+	esp32ID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ESP32 ID"})
+		return 0, 0, false
+	}
+
+	scheduleID, err := strconv.Atoi(ctx.Param("scheduleId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid schedule ID"})
+		return 0, 0, false
+	}
+
+	return esp32ID, scheduleID, true
+}
+
+// SetupRoutes configura las rutas de mantenimiento
+func (c *MaintenanceController) SetupRoutes(router *gin.Engine, authMiddleware gin.HandlerFunc) {
+	api := router.Group("/api")
+	{
+		esp32s := api.Group("/esp32s")
+		// Rutas de usuarios (requieren autenticación)
+		esp32s.Use(authMiddleware)
+		{
+			esp32s.GET("/:id/maintenance", c.GetESP32Maintenance)
+			esp32s.POST("/:id/maintenance/schedules", c.CreateSchedule)
+			esp32s.POST("/:id/maintenance/schedules/defaults", c.ApplyDefaults)
+			esp32s.PUT("/:id/maintenance/schedules/:scheduleId", c.UpdateSchedule)
+			esp32s.DELETE("/:id/maintenance/schedules/:scheduleId", c.DeleteSchedule)
+			esp32s.POST("/:id/maintenance/records", c.RecordMaintenance)
+		}
+
+		groups := api.Group("/groups")
+		// Rutas de usuarios (requieren autenticación)
+		groups.Use(authMiddleware)
+		{
+			groups.GET("/:id/compliance", c.GetGroupCompliance)
+		}
+	}
+}
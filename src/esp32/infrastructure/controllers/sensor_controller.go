@@ -0,0 +1,278 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"hex_go/src/esp32/application/services"
+	"hex_go/src/esp32/domain/entities"
+)
+
+// SensorController maneja las solicitudes HTTP del registro de tipos de sensor
+// y de los sensores instalados en cada ESP32
+type SensorController struct {
+	getSensorTypesUseCase   *services.GetSensorTypesUseCase
+	createSensorTypeUseCase *services.CreateSensorTypeUseCase
+	updateSensorTypeUseCase *services.UpdateSensorTypeUseCase
+	getESP32SensorsUseCase  *services.GetESP32SensorsUseCase
+	installSensorUseCase    *services.InstallSensorUseCase
+	removeSensorUseCase     *services.RemoveSensorUseCase
+	replaceSensorUseCase    *services.ReplaceSensorUseCase
+	getReplacementsUseCase  *services.GetSensorReplacementsUseCase
+}
+
+// NewSensorController crea una nueva instancia de SensorController
+func NewSensorController(
+	getSensorTypesUseCase *services.GetSensorTypesUseCase,
+	createSensorTypeUseCase *services.CreateSensorTypeUseCase,
+	updateSensorTypeUseCase *services.UpdateSensorTypeUseCase,
+	getESP32SensorsUseCase *services.GetESP32SensorsUseCase,
+	installSensorUseCase *services.InstallSensorUseCase,
+	removeSensorUseCase *services.RemoveSensorUseCase,
+	replaceSensorUseCase *services.ReplaceSensorUseCase,
+	getReplacementsUseCase *services.GetSensorReplacementsUseCase,
+) *SensorController {
+	return &SensorController{
+		getSensorTypesUseCase:   getSensorTypesUseCase,
+		createSensorTypeUseCase: createSensorTypeUseCase,
+		updateSensorTypeUseCase: updateSensorTypeUseCase,
+		getESP32SensorsUseCase:  getESP32SensorsUseCase,
+		installSensorUseCase:    installSensorUseCase,
+		removeSensorUseCase:     removeSensorUseCase,
+		replaceSensorUseCase:    replaceSensorUseCase,
+		getReplacementsUseCase:  getReplacementsUseCase,
+	}
+}
+
+// SensorTypeRequest representa la estructura de la solicitud para registrar o editar un tipo de sensor
+type SensorTypeRequest struct {
+	Code       string   `json:"code"`
+	Name       string   `json:"name" binding:"required"`
+	Unit       string   `json:"unit"`
+	MinValue   *float64 `json:"min_value"`
+	MaxValue   *float64 `json:"max_value"`
+	Digital    bool     `json:"digital"`
+	DefaultKit bool     `json:"default_kit"`
+	// Intervalo de recalibración y curva de conversión a ppm, solo para sensores de gas
+	CalibrationIntervalDays *int     `json:"calibration_interval_days"`
+	PPMCurveA               *float64 `json:"ppm_curve_a"`
+	PPMCurveB               *float64 `json:"ppm_curve_b"`
+}
+
+// InstallSensorRequest representa la estructura de la solicitud para instalar un sensor en un ESP32
+type InstallSensorRequest struct {
+	SensorType  string `json:"sensor_type" binding:"required"`
+	NumeroSerie string `json:"numero_serie"`
+	Label       string `json:"label"`
+}
+
+// toSensorType convierte la solicitud en una entidad SensorType
+func (r SensorTypeRequest) toSensorType() *entities.SensorType {
+	return &entities.SensorType{
+		Code:       r.Code,
+		Name:       r.Name,
+		Unit:       r.Unit,
+		MinValue:   r.MinValue,
+		MaxValue:   r.MaxValue,
+		Digital:    r.Digital,
+		DefaultKit: r.DefaultKit,
+
+		CalibrationIntervalDays: r.CalibrationIntervalDays,
+		PPMCurveA:               r.PPMCurveA,
+		PPMCurveB:               r.PPMCurveB,
+	}
+}
+
+// GetSensorTypes maneja la solicitud HTTP para listar los tipos de sensor registrados
+func (c *SensorController) GetSensorTypes(ctx *gin.Context) {
+	sensorTypes, err := c.getSensorTypesUseCase.Execute(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if sensorTypes == nil {
+		sensorTypes = []*entities.SensorType{}
+	}
+
+	ctx.JSON(http.StatusOK, sensorTypes)
+}
+
+// CreateSensorType maneja la solicitud HTTP para registrar un tipo de sensor
+func (c *SensorController) CreateSensorType(ctx *gin.Context) {
+	var req SensorTypeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sensorType, err := c.createSensorTypeUseCase.Execute(ctx, req.toSensorType())
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, sensorType)
+}
+
+// UpdateSensorType maneja la solicitud HTTP para editar un tipo de sensor
+func (c *SensorController) UpdateSensorType(ctx *gin.Context) {
+	var req SensorTypeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sensorType, err := c.updateSensorTypeUseCase.Execute(ctx, ctx.Param("code"), req.toSensorType())
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, sensorType)
+}
+
+// GetESP32Sensors maneja la solicitud HTTP para listar los sensores instalados en un ESP32
+func (c *SensorController) GetESP32Sensors(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	esp32ID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ESP32 ID"})
+		return
+	}
+
+	sensors, err := c.getESP32SensorsUseCase.Execute(ctx, esp32ID, actor)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, sensors)
+}
+
+// InstallSensor maneja la solicitud HTTP para instalar un sensor en un ESP32
+func (c *SensorController) InstallSensor(ctx *gin.Context) {
+	esp32ID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ESP32 ID"})
+		return
+	}
+
+	var req InstallSensorRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sensor, err := c.installSensorUseCase.Execute(ctx, esp32ID, req.SensorType, req.NumeroSerie, req.Label)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, sensor)
+}
+
+// RemoveSensor maneja la solicitud HTTP para retirar un sensor de un ESP32
+func (c *SensorController) RemoveSensor(ctx *gin.Context) {
+	esp32ID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ESP32 ID"})
+		return
+	}
+
+	sensorID, err := strconv.Atoi(ctx.Param("sensorId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid sensor ID"})
+		return
+	}
+
+	if err := c.removeSensorUseCase.Execute(ctx, esp32ID, sensorID); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ReplaceSensor maneja la solicitud HTTP para reemplazar un sensor averiado de un ESP32
+func (c *SensorController) ReplaceSensor(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	esp32ID, sensorID, ok := sensorParams(ctx)
+	if !ok {
+		return
+	}
+
+	var req services.ReplaceSensorInput
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := c.replaceSensorUseCase.Execute(ctx, esp32ID, sensorID, actor, req)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, result)
+}
+
+// GetSensorReplacements maneja la solicitud HTTP para consultar los reemplazos de sensores de un ESP32
+func (c *SensorController) GetSensorReplacements(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	esp32ID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ESP32 ID"})
+		return
+	}
+
+	replacements, err := c.getReplacementsUseCase.Execute(ctx, esp32ID, actor)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, replacements)
+}
+
+// SetupRoutes configura las rutas de tipos de sensor y sensores instalados
+func (c *SensorController) SetupRoutes(router *gin.Engine, authMiddleware, adminMiddleware gin.HandlerFunc) {
+	api := router.Group("/api")
+	{
+		users := api.Group("")
+		// Rutas de usuarios (requieren autenticación)
+		users.Use(authMiddleware)
+		{
+			users.GET("/sensor-types", c.GetSensorTypes)
+			users.GET("/esp32s/:id/sensors", c.GetESP32Sensors)
+			users.GET("/esp32s/:id/sensors/replacements", c.GetSensorReplacements)
+			users.POST("/esp32s/:id/sensors/:sensorId/replace", c.ReplaceSensor)
+		}
+
+		admin := api.Group("/admin")
+		// Rutas de administración (requieren autenticación y rol de administrador)
+		admin.Use(authMiddleware, adminMiddleware)
+		{
+			admin.POST("/sensor-types", c.CreateSensorType)
+			admin.PUT("/sensor-types/:code", c.UpdateSensorType)
+			admin.POST("/esp32s/:id/sensors", c.InstallSensor)
+			admin.DELETE("/esp32s/:id/sensors/:sensorId", c.RemoveSensor)
+		}
+	}
+}
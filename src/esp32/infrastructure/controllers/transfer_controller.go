@@ -0,0 +1,194 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"hex_go/src/esp32/application/services"
+)
+
+// TransferController maneja las solicitudes HTTP para transferir ESP32 entre usuarios
+type TransferController struct {
+	initiateTransferUseCase     *services.InitiateTransferUseCase
+	acceptTransferUseCase       *services.AcceptTransferUseCase
+	rejectTransferUseCase       *services.RejectTransferUseCase
+	cancelTransferUseCase       *services.CancelTransferUseCase
+	getIncomingTransfersUseCase *services.GetIncomingTransfersUseCase
+	getOutgoingTransfersUseCase *services.GetOutgoingTransfersUseCase
+}
+
+// NewTransferController crea una nueva instancia de TransferController
+func NewTransferController(
+	initiateTransferUseCase *services.InitiateTransferUseCase,
+	acceptTransferUseCase *services.AcceptTransferUseCase,
+	rejectTransferUseCase *services.RejectTransferUseCase,
+	cancelTransferUseCase *services.CancelTransferUseCase,
+	getIncomingTransfersUseCase *services.GetIncomingTransfersUseCase,
+	getOutgoingTransfersUseCase *services.GetOutgoingTransfersUseCase,
+) *TransferController {
+	return &TransferController{
+		initiateTransferUseCase:     initiateTransferUseCase,
+		acceptTransferUseCase:       acceptTransferUseCase,
+		rejectTransferUseCase:       rejectTransferUseCase,
+		cancelTransferUseCase:       cancelTransferUseCase,
+		getIncomingTransfersUseCase: getIncomingTransfersUseCase,
+		getOutgoingTransfersUseCase: getOutgoingTransfersUseCase,
+	}
+}
+
+// InitiateTransferRequest representa la estructura de la solicitud para transferir un ESP32
+type InitiateTransferRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// InitiateTransfer maneja la solicitud HTTP para ofrecer un ESP32 a otro usuario
+func (c *TransferController) InitiateTransfer(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	esp32ID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ESP32 ID"})
+		return
+	}
+
+	var req InitiateTransferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	transfer, err := c.initiateTransferUseCase.Execute(ctx, esp32ID, actor, req.Email)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, transfer)
+}
+
+// GetIncomingTransfers maneja la solicitud HTTP para listar los ESP32 ofrecidos al usuario
+func (c *TransferController) GetIncomingTransfers(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	transfers, err := c.getIncomingTransfersUseCase.Execute(ctx, actor.UserID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, transfers)
+}
+
+// GetOutgoingTransfers maneja la solicitud HTTP para listar las transferencias iniciadas por el usuario
+func (c *TransferController) GetOutgoingTransfers(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	transfers, err := c.getOutgoingTransfersUseCase.Execute(ctx, actor.UserID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, transfers)
+}
+
+// AcceptTransfer maneja la solicitud HTTP para aceptar un ESP32 ofrecido
+func (c *TransferController) AcceptTransfer(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	transferID, err := strconv.Atoi(ctx.Param("transferId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid transfer ID"})
+		return
+	}
+
+	transfer, err := c.acceptTransferUseCase.Execute(ctx, transferID, actor)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, transfer)
+}
+
+// RejectTransfer maneja la solicitud HTTP para rechazar un ESP32 ofrecido
+func (c *TransferController) RejectTransfer(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	transferID, err := strconv.Atoi(ctx.Param("transferId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid transfer ID"})
+		return
+	}
+
+	if err := c.rejectTransferUseCase.Execute(ctx, transferID, actor); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "transfer rejected successfully"})
+}
+
+// CancelTransfer maneja la solicitud HTTP para que el remitente retire una transferencia
+func (c *TransferController) CancelTransfer(ctx *gin.Context) {
+	actor, exists := actorFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	transferID, err := strconv.Atoi(ctx.Param("transferId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid transfer ID"})
+		return
+	}
+
+	if err := c.cancelTransferUseCase.Execute(ctx, transferID, actor); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "transfer cancelled successfully"})
+}
+
+// SetupRoutes configura las rutas para el controlador de transferencias
+func (c *TransferController) SetupRoutes(router *gin.Engine, authMiddleware gin.HandlerFunc) {
+	api := router.Group("/api")
+	{
+		esp32s := api.Group("/esp32s")
+		{
+			// Rutas protegidas (requieren autenticación)
+			protected := esp32s.Group("")
+			protected.Use(authMiddleware)
+			{
+				protected.POST("/:id/transfers", c.InitiateTransfer)
+				protected.GET("/transfers/incoming", c.GetIncomingTransfers)
+				protected.GET("/transfers/outgoing", c.GetOutgoingTransfers)
+				protected.POST("/transfers/:transferId/accept", c.AcceptTransfer)
+				protected.POST("/transfers/:transferId/reject", c.RejectTransfer)
+				protected.DELETE("/transfers/:transferId", c.CancelTransfer)
+			}
+		}
+	}
+}
@@ -1,42 +1,299 @@
 package infrastructure
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
 	"log"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"hex_go/src/config"
 	"hex_go/src/esp32/application/services"
+	"hex_go/src/esp32/domain/entities"
 	"hex_go/src/esp32/infrastructure/controllers"
+	"hex_go/src/esp32/infrastructure/notifications"
 	"hex_go/src/esp32/infrastructure/repositories"
+	"hex_go/src/esp32/infrastructure/signing"
 	"hex_go/src/middleware"
 	userRepo "hex_go/src/users/infrastructure/repositories"
 )
 
 // Init inicializa la infraestructura de ESP32
 func Init(router *gin.Engine, db *sql.DB) {
-	// Crear tabla de ESP32 si no existe
-	createESP32Table(db)
+	// Crear tablas de ESP32 si no existen
+	Migrate(db)
 
 	// Inicializar repositorios
 	esp32Repo := repositories.NewMySQLESP32Repository(db)
+	deviceEventRepo := repositories.NewMySQLDeviceEventRepository(db)
+	transferRepo := repositories.NewMySQLESP32TransferRepository(db)
+	deviceConfigRepo := repositories.NewMySQLDeviceConfigRepository(db)
+	deviceCommandRepo := repositories.NewMySQLDeviceCommandRepository(db)
+	sensorTypeRepo := repositories.NewMySQLSensorTypeRepository(db)
+	deviceSensorRepo := repositories.NewMySQLDeviceSensorRepository(db)
+	groupRepo := repositories.NewMySQLDeviceGroupRepository(db)
+	assignmentRepo := repositories.NewMySQLESP32AssignmentRepository(db)
+	calibrationRepo := repositories.NewMySQLSensorCalibrationRepository(db)
+	commissioningRepo := repositories.NewMySQLCommissioningRepository(db)
+	maintenanceRepo := repositories.NewMySQLMaintenanceRepository(db)
 	userRepository := userRepo.NewMySQLUserRepository(db)
 
 	// Inicializar casos de uso
-	assignESP32UseCase := services.NewAssignESP32UseCase(esp32Repo, userRepository)
-	unassignESP32UseCase := services.NewUnassignESP32UseCase(esp32Repo)
+	groupAuthorizer := services.NewGroupAuthorizer(groupRepo)
+	esp32Authorizer := services.NewESP32Authorizer(esp32Repo, groupAuthorizer)
+	userClaimThrottler := services.NewClaimThrottler[int](5, 15*time.Minute)
+	serialClaimThrottler := services.NewClaimThrottler[string](10, 15*time.Minute)
+	assignESP32UseCase := services.NewAssignESP32UseCase(esp32Repo, userRepository, userClaimThrottler, serialClaimThrottler)
+	unassignESP32UseCase := services.NewUnassignESP32UseCase(esp32Repo, esp32Authorizer)
 	getUserESP32sUseCase := services.NewGetUserESP32sUseCase(esp32Repo, userRepository)
+	getESP32UseCase := services.NewGetESP32UseCase(deviceSensorRepo, esp32Authorizer)
+	provisionESP32UseCase := services.NewProvisionESP32UseCase(esp32Repo, sensorTypeRepo)
+	getUnassignedESP32sUseCase := services.NewGetUnassignedESP32sUseCase(esp32Repo)
+	updateESP32UseCase := services.NewUpdateESP32UseCase(esp32Repo)
+	decommissionESP32UseCase := services.NewDecommissionESP32UseCase(esp32Repo)
+	getDecommissionedESP32sUseCase := services.NewGetDecommissionedESP32sUseCase(esp32Repo)
+	regenerateClaimCodeUseCase := services.NewRegenerateClaimCodeUseCase(esp32Repo)
+	regenerateDeviceTokenUseCase := services.NewRegenerateDeviceTokenUseCase(esp32Repo)
+	importESP32sUseCase := services.NewImportESP32sUseCase(esp32Repo, sensorTypeRepo)
+	authenticateDeviceUseCase := services.NewAuthenticateDeviceUseCase(esp32Repo)
+	recordHeartbeatUseCase := services.NewRecordHeartbeatUseCase(esp32Repo, deviceEventRepo)
+	getDeviceEventsUseCase := services.NewGetDeviceEventsUseCase(deviceEventRepo, esp32Authorizer)
+	getAssignmentHistoryUseCase := services.NewGetAssignmentHistoryUseCase(assignmentRepo, esp32Authorizer)
+	updateMetadataUseCase := services.NewUpdateESP32MetadataUseCase(esp32Repo, esp32Authorizer)
+	initiateTransferUseCase := services.NewInitiateTransferUseCase(transferRepo, userRepository, esp32Authorizer)
+	acceptTransferUseCase := services.NewAcceptTransferUseCase(transferRepo, userRepository)
+	rejectTransferUseCase := services.NewRejectTransferUseCase(transferRepo, userRepository)
+	cancelTransferUseCase := services.NewCancelTransferUseCase(transferRepo)
+	getIncomingTransfersUseCase := services.NewGetIncomingTransfersUseCase(transferRepo, userRepository)
+	getOutgoingTransfersUseCase := services.NewGetOutgoingTransfersUseCase(transferRepo)
+	getDeviceConfigUseCase := services.NewGetDeviceConfigUseCase(deviceConfigRepo, esp32Authorizer)
+	updateDeviceConfigUseCase := services.NewUpdateDeviceConfigUseCase(deviceConfigRepo, sensorTypeRepo, esp32Authorizer)
+	fetchDeviceConfigUseCase := services.NewFetchDeviceConfigUseCase(deviceConfigRepo)
+	acknowledgeDeviceConfigUseCase := services.NewAcknowledgeDeviceConfigUseCase(deviceConfigRepo)
+	enqueueCommandUseCase := services.NewEnqueueCommandUseCase(deviceCommandRepo, esp32Authorizer)
+	getCommandHistoryUseCase := services.NewGetCommandHistoryUseCase(deviceCommandRepo, esp32Authorizer)
+	pollCommandsUseCase := services.NewPollCommandsUseCase(deviceCommandRepo)
+	acknowledgeCommandUseCase := services.NewAcknowledgeCommandUseCase(deviceCommandRepo)
+	getSensorTypesUseCase := services.NewGetSensorTypesUseCase(sensorTypeRepo)
+	createSensorTypeUseCase := services.NewCreateSensorTypeUseCase(sensorTypeRepo)
+	updateSensorTypeUseCase := services.NewUpdateSensorTypeUseCase(sensorTypeRepo)
+	getESP32SensorsUseCase := services.NewGetESP32SensorsUseCase(deviceSensorRepo, esp32Authorizer)
+	installSensorUseCase := services.NewInstallSensorUseCase(esp32Repo, sensorTypeRepo, deviceSensorRepo)
+	removeSensorUseCase := services.NewRemoveSensorUseCase(deviceSensorRepo)
+	replaceSensorUseCase := services.NewReplaceSensorUseCase(deviceSensorRepo, maintenanceRepo, esp32Authorizer)
+	getSensorReplacementsUseCase := services.NewGetSensorReplacementsUseCase(deviceSensorRepo, esp32Authorizer)
+	recordCalibrationUseCase := services.NewRecordCalibrationUseCase(deviceSensorRepo, sensorTypeRepo, calibrationRepo, esp32Authorizer)
+	getSensorCalibrationsUseCase := services.NewGetSensorCalibrationsUseCase(deviceSensorRepo, sensorTypeRepo, calibrationRepo, esp32Authorizer)
+	submitSelfCalibrationUseCase := services.NewSubmitSelfCalibrationUseCase(deviceSensorRepo, sensorTypeRepo, calibrationRepo)
+	certificateSigner := loadCertificateSigner()
+	startCommissioningUseCase := services.NewStartCommissioningUseCase(esp32Repo, deviceSensorRepo, commissioningRepo)
+	getESP32CommissioningUseCase := services.NewGetESP32CommissioningUseCase(commissioningRepo, esp32Authorizer)
+	getCommissioningSessionUseCase := services.NewGetCommissioningSessionUseCase(commissioningRepo, esp32Authorizer)
+	completeCommissioningUseCase := services.NewCompleteCommissioningUseCase(esp32Repo, deviceSensorRepo, commissioningRepo, userRepository, certificateSigner, esp32Authorizer)
+	cancelCommissioningUseCase := services.NewCancelCommissioningUseCase(commissioningRepo, esp32Authorizer)
+	getCommissioningCertificateUseCase := services.NewGetCommissioningCertificateUseCase(commissioningRepo, certificateSigner, esp32Authorizer)
+	getDeviceCommissioningUseCase := services.NewGetDeviceCommissioningUseCase(commissioningRepo)
+	reportCommissioningTestUseCase := services.NewReportCommissioningTestUseCase(deviceSensorRepo, commissioningRepo)
+	createGroupUseCase := services.NewCreateGroupUseCase(groupRepo, groupAuthorizer)
+	getGroupsUseCase := services.NewGetGroupsUseCase(groupRepo)
+	getGroupUseCase := services.NewGetGroupUseCase(groupRepo, groupAuthorizer)
+	updateGroupUseCase := services.NewUpdateGroupUseCase(groupRepo, groupAuthorizer)
+	deleteGroupUseCase := services.NewDeleteGroupUseCase(groupRepo, groupAuthorizer)
+	getGroupStatusUseCase := services.NewGetGroupStatusUseCase(esp32Repo, deviceSensorRepo, groupAuthorizer)
+	addESP32ToGroupUseCase := services.NewAddESP32ToGroupUseCase(esp32Repo, groupAuthorizer)
+	removeESP32FromGroupUseCase := services.NewRemoveESP32FromGroupUseCase(esp32Repo, groupAuthorizer)
+	getGroupMembersUseCase := services.NewGetGroupMembersUseCase(groupRepo, groupAuthorizer)
+	saveGroupMemberUseCase := services.NewSaveGroupMemberUseCase(groupRepo, userRepository, groupAuthorizer)
+	removeGroupMemberUseCase := services.NewRemoveGroupMemberUseCase(groupRepo, groupAuthorizer)
+	getESP32MaintenanceUseCase := services.NewGetESP32MaintenanceUseCase(maintenanceRepo, esp32Authorizer)
+	createMaintenanceScheduleUseCase := services.NewCreateMaintenanceScheduleUseCase(deviceSensorRepo, maintenanceRepo, esp32Authorizer)
+	applyDefaultMaintenanceUseCase := services.NewApplyDefaultMaintenanceUseCase(deviceSensorRepo, maintenanceRepo, esp32Authorizer)
+	updateMaintenanceScheduleUseCase := services.NewUpdateMaintenanceScheduleUseCase(maintenanceRepo, esp32Authorizer)
+	deleteMaintenanceScheduleUseCase := services.NewDeleteMaintenanceScheduleUseCase(maintenanceRepo, esp32Authorizer)
+	recordMaintenanceUseCase := services.NewRecordMaintenanceUseCase(maintenanceRepo, esp32Authorizer)
+	getGroupComplianceUseCase := services.NewGetGroupComplianceUseCase(esp32Repo, maintenanceRepo, groupAuthorizer)
 
 	// Inicializar controladores
 	esp32Controller := controllers.NewESP32Controller(
 		assignESP32UseCase,
 		unassignESP32UseCase,
 		getUserESP32sUseCase,
-		esp32Repo,
+		getESP32UseCase,
+		getDeviceEventsUseCase,
+		updateMetadataUseCase,
+		getAssignmentHistoryUseCase,
+	)
+	adminESP32Controller := controllers.NewAdminESP32Controller(
+		provisionESP32UseCase,
+		getUnassignedESP32sUseCase,
+		updateESP32UseCase,
+		decommissionESP32UseCase,
+		getDecommissionedESP32sUseCase,
+		regenerateClaimCodeUseCase,
+		regenerateDeviceTokenUseCase,
+		importESP32sUseCase,
+	)
+	deviceController := controllers.NewDeviceController(recordHeartbeatUseCase)
+	transferController := controllers.NewTransferController(
+		initiateTransferUseCase,
+		acceptTransferUseCase,
+		rejectTransferUseCase,
+		cancelTransferUseCase,
+		getIncomingTransfersUseCase,
+		getOutgoingTransfersUseCase,
+	)
+	configController := controllers.NewConfigController(
+		getDeviceConfigUseCase,
+		updateDeviceConfigUseCase,
+		fetchDeviceConfigUseCase,
+		acknowledgeDeviceConfigUseCase,
+	)
+	commandController := controllers.NewCommandController(
+		enqueueCommandUseCase,
+		getCommandHistoryUseCase,
+		pollCommandsUseCase,
+		acknowledgeCommandUseCase,
+	)
+	sensorController := controllers.NewSensorController(
+		getSensorTypesUseCase,
+		createSensorTypeUseCase,
+		updateSensorTypeUseCase,
+		getESP32SensorsUseCase,
+		installSensorUseCase,
+		removeSensorUseCase,
+		replaceSensorUseCase,
+		getSensorReplacementsUseCase,
+	)
+	calibrationController := controllers.NewCalibrationController(
+		recordCalibrationUseCase,
+		getSensorCalibrationsUseCase,
+		submitSelfCalibrationUseCase,
+	)
+	commissioningController := controllers.NewCommissioningController(
+		startCommissioningUseCase,
+		getESP32CommissioningUseCase,
+		getCommissioningSessionUseCase,
+		completeCommissioningUseCase,
+		cancelCommissioningUseCase,
+		getCommissioningCertificateUseCase,
+		getDeviceCommissioningUseCase,
+		reportCommissioningTestUseCase,
+	)
+	groupController := controllers.NewGroupController(
+		createGroupUseCase,
+		getGroupsUseCase,
+		getGroupUseCase,
+		updateGroupUseCase,
+		deleteGroupUseCase,
+		getGroupStatusUseCase,
+		addESP32ToGroupUseCase,
+		removeESP32FromGroupUseCase,
+		getGroupMembersUseCase,
+		saveGroupMemberUseCase,
+		removeGroupMemberUseCase,
+	)
+	maintenanceController := controllers.NewMaintenanceController(
+		getESP32MaintenanceUseCase,
+		createMaintenanceScheduleUseCase,
+		applyDefaultMaintenanceUseCase,
+		updateMaintenanceScheduleUseCase,
+		deleteMaintenanceScheduleUseCase,
+		recordMaintenanceUseCase,
+		getGroupComplianceUseCase,
 	)
 
 	// Configurar rutas
 	authMiddleware := middleware.AuthMiddleware()
+	adminMiddleware := middleware.AdminMiddleware()
 	esp32Controller.SetupRoutes(router, authMiddleware)
+	adminESP32Controller.SetupRoutes(router, authMiddleware, adminMiddleware)
+	transferController.SetupRoutes(router, authMiddleware)
+	deviceAuthMiddleware := middleware.DeviceAuthMiddleware(authenticateDeviceUseCase)
+	deviceController.SetupRoutes(router, deviceAuthMiddleware)
+	configController.SetupRoutes(router, authMiddleware, deviceAuthMiddleware)
+	commandController.SetupRoutes(router, authMiddleware, deviceAuthMiddleware)
+	sensorController.SetupRoutes(router, authMiddleware, adminMiddleware)
+	calibrationController.SetupRoutes(router, authMiddleware, deviceAuthMiddleware)
+	commissioningController.SetupRoutes(router, authMiddleware, deviceAuthMiddleware)
+	groupController.SetupRoutes(router, authMiddleware)
+	maintenanceController.SetupRoutes(router, authMiddleware)
+
+	// Iniciar la detección de ESP32 desconectados
+	offlineAfter := config.GetDurationEnv("DEVICE_OFFLINE_AFTER", 5*time.Minute)
+	offlineChecker := services.NewDeviceOfflineChecker(esp32Repo, deviceEventRepo, offlineAfter)
+	checkInterval := offlineAfter / 5
+	if checkInterval < time.Second {
+		checkInterval = time.Second
+	}
+	go offlineChecker.Run(context.Background(), checkInterval)
+
+	// Vencer los comandos que no se confirmaron a tiempo
+	commandExpirer := services.NewCommandExpirer(deviceCommandRepo)
+	go commandExpirer.Run(context.Background(), time.Minute)
+
+	// Purgar las lecturas, eventos y ubicación de los ESP32 retirados una vez vencido el período de retención
+	retention := config.GetDurationEnv("DECOMMISSION_RETENTION", 365*24*time.Hour)
+	decommissionPurger := services.NewDecommissionPurger(esp32Repo, retention)
+	go decommissionPurger.Run(context.Background(), time.Hour)
+
+	// Barrer los intentos de reclamo vencidos de usuarios y números de serie
+	go userClaimThrottler.Run(context.Background(), 5*time.Minute)
+	go serialClaimThrottler.Run(context.Background(), 5*time.Minute)
+
+	// Avisar a los responsables de las tareas de mantenimiento próximas a vencer o vencidas
+	maintenanceReminder := services.NewMaintenanceReminder(maintenanceRepo, esp32Repo, groupRepo, userRepository, loadNotifier())
+	go maintenanceReminder.Run(context.Background(), config.GetDurationEnv("MAINTENANCE_REMINDER_INTERVAL", time.Hour))
+}
+
+// Migrate crea o actualiza las tablas del módulo de ESP32. También la usan las herramientas
+// de línea de comandos que acceden a la base de datos sin levantar el servidor.
+func Migrate(db *sql.DB) {
+	createESP32Table(db)
+	createDeviceGroupsTable(db)
+	migrateESP32Table(db)
+	createSensorTypesTable(db)
+	createDeviceSensorsTable(db)
+	migrateLegacySensors(db)
+	createSensorReplacementsTable(db)
+	createSensorCalibrationsTable(db)
+	createESP32EventsTable(db)
+	createESP32AssignmentsTable(db)
+	createESP32TransfersTable(db)
+	createESP32ConfigsTable(db)
+	createESP32CommandsTable(db)
+	createCommissioningTables(db)
+	createMaintenanceTables(db)
+}
+
+// loadCertificateSigner crea el firmante de certificados de comisionamiento a partir de
+// COMMISSIONING_SIGNING_KEY (semilla o clave privada Ed25519 en base64)
+func loadCertificateSigner() services.CertificateSigner {
+	encoded := config.GetEnv("COMMISSIONING_SIGNING_KEY", "")
+	if encoded == "" {
+		log.Println("Warning: COMMISSIONING_SIGNING_KEY not set, commissioning certificates are disabled")
+		return nil
+	}
+
+	signer, err := signing.NewEd25519CertificateSigner(encoded)
+	if err != nil {
+		log.Printf("Warning: Invalid COMMISSIONING_SIGNING_KEY, commissioning certificates are disabled: %v", err)
+		return nil
+	}
+
+	return signer
+}
+
+// loadNotifier crea el canal de avisos: el webhook de NOTIFICATION_WEBHOOK_URL o, si no está
+// configurado, el log del servidor
+func loadNotifier() services.Notifier {
+	url := config.GetEnv("NOTIFICATION_WEBHOOK_URL", "")
+	if url == "" {
+		log.Println("Warning: NOTIFICATION_WEBHOOK_URL not set, notifications are only logged")
+		return notifications.NewLogNotifier()
+	}
+
+	return notifications.NewWebhookNotifier(url)
 }
 
 // createESP32Table crea la tabla de ESP32 si no existe
@@ -54,12 +311,20 @@ func createESP32Table(db *sql.DB) {
 	query := `
 		CREATE TABLE IF NOT EXISTS esp32 (
 			idESP32 INT AUTO_INCREMENT PRIMARY KEY,
-			idKY_026 INT,
-			idMQ_2 INT,
-			idMQ_135 INT,
-			idDHT_22 INT,
 			numero_serie VARCHAR(255) NOT NULL UNIQUE,
 			idUser INT,
+			claim_code_hash CHAR(64) NULL,
+			device_token_hash CHAR(64) NULL,
+			last_seen_at DATETIME NULL,
+			online TINYINT(1) NOT NULL DEFAULT 0,
+			nickname VARCHAR(100) NULL,
+			room VARCHAR(100) NULL,
+			address VARCHAR(255) NULL,
+			latitude DECIMAL(9,6) NULL,
+			longitude DECIMAL(9,6) NULL,
+			assigned_at DATETIME NULL,
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			decommissioned_at DATETIME NULL,
 			FOREIGN KEY (idUser) REFERENCES users(id) ON DELETE SET NULL
 		)
 	`
@@ -71,4 +336,488 @@ func createESP32Table(db *sql.DB) {
 	} else {
 		log.Println("ESP32 table created successfully")
 	}
-}
\ No newline at end of file
+}
+
+// migrateESP32Table agrega a la tabla de ESP32 las columnas introducidas después de su creación
+func migrateESP32Table(db *sql.DB) {
+	config.EnsureColumn(db, "esp32", "claim_code_hash", "CHAR(64) NULL")
+	config.EnsureColumn(db, "esp32", "device_token_hash", "CHAR(64) NULL")
+	config.EnsureColumn(db, "esp32", "last_seen_at", "DATETIME NULL")
+	config.EnsureColumn(db, "esp32", "online", "TINYINT(1) NOT NULL DEFAULT 0")
+	config.EnsureColumn(db, "esp32", "nickname", "VARCHAR(100) NULL")
+	config.EnsureColumn(db, "esp32", "room", "VARCHAR(100) NULL")
+	config.EnsureColumn(db, "esp32", "address", "VARCHAR(255) NULL")
+	config.EnsureColumn(db, "esp32", "latitude", "DECIMAL(9,6) NULL")
+	config.EnsureColumn(db, "esp32", "longitude", "DECIMAL(9,6) NULL")
+	config.EnsureColumn(db, "esp32", "assigned_at", "DATETIME NULL")
+	config.EnsureColumn(db, "esp32", "idGroup", "INT NULL")
+	// Los ESP32 existentes reciben la fecha de la migración, que es lo más cercano que se conoce
+	config.EnsureColumn(db, "esp32", "created_at", "DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP")
+	config.EnsureColumn(db, "esp32", "decommissioned_at", "DATETIME NULL")
+	config.EnsureColumn(db, "esp32", "hardware_revision", "VARCHAR(50) NULL")
+	config.EnsureColumn(db, "esp32", "purged_at", "DATETIME NULL")
+	config.EnsureIndex(db, "esp32", "idx_esp32_decommissioned", "INDEX idx_esp32_decommissioned (decommissioned_at)")
+	config.EnsureIndex(db, "esp32", "fk_esp32_group",
+		"CONSTRAINT fk_esp32_group FOREIGN KEY (idGroup) REFERENCES device_groups(idGroup) ON DELETE SET NULL")
+}
+
+// createDeviceGroupsTable crea las tablas de grupos de ESP32 y de sus miembros si no existen
+func createDeviceGroupsTable(db *sql.DB) {
+	query := `
+		CREATE TABLE IF NOT EXISTS device_groups (
+			idGroup INT AUTO_INCREMENT PRIMARY KEY,
+			parent_id INT NULL,
+			kind VARCHAR(20) NOT NULL,
+			name VARCHAR(100) NOT NULL,
+			owner_id INT NOT NULL,
+			created_at DATETIME NOT NULL,
+			INDEX idx_device_groups_owner (owner_id),
+			FOREIGN KEY (parent_id) REFERENCES device_groups(idGroup),
+			FOREIGN KEY (owner_id) REFERENCES users(id) ON DELETE CASCADE
+		)
+	`
+
+	if _, err := db.Exec(query); err != nil {
+		log.Printf("Warning: Failed to create device groups table: %v", err)
+		return
+	}
+
+	query = `
+		CREATE TABLE IF NOT EXISTS device_group_members (
+			idGroup INT NOT NULL,
+			idUser INT NOT NULL,
+			role VARCHAR(20) NOT NULL,
+			created_at DATETIME NOT NULL,
+			PRIMARY KEY (idGroup, idUser),
+			INDEX idx_device_group_members_user (idUser),
+			FOREIGN KEY (idGroup) REFERENCES device_groups(idGroup) ON DELETE CASCADE,
+			FOREIGN KEY (idUser) REFERENCES users(id) ON DELETE CASCADE
+		)
+	`
+
+	if _, err := db.Exec(query); err != nil {
+		log.Printf("Warning: Failed to create device group members table: %v", err)
+	}
+}
+
+// createSensorTypesTable crea el registro de tipos de sensor y carga los tipos del kit original
+func createSensorTypesTable(db *sql.DB) {
+	query := `
+		CREATE TABLE IF NOT EXISTS sensor_types (
+			code VARCHAR(20) PRIMARY KEY,
+			name VARCHAR(100) NOT NULL,
+			unit VARCHAR(20) NULL,
+			min_value DOUBLE NULL,
+			max_value DOUBLE NULL,
+			digital TINYINT(1) NOT NULL DEFAULT 0,
+			default_kit TINYINT(1) NOT NULL DEFAULT 0,
+			calibration_interval_days INT NULL,
+			ppm_curve_a DOUBLE NULL,
+			ppm_curve_b DOUBLE NULL,
+			created_at DATETIME NOT NULL
+		)
+	`
+
+	if _, err := db.Exec(query); err != nil {
+		log.Printf("Warning: Failed to create sensor types table: %v", err)
+		return
+	}
+
+	// Las versiones anteriores no guardaban datos de calibración; al agregar las columnas se
+	// completan con los valores del kit, sin pisar los que un administrador cargue después
+	calibrationAdded, err := config.ColumnExists(db, "sensor_types", "calibration_interval_days")
+	if err != nil {
+		log.Printf("Warning: Failed to inspect sensor types table: %v", err)
+	}
+	calibrationAdded = err == nil && !calibrationAdded
+	config.EnsureColumn(db, "sensor_types", "calibration_interval_days", "INT NULL")
+	config.EnsureColumn(db, "sensor_types", "ppm_curve_a", "DOUBLE NULL")
+	config.EnsureColumn(db, "sensor_types", "ppm_curve_b", "DOUBLE NULL")
+
+	// INSERT IGNORE respeta los cambios que un administrador haya hecho sobre los tipos existentes
+	for _, sensorType := range entities.DefaultSensorTypes() {
+		_, err := db.Exec(`INSERT IGNORE INTO sensor_types (code, name, unit, min_value, max_value, digital, default_kit,
+				calibration_interval_days, ppm_curve_a, ppm_curve_b, created_at)
+			VALUES (?, ?, NULLIF(?, ''), ?, ?, ?, ?, ?, ?, ?, NOW())`,
+			sensorType.Code, sensorType.Name, sensorType.Unit, sensorType.MinValue, sensorType.MaxValue,
+			sensorType.Digital, sensorType.DefaultKit,
+			sensorType.CalibrationIntervalDays, sensorType.PPMCurveA, sensorType.PPMCurveB)
+		if err != nil {
+			log.Printf("Warning: Failed to seed sensor type %s: %v", sensorType.Code, err)
+			continue
+		}
+
+		if calibrationAdded && sensorType.RequiresCalibration() {
+			_, err := db.Exec(`UPDATE sensor_types SET calibration_interval_days = ?, ppm_curve_a = ?, ppm_curve_b = ?
+				WHERE code = ?`,
+				sensorType.CalibrationIntervalDays, sensorType.PPMCurveA, sensorType.PPMCurveB, sensorType.Code)
+			if err != nil {
+				log.Printf("Warning: Failed to set calibration defaults for sensor type %s: %v", sensorType.Code, err)
+			}
+		}
+	}
+}
+
+// createDeviceSensorsTable crea la tabla de sensores instalados en cada ESP32 si no existe
+func createDeviceSensorsTable(db *sql.DB) {
+	query := `
+		CREATE TABLE IF NOT EXISTS device_sensors (
+			idSensor INT AUTO_INCREMENT PRIMARY KEY,
+			idESP32 INT NOT NULL,
+			sensor_type VARCHAR(20) NOT NULL,
+			numero_serie VARCHAR(100) NULL,
+			label VARCHAR(100) NULL,
+			alarm TINYINT(1) NOT NULL DEFAULT 0,
+			fecha_activacion DATETIME NULL,
+			installed_at DATETIME NOT NULL,
+			retired_at DATETIME NULL,
+			INDEX idx_device_sensors_esp32 (idESP32, sensor_type),
+			FOREIGN KEY (idESP32) REFERENCES esp32(idESP32) ON DELETE CASCADE,
+			FOREIGN KEY (sensor_type) REFERENCES sensor_types(code)
+		)
+	`
+
+	if _, err := db.Exec(query); err != nil {
+		log.Printf("Warning: Failed to create device sensors table: %v", err)
+	}
+}
+
+// createSensorReplacementsTable crea la tabla de reemplazos de sensores si no existe y agrega a los
+// sensores instalados la fecha de retiro. Los sensores no se referencian con claves foráneas para que
+// el historial sobreviva a la eliminación de un sensor.
+func createSensorReplacementsTable(db *sql.DB) {
+	config.EnsureColumn(db, "device_sensors", "retired_at", "DATETIME NULL AFTER installed_at")
+
+	query := `
+		CREATE TABLE IF NOT EXISTS sensor_replacements (
+			idReplacement INT AUTO_INCREMENT PRIMARY KEY,
+			idESP32 INT NOT NULL,
+			sensor_type VARCHAR(20) NOT NULL,
+			old_sensor INT NOT NULL,
+			new_sensor INT NOT NULL,
+			reason VARCHAR(255) NOT NULL,
+			technician VARCHAR(100) NOT NULL,
+			replaced_by INT NULL,
+			replaced_at DATETIME NOT NULL,
+			INDEX idx_sensor_replacements_esp32 (idESP32, replaced_at),
+			FOREIGN KEY (idESP32) REFERENCES esp32(idESP32) ON DELETE CASCADE,
+			FOREIGN KEY (replaced_by) REFERENCES users(id) ON DELETE SET NULL
+		)
+	`
+
+	if _, err := db.Exec(query); err != nil {
+		log.Printf("Warning: Failed to create sensor replacements table: %v", err)
+	}
+}
+
+// createSensorCalibrationsTable crea la tabla de calibraciones de los sensores de gas si no existe
+func createSensorCalibrationsTable(db *sql.DB) {
+	query := `
+		CREATE TABLE IF NOT EXISTS sensor_calibrations (
+			idCalibration INT AUTO_INCREMENT PRIMARY KEY,
+			idSensor INT NOT NULL,
+			r0 DOUBLE NOT NULL,
+			source VARCHAR(20) NOT NULL,
+			technician VARCHAR(100) NULL,
+			calibrated_by INT NULL,
+			calibrated_at DATETIME NOT NULL,
+			temperature DOUBLE NULL,
+			humidity DOUBLE NULL,
+			notes VARCHAR(255) NULL,
+			created_at DATETIME NOT NULL,
+			INDEX idx_sensor_calibrations_sensor (idSensor, calibrated_at),
+			FOREIGN KEY (idSensor) REFERENCES device_sensors(idSensor) ON DELETE CASCADE,
+			FOREIGN KEY (calibrated_by) REFERENCES users(id) ON DELETE SET NULL
+		)
+	`
+
+	if _, err := db.Exec(query); err != nil {
+		log.Printf("Warning: Failed to create sensor calibrations table: %v", err)
+	}
+}
+
+// migrateLegacySensors copia a device_sensors los sensores que las versiones anteriores guardaban
+// en una tabla por tipo (KY_026, MQ_2, MQ_135, DHT_22) referenciada desde una columna de esp32.
+// Cada tipo se migra en una transacción y la columna de esp32 queda en NULL, por lo que
+// volver a ejecutarla no duplica sensores.
+func migrateLegacySensors(db *sql.DB) {
+	for _, table := range []string{entities.SensorKY026, entities.SensorMQ2, entities.SensorMQ135, entities.SensorDHT22} {
+		column := "id" + table
+
+		hasColumn, err := config.ColumnExists(db, "esp32", column)
+		if err != nil {
+			log.Printf("Warning: Failed to inspect column esp32.%s: %v", column, err)
+			continue
+		}
+		hasTable, err := config.TableExists(db, table)
+		if err != nil {
+			log.Printf("Warning: Failed to inspect table %s: %v", table, err)
+			continue
+		}
+		if !hasColumn || !hasTable {
+			continue
+		}
+
+		// El número de serie de fábrica solo existe si la tabla la creó una versión que lo importaba
+		serialColumn := "NULL"
+		if hasSerial, err := config.ColumnExists(db, table, "numero_serie"); err == nil && hasSerial {
+			serialColumn = "s.numero_serie"
+		}
+
+		if err := migrateLegacySensorTable(db, table, column, serialColumn); err != nil {
+			log.Printf("Warning: Failed to migrate %s sensors: %v", table, err)
+		}
+	}
+}
+
+// migrateLegacySensorTable migra los sensores de una tabla por tipo dentro de una transacción
+func migrateLegacySensorTable(db *sql.DB, table, column, serialColumn string) error {
+	// La columna pudo crearse como NOT NULL; se relaja para poder marcar los sensores migrados
+	if _, err := db.Exec(fmt.Sprintf("ALTER TABLE esp32 MODIFY %s INT NULL", column)); err != nil {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	insert := fmt.Sprintf(`INSERT INTO device_sensors (idESP32, sensor_type, numero_serie, alarm, fecha_activacion, installed_at)
+		SELECT e.idESP32, ?, %[3]s, s.estado <> 0, s.fecha_activacion, COALESCE(s.fecha_activacion, NOW())
+		FROM esp32 e JOIN %[1]s s ON s.%[2]s = e.%[2]s`, table, column, serialColumn)
+	result, err := tx.Exec(insert, table)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(fmt.Sprintf("UPDATE esp32 SET %[1]s = NULL WHERE %[1]s IS NOT NULL", column)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	if migrated, err := result.RowsAffected(); err == nil && migrated > 0 {
+		log.Printf("Migrated %d %s sensors to device_sensors", migrated, table)
+	}
+	return nil
+}
+
+// createESP32EventsTable crea la tabla de eventos de conectividad si no existe
+func createESP32EventsTable(db *sql.DB) {
+	query := `
+		CREATE TABLE IF NOT EXISTS esp32_events (
+			idEvent INT AUTO_INCREMENT PRIMARY KEY,
+			idESP32 INT NOT NULL,
+			event_type VARCHAR(20) NOT NULL,
+			created_at DATETIME NOT NULL,
+			INDEX idx_esp32_events_esp32 (idESP32, created_at),
+			FOREIGN KEY (idESP32) REFERENCES esp32(idESP32) ON DELETE CASCADE
+		)
+	`
+
+	if _, err := db.Exec(query); err != nil {
+		log.Printf("Warning: Failed to create ESP32 events table: %v", err)
+	}
+}
+
+// createESP32AssignmentsTable crea la tabla del historial de dueños si no existe y registra
+// como asignaciones heredadas las de los ESP32 que ya tenían dueño antes de que existiera
+func createESP32AssignmentsTable(db *sql.DB) {
+	query := `
+		CREATE TABLE IF NOT EXISTS esp32_assignments (
+			idAssignment INT AUTO_INCREMENT PRIMARY KEY,
+			idESP32 INT NOT NULL,
+			idUser INT NULL,
+			assigned_at DATETIME NOT NULL,
+			unassigned_at DATETIME NULL,
+			assign_reason VARCHAR(20) NOT NULL,
+			unassign_reason VARCHAR(20) NULL,
+			INDEX idx_esp32_assignments_esp32 (idESP32, assigned_at),
+			FOREIGN KEY (idESP32) REFERENCES esp32(idESP32) ON DELETE CASCADE,
+			FOREIGN KEY (idUser) REFERENCES users(id) ON DELETE SET NULL
+		)
+	`
+
+	if _, err := db.Exec(query); err != nil {
+		log.Printf("Warning: Failed to create ESP32 assignments table: %v", err)
+		return
+	}
+
+	// Solo se agregan los ESP32 asignados sin asignación vigente, por lo que volver a ejecutarlo no duplica filas
+	result, err := db.Exec(`INSERT INTO esp32_assignments (idESP32, idUser, assigned_at, assign_reason)
+		SELECT e.idESP32, e.idUser, COALESCE(e.assigned_at, e.created_at), ?
+		FROM esp32 e
+		WHERE e.idUser IS NOT NULL AND NOT EXISTS (
+			SELECT 1 FROM esp32_assignments a WHERE a.idESP32 = e.idESP32 AND a.unassigned_at IS NULL
+		)`, string(entities.AssignmentLegacy))
+	if err != nil {
+		log.Printf("Warning: Failed to backfill ESP32 assignments: %v", err)
+		return
+	}
+	if backfilled, err := result.RowsAffected(); err == nil && backfilled > 0 {
+		log.Printf("Backfilled %d ESP32 assignments", backfilled)
+	}
+}
+
+// createESP32TransfersTable crea la tabla de transferencias de propiedad si no existe
+func createESP32TransfersTable(db *sql.DB) {
+	query := `
+		CREATE TABLE IF NOT EXISTS esp32_transfers (
+			idTransfer INT AUTO_INCREMENT PRIMARY KEY,
+			idESP32 INT NOT NULL,
+			from_user INT NOT NULL,
+			to_email VARCHAR(255) NOT NULL,
+			to_user INT NULL,
+			status VARCHAR(20) NOT NULL,
+			created_at DATETIME NOT NULL,
+			expires_at DATETIME NOT NULL,
+			resolved_at DATETIME NULL,
+			INDEX idx_esp32_transfers_email (to_email, status),
+			FOREIGN KEY (idESP32) REFERENCES esp32(idESP32) ON DELETE CASCADE,
+			FOREIGN KEY (from_user) REFERENCES users(id) ON DELETE CASCADE,
+			FOREIGN KEY (to_user) REFERENCES users(id) ON DELETE SET NULL
+		)
+	`
+
+	if _, err := db.Exec(query); err != nil {
+		log.Printf("Warning: Failed to create ESP32 transfers table: %v", err)
+	}
+}
+
+// createESP32ConfigsTable crea la tabla de configuración remota si no existe
+func createESP32ConfigsTable(db *sql.DB) {
+	query := `
+		CREATE TABLE IF NOT EXISTS esp32_configs (
+			idESP32 INT PRIMARY KEY,
+			version INT NOT NULL,
+			settings JSON NOT NULL,
+			updated_at DATETIME NULL,
+			updated_by INT NULL,
+			applied_version INT NULL,
+			applied_at DATETIME NULL,
+			FOREIGN KEY (idESP32) REFERENCES esp32(idESP32) ON DELETE CASCADE,
+			FOREIGN KEY (updated_by) REFERENCES users(id) ON DELETE SET NULL
+		)
+	`
+
+	if _, err := db.Exec(query); err != nil {
+		log.Printf("Warning: Failed to create ESP32 configs table: %v", err)
+	}
+}
+
+// createESP32CommandsTable crea la tabla de la cola de comandos si no existe
+func createESP32CommandsTable(db *sql.DB) {
+	query := `
+		CREATE TABLE IF NOT EXISTS esp32_commands (
+			idCommand INT AUTO_INCREMENT PRIMARY KEY,
+			idESP32 INT NOT NULL,
+			command_type VARCHAR(20) NOT NULL,
+			duration_seconds INT NOT NULL DEFAULT 0,
+			issued_by INT NULL,
+			status VARCHAR(20) NOT NULL,
+			result VARCHAR(255) NULL,
+			created_at DATETIME NOT NULL,
+			expires_at DATETIME NOT NULL,
+			delivered_at DATETIME NULL,
+			completed_at DATETIME NULL,
+			INDEX idx_esp32_commands_open (idESP32, status, expires_at),
+			FOREIGN KEY (idESP32) REFERENCES esp32(idESP32) ON DELETE CASCADE,
+			FOREIGN KEY (issued_by) REFERENCES users(id) ON DELETE SET NULL
+		)
+	`
+
+	if _, err := db.Exec(query); err != nil {
+		log.Printf("Warning: Failed to create ESP32 commands table: %v", err)
+	}
+}
+
+// createCommissioningTables crea las tablas de sesiones y pruebas de comisionamiento si no existen
+func createCommissioningTables(db *sql.DB) {
+	queries := []string{
+		`CREATE TABLE IF NOT EXISTS commissioning_sessions (
+			idSession INT AUTO_INCREMENT PRIMARY KEY,
+			idESP32 INT NOT NULL,
+			installer_id INT NULL,
+			status VARCHAR(20) NOT NULL,
+			notes VARCHAR(255) NULL,
+			started_at DATETIME NOT NULL,
+			expires_at DATETIME NOT NULL,
+			completed_at DATETIME NULL,
+			certificate TEXT NULL,
+			signature VARCHAR(255) NULL,
+			INDEX idx_commissioning_sessions_esp32 (idESP32, status),
+			FOREIGN KEY (idESP32) REFERENCES esp32(idESP32) ON DELETE CASCADE,
+			FOREIGN KEY (installer_id) REFERENCES users(id) ON DELETE SET NULL
+		)`,
+		// Las pruebas guardan el tipo del sensor para que el historial sobreviva al retiro del sensor
+		`CREATE TABLE IF NOT EXISTS commissioning_tests (
+			idTest INT AUTO_INCREMENT PRIMARY KEY,
+			idSession INT NOT NULL,
+			idSensor INT NOT NULL,
+			sensor_type VARCHAR(20) NOT NULL,
+			kind VARCHAR(20) NULL,
+			status VARCHAR(20) NOT NULL,
+			value DOUBLE NULL,
+			detail VARCHAR(255) NULL,
+			reported_at DATETIME NULL,
+			UNIQUE KEY uq_commissioning_tests_sensor (idSession, idSensor),
+			FOREIGN KEY (idSession) REFERENCES commissioning_sessions(idSession) ON DELETE CASCADE
+		)`,
+	}
+
+	for _, query := range queries {
+		if _, err := db.Exec(query); err != nil {
+			log.Printf("Warning: Failed to create commissioning tables: %v", err)
+		}
+	}
+}
+
+// createMaintenanceTables crea las tablas de tareas de mantenimiento y de su historial si no existen
+func createMaintenanceTables(db *sql.DB) {
+	queries := []string{
+		`CREATE TABLE IF NOT EXISTS maintenance_schedules (
+			idSchedule INT AUTO_INCREMENT PRIMARY KEY,
+			idESP32 INT NOT NULL,
+			idSensor INT NULL,
+			task VARCHAR(30) NOT NULL,
+			interval_days INT NOT NULL,
+			due_at DATETIME NOT NULL,
+			last_performed_at DATETIME NULL,
+			last_result VARCHAR(10) NULL,
+			reminder_level VARCHAR(10) NULL,
+			reminded_at DATETIME NULL,
+			created_at DATETIME NOT NULL,
+			INDEX idx_maintenance_schedules_esp32 (idESP32),
+			INDEX idx_maintenance_schedules_due (due_at),
+			FOREIGN KEY (idESP32) REFERENCES esp32(idESP32) ON DELETE CASCADE,
+			FOREIGN KEY (idSensor) REFERENCES device_sensors(idSensor) ON DELETE CASCADE
+		)`,
+		// El historial guarda la tarea y el sensor para sobrevivir a la eliminación de la tarea
+		`CREATE TABLE IF NOT EXISTS maintenance_records (
+			idRecord INT AUTO_INCREMENT PRIMARY KEY,
+			idSchedule INT NULL,
+			idESP32 INT NOT NULL,
+			idSensor INT NULL,
+			task VARCHAR(30) NOT NULL,
+			result VARCHAR(10) NOT NULL,
+			performed_at DATETIME NOT NULL,
+			performed_by INT NULL,
+			technician VARCHAR(100) NULL,
+			notes VARCHAR(255) NULL,
+			created_at DATETIME NOT NULL,
+			INDEX idx_maintenance_records_esp32 (idESP32, task, performed_at),
+			FOREIGN KEY (idSchedule) REFERENCES maintenance_schedules(idSchedule) ON DELETE SET NULL,
+			FOREIGN KEY (idESP32) REFERENCES esp32(idESP32) ON DELETE CASCADE,
+			FOREIGN KEY (performed_by) REFERENCES users(id) ON DELETE SET NULL
+		)`,
+	}
+
+	for _, query := range queries {
+		if _, err := db.Exec(query); err != nil {
+			log.Printf("Warning: Failed to create maintenance tables: %v", err)
+		}
+	}
+}
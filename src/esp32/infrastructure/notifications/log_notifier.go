@@ -0,0 +1,23 @@
+package notifications
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"hex_go/src/esp32/application/services"
+)
+
+// LogNotifier escribe los avisos en el log; se usa cuando no hay un webhook configurado
+type LogNotifier struct{}
+
+// NewLogNotifier crea una nueva instancia de LogNotifier
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+// Notify escribe el aviso en el log
+func (n *LogNotifier) Notify(ctx context.Context, notification services.Notification) error {
+	log.Printf("Notification %s to %s: %s", notification.Kind, strings.Join(notification.Recipients, ", "), notification.Message)
+	return nil
+}
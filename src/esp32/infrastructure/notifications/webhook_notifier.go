@@ -0,0 +1,55 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"hex_go/src/esp32/application/services"
+)
+
+// webhookTimeout limita la espera de cada envío para no demorar al resto de los avisos
+const webhookTimeout = 10 * time.Second
+
+// WebhookNotifier envía los avisos como JSON a un webhook, que se encarga de repartirlos por
+// email, SMS u otros canales
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookNotifier crea una nueva instancia de WebhookNotifier
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:    url,
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// Notify envía el aviso al webhook y falla si no responde con un estado 2xx
+func (n *WebhookNotifier) Notify(ctx context.Context, notification services.Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notification webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
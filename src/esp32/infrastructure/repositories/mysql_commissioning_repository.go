@@ -0,0 +1,284 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// commissioningColumns son las columnas que se leen en todas las consultas de sesiones, en el orden de scanCommissioningSession
+const commissioningColumns = `idSession, idESP32, installer_id, status, notes, started_at, expires_at, completed_at,
+              certificate, signature`
+
+// commissioningTestColumns son las columnas que se leen en todas las consultas de pruebas, en el orden de scanCommissioningTest
+const commissioningTestColumns = `idTest, idSession, idSensor, sensor_type, kind, status, value, detail, reported_at`
+
+// MySQLCommissioningRepository implementa CommissioningRepository usando MySQL
+type MySQLCommissioningRepository struct {
+	db *sql.DB
+}
+
+// NewMySQLCommissioningRepository crea una nueva instancia de MySQLCommissioningRepository
+func NewMySQLCommissioningRepository(db *sql.DB) repositories.CommissioningRepository {
+	return &MySQLCommissioningRepository{
+		db: db,
+	}
+}
+
+// Create inserta la sesión y sus pruebas pendientes en una transacción
+func (r *MySQLCommissioningRepository) Create(ctx context.Context, session *entities.CommissioningSession) (*entities.CommissioningSession, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `INSERT INTO commissioning_sessions (idESP32, installer_id, status, started_at, expires_at)
+              VALUES (?, ?, ?, ?, ?)`,
+		session.ESP32ID, session.InstallerID, string(session.Status), session.StartedAt, session.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	session.ID = int(id)
+
+	for _, test := range session.Tests {
+		test.SessionID = session.ID
+		result, err := tx.ExecContext(ctx, `INSERT INTO commissioning_tests (idSession, idSensor, sensor_type, kind, status)
+              VALUES (?, ?, ?, ?, ?)`,
+			test.SessionID, test.SensorID, test.SensorType, nullableString(string(test.Kind)), string(test.Status))
+		if err != nil {
+			return nil, err
+		}
+
+		testID, err := result.LastInsertId()
+		if err != nil {
+			return nil, err
+		}
+		test.ID = int(testID)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// FindByID busca una sesión por su ID
+func (r *MySQLCommissioningRepository) FindByID(ctx context.Context, id int) (*entities.CommissioningSession, error) {
+	query := `SELECT ` + commissioningColumns + ` FROM commissioning_sessions WHERE idSession = ?`
+
+	return r.findOne(ctx, query, id)
+}
+
+// FindOpenByESP32ID busca la sesión en curso y no vencida de un ESP32
+func (r *MySQLCommissioningRepository) FindOpenByESP32ID(ctx context.Context, esp32ID int) (*entities.CommissioningSession, error) {
+	query := `SELECT ` + commissioningColumns + ` FROM commissioning_sessions
+              WHERE idESP32 = ? AND status = 'in_progress' AND expires_at > ?
+              ORDER BY started_at DESC, idSession DESC LIMIT 1`
+
+	return r.findOne(ctx, query, esp32ID, time.Now())
+}
+
+// FindByESP32ID busca las sesiones de un ESP32, de la más reciente a la más antigua
+func (r *MySQLCommissioningRepository) FindByESP32ID(ctx context.Context, esp32ID int) ([]*entities.CommissioningSession, error) {
+	query := `SELECT ` + commissioningColumns + ` FROM commissioning_sessions
+              WHERE idESP32 = ? ORDER BY started_at DESC, idSession DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []*entities.CommissioningSession
+
+	for rows.Next() {
+		session, err := scanCommissioningSession(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// SaveTest actualiza el resultado de una prueba
+func (r *MySQLCommissioningRepository) SaveTest(ctx context.Context, test *entities.CommissioningTest) error {
+	query := `UPDATE commissioning_tests SET kind = ?, status = ?, value = ?, detail = ?, reported_at = ?
+              WHERE idTest = ?`
+
+	_, err := r.db.ExecContext(ctx, query, nullableString(string(test.Kind)), string(test.Status),
+		nullableFloat(test.Value), nullableString(test.Detail), test.ReportedAt, test.ID)
+	return err
+}
+
+// Close registra el estado final de una sesión que seguía en curso, junto con su certificado si lo tiene
+func (r *MySQLCommissioningRepository) Close(ctx context.Context, session *entities.CommissioningSession, completedAt time.Time) (bool, error) {
+	var certificate, signature interface{}
+	if session.Certificate != nil {
+		certificate = string(session.Certificate.Payload)
+		signature = session.Certificate.Signature
+	}
+
+	query := `UPDATE commissioning_sessions SET status = ?, notes = ?, completed_at = ?, certificate = ?, signature = ?
+              WHERE idSession = ? AND status = 'in_progress' AND expires_at > ?`
+
+	result, err := r.db.ExecContext(ctx, query, string(session.Status), nullableString(session.Notes), completedAt,
+		certificate, signature, session.ID, completedAt)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// findOne ejecuta una consulta que devuelve una sesión y carga sus pruebas
+func (r *MySQLCommissioningRepository) findOne(ctx context.Context, query string, args ...interface{}) (*entities.CommissioningSession, error) {
+	session, err := scanCommissioningSession(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // No error, just no session found
+		}
+		return nil, err
+	}
+
+	tests, err := r.findTests(ctx, session.ID)
+	if err != nil {
+		return nil, err
+	}
+	session.Tests = tests
+
+	return session, nil
+}
+
+// findTests busca las pruebas de una sesión en el orden en que se crearon
+func (r *MySQLCommissioningRepository) findTests(ctx context.Context, sessionID int) ([]*entities.CommissioningTest, error) {
+	query := `SELECT ` + commissioningTestColumns + ` FROM commissioning_tests WHERE idSession = ? ORDER BY idTest`
+
+	rows, err := r.db.QueryContext(ctx, query, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tests []*entities.CommissioningTest
+
+	for rows.Next() {
+		test, err := scanCommissioningTest(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		tests = append(tests, test)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tests, nil
+}
+
+// scanCommissioningSession convierte una fila con las columnas de commissioningColumns en una entidad CommissioningSession
+func scanCommissioningSession(row rowScanner) (*entities.CommissioningSession, error) {
+	var session entities.CommissioningSession
+	var status string
+	var installerID sql.NullInt64
+	var notes, certificate, signature sql.NullString
+	var completedAt sql.NullTime
+
+	err := row.Scan(
+		&session.ID,
+		&session.ESP32ID,
+		&installerID,
+		&status,
+		&notes,
+		&session.StartedAt,
+		&session.ExpiresAt,
+		&completedAt,
+		&certificate,
+		&signature,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	session.Status = entities.CommissioningStatus(status)
+	session.Notes = notes.String
+	if installerID.Valid {
+		installerIDInt := int(installerID.Int64)
+		session.InstallerID = &installerIDInt
+	}
+	if completedAt.Valid {
+		session.CompletedAt = &completedAt.Time
+	}
+	if certificate.Valid {
+		session.Certificate = &entities.SignedCertificate{
+			Payload:   []byte(certificate.String),
+			Signature: signature.String,
+		}
+	}
+
+	// Las sesiones vencidas se reportan como tales aunque nadie las haya cerrado
+	if session.IsOpen() && !time.Now().Before(session.ExpiresAt) {
+		session.Status = entities.CommissioningExpired
+	}
+
+	return &session, nil
+}
+
+// scanCommissioningTest convierte una fila con las columnas de commissioningTestColumns en una entidad CommissioningTest
+func scanCommissioningTest(row rowScanner) (*entities.CommissioningTest, error) {
+	var test entities.CommissioningTest
+	var kind, detail sql.NullString
+	var status string
+	var value sql.NullFloat64
+	var reportedAt sql.NullTime
+
+	err := row.Scan(
+		&test.ID,
+		&test.SessionID,
+		&test.SensorID,
+		&test.SensorType,
+		&kind,
+		&status,
+		&value,
+		&detail,
+		&reportedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	test.Kind = entities.TestKind(kind.String)
+	test.Status = entities.TestStatus(status)
+	test.Detail = detail.String
+	if value.Valid {
+		test.Value = &value.Float64
+	}
+	if reportedAt.Valid {
+		test.ReportedAt = &reportedAt.Time
+	}
+
+	return &test, nil
+}
@@ -0,0 +1,237 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// commandColumns son las columnas que se leen en todas las consultas de comandos
+const commandColumns = `idCommand, idESP32, command_type, duration_seconds, issued_by, status, result,
+              created_at, expires_at, delivered_at, completed_at`
+
+// MySQLDeviceCommandRepository implementa DeviceCommandRepository usando MySQL
+type MySQLDeviceCommandRepository struct {
+	db *sql.DB
+}
+
+// NewMySQLDeviceCommandRepository crea una nueva instancia de MySQLDeviceCommandRepository
+func NewMySQLDeviceCommandRepository(db *sql.DB) repositories.DeviceCommandRepository {
+	return &MySQLDeviceCommandRepository{
+		db: db,
+	}
+}
+
+// Create inserta un nuevo comando en la cola
+func (r *MySQLDeviceCommandRepository) Create(ctx context.Context, command *entities.DeviceCommand) (*entities.DeviceCommand, error) {
+	query := `INSERT INTO esp32_commands (idESP32, command_type, duration_seconds, issued_by, status, created_at, expires_at)
+              VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	result, err := r.db.ExecContext(ctx, query, command.ESP32ID, string(command.Type), command.DurationSeconds,
+		command.IssuedBy, string(command.Status), command.CreatedAt, command.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	command.ID = int(id)
+
+	return command, nil
+}
+
+// FindByID busca un comando por su ID
+func (r *MySQLDeviceCommandRepository) FindByID(ctx context.Context, id int) (*entities.DeviceCommand, error) {
+	query := `SELECT ` + commandColumns + ` FROM esp32_commands WHERE idCommand = ?`
+
+	command, err := scanCommand(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // No error, just no command found
+		}
+		return nil, err
+	}
+
+	return command, nil
+}
+
+// FindByESP32ID busca el historial de comandos de un ESP32, del más reciente al más antiguo
+func (r *MySQLDeviceCommandRepository) FindByESP32ID(ctx context.Context, esp32ID, limit int) ([]*entities.DeviceCommand, error) {
+	query := `SELECT ` + commandColumns + ` FROM esp32_commands
+              WHERE idESP32 = ? ORDER BY created_at DESC, idCommand DESC LIMIT ?`
+
+	return r.findMany(ctx, query, esp32ID, limit)
+}
+
+// ClaimPending marca como entregados los comandos pendientes y no vencidos de un ESP32 y los
+// devuelve, en el orden en que se encolaron. Las filas se bloquean con FOR UPDATE dentro de la
+// transacción, así dos consultas simultáneas del mismo ESP32 no reciben el mismo comando.
+func (r *MySQLDeviceCommandRepository) ClaimPending(ctx context.Context, esp32ID int, deliveredAt time.Time) ([]*entities.DeviceCommand, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `SELECT ` + commandColumns + ` FROM esp32_commands
+              WHERE idESP32 = ? AND status = 'pending' AND expires_at > ?
+              ORDER BY created_at, idCommand
+              FOR UPDATE`
+
+	rows, err := tx.QueryContext(ctx, query, esp32ID, deliveredAt)
+	if err != nil {
+		return nil, err
+	}
+
+	var commands []*entities.DeviceCommand
+	for rows.Next() {
+		command, err := scanCommand(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		commands = append(commands, command)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(commands) == 0 {
+		return commands, tx.Commit()
+	}
+
+	args := []interface{}{deliveredAt}
+	for _, command := range commands {
+		args = append(args, command.ID)
+	}
+
+	query = `UPDATE esp32_commands SET status = 'delivered', delivered_at = ?
+             WHERE status = 'pending' AND idCommand IN (?` + strings.Repeat(", ?", len(commands)-1) + `)`
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	for _, command := range commands {
+		command.Status = entities.CommandDelivered
+		command.DeliveredAt = &deliveredAt
+	}
+
+	return commands, nil
+}
+
+// Complete registra el resultado de un comando que seguía abierto
+func (r *MySQLDeviceCommandRepository) Complete(ctx context.Context, id int, status entities.CommandStatus, result string, completedAt time.Time) (bool, error) {
+	query := `UPDATE esp32_commands SET status = ?, result = ?, completed_at = ?
+              WHERE idCommand = ? AND status IN ('pending', 'delivered')`
+
+	res, err := r.db.ExecContext(ctx, query, string(status), result, completedAt, id)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// ExpireStale marca como vencidos los comandos no entregados cuyo plazo terminó. Los entregados
+// no vencen: el ESP32 ya los recibió y su confirmación puede llegar tarde, por ejemplo tras un reinicio.
+func (r *MySQLDeviceCommandRepository) ExpireStale(ctx context.Context, now time.Time) (int64, error) {
+	query := `UPDATE esp32_commands SET status = 'expired'
+              WHERE status = 'pending' AND expires_at <= ?`
+
+	result, err := r.db.ExecContext(ctx, query, now)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// findMany ejecuta una consulta que devuelve una lista de comandos
+func (r *MySQLDeviceCommandRepository) findMany(ctx context.Context, query string, args ...interface{}) ([]*entities.DeviceCommand, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var commands []*entities.DeviceCommand
+
+	for rows.Next() {
+		command, err := scanCommand(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		commands = append(commands, command)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return commands, nil
+}
+
+// scanCommand convierte una fila con las columnas de commandColumns en una entidad DeviceCommand
+func scanCommand(row rowScanner) (*entities.DeviceCommand, error) {
+	var command entities.DeviceCommand
+	var commandType, status string
+	var issuedBy sql.NullInt64
+	var result sql.NullString
+	var deliveredAt, completedAt sql.NullTime
+
+	err := row.Scan(
+		&command.ID,
+		&command.ESP32ID,
+		&commandType,
+		&command.DurationSeconds,
+		&issuedBy,
+		&status,
+		&result,
+		&command.CreatedAt,
+		&command.ExpiresAt,
+		&deliveredAt,
+		&completedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	command.Type = entities.CommandType(commandType)
+	command.Status = entities.CommandStatus(status)
+	command.Result = result.String
+	if issuedBy.Valid {
+		issuedByInt := int(issuedBy.Int64)
+		command.IssuedBy = &issuedByInt
+	}
+	if deliveredAt.Valid {
+		command.DeliveredAt = &deliveredAt.Time
+	}
+	if completedAt.Valid {
+		command.CompletedAt = &completedAt.Time
+	}
+
+	// Los comandos no entregados y vencidos se reportan como tales aunque la limpieza periódica todavía no los haya marcado
+	if command.Status == entities.CommandPending && !time.Now().Before(command.ExpiresAt) {
+		command.Status = entities.CommandExpired
+	}
+
+	return &command, nil
+}
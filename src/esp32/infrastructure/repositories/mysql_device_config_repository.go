@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// mysqlDuplicateEntry es el código de error de MySQL para claves duplicadas
+const mysqlDuplicateEntry = 1062
+
+// MySQLDeviceConfigRepository implementa DeviceConfigRepository usando MySQL
+type MySQLDeviceConfigRepository struct {
+	db *sql.DB
+}
+
+// NewMySQLDeviceConfigRepository crea una nueva instancia de MySQLDeviceConfigRepository
+func NewMySQLDeviceConfigRepository(db *sql.DB) repositories.DeviceConfigRepository {
+	return &MySQLDeviceConfigRepository{
+		db: db,
+	}
+}
+
+// FindByESP32ID busca la configuración vigente de un ESP32
+func (r *MySQLDeviceConfigRepository) FindByESP32ID(ctx context.Context, esp32ID int) (*entities.DeviceConfig, error) {
+	query := `SELECT idESP32, version, settings, updated_at, updated_by, applied_version, applied_at
+              FROM esp32_configs WHERE idESP32 = ?`
+
+	var config entities.DeviceConfig
+	var settings []byte
+	var updatedAt, appliedAt sql.NullTime
+	var updatedBy, appliedVersion sql.NullInt64
+
+	err := r.db.QueryRowContext(ctx, query, esp32ID).Scan(&config.ESP32ID, &config.Version, &settings,
+		&updatedAt, &updatedBy, &appliedVersion, &appliedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // No error, just no config found
+		}
+		return nil, err
+	}
+
+	config.Settings = entities.DefaultDeviceSettings()
+	if err := json.Unmarshal(settings, &config.Settings); err != nil {
+		return nil, err
+	}
+	if updatedAt.Valid {
+		config.UpdatedAt = &updatedAt.Time
+	}
+	if updatedBy.Valid {
+		id := int(updatedBy.Int64)
+		config.UpdatedBy = &id
+	}
+	if appliedVersion.Valid {
+		version := int(appliedVersion.Int64)
+		config.AppliedVersion = &version
+	}
+	if appliedAt.Valid {
+		config.AppliedAt = &appliedAt.Time
+	}
+
+	return &config, nil
+}
+
+// Save guarda una nueva versión de la configuración con control de concurrencia optimista
+func (r *MySQLDeviceConfigRepository) Save(ctx context.Context, config *entities.DeviceConfig, previousVersion int) error {
+	settings, err := json.Marshal(config.Settings)
+	if err != nil {
+		return err
+	}
+
+	if previousVersion == 0 {
+		query := `INSERT INTO esp32_configs (idESP32, version, settings, updated_at, updated_by)
+                  VALUES (?, ?, ?, ?, ?)`
+
+		_, err := r.db.ExecContext(ctx, query, config.ESP32ID, config.Version, settings, config.UpdatedAt, config.UpdatedBy)
+		if mysqlErr, ok := err.(*mysql.MySQLError); ok && mysqlErr.Number == mysqlDuplicateEntry {
+			return repositories.ErrConfigVersionConflict
+		}
+		return err
+	}
+
+	query := `UPDATE esp32_configs SET version = ?, settings = ?, updated_at = ?, updated_by = ?
+              WHERE idESP32 = ? AND version = ?`
+
+	result, err := r.db.ExecContext(ctx, query, config.Version, settings, config.UpdatedAt, config.UpdatedBy,
+		config.ESP32ID, previousVersion)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return repositories.ErrConfigVersionConflict
+	}
+
+	return nil
+}
+
+// MarkApplied registra la versión que el ESP32 confirmó haber aplicado
+func (r *MySQLDeviceConfigRepository) MarkApplied(ctx context.Context, esp32ID, version int, appliedAt time.Time) error {
+	query := `UPDATE esp32_configs SET applied_version = ?, applied_at = ? WHERE idESP32 = ?`
+
+	_, err := r.db.ExecContext(ctx, query, version, appliedAt, esp32ID)
+	return err
+}
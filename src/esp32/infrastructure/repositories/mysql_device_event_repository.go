@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// MySQLDeviceEventRepository implementa DeviceEventRepository usando MySQL
+type MySQLDeviceEventRepository struct {
+	db *sql.DB
+}
+
+// NewMySQLDeviceEventRepository crea una nueva instancia de MySQLDeviceEventRepository
+func NewMySQLDeviceEventRepository(db *sql.DB) repositories.DeviceEventRepository {
+	return &MySQLDeviceEventRepository{
+		db: db,
+	}
+}
+
+// Create inserta un nuevo evento de conectividad
+func (r *MySQLDeviceEventRepository) Create(ctx context.Context, event *entities.DeviceEvent) (*entities.DeviceEvent, error) {
+	query := `INSERT INTO esp32_events (idESP32, event_type, created_at) VALUES (?, ?, ?)`
+
+	result, err := r.db.ExecContext(ctx, query, event.ESP32ID, string(event.Type), event.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	event.ID = int(id)
+
+	return event, nil
+}
+
+// FindByESP32ID busca los eventos de conectividad de un ESP32, del más reciente al más antiguo
+func (r *MySQLDeviceEventRepository) FindByESP32ID(ctx context.Context, esp32ID int) ([]*entities.DeviceEvent, error) {
+	query := `SELECT idEvent, idESP32, event_type, created_at FROM esp32_events
+              WHERE idESP32 = ? ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*entities.DeviceEvent
+
+	for rows.Next() {
+		var event entities.DeviceEvent
+		var eventType string
+
+		if err := rows.Scan(&event.ID, &event.ESP32ID, &eventType, &event.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		event.Type = entities.DeviceEventType(eventType)
+		events = append(events, &event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
@@ -0,0 +1,223 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// groupColumns son las columnas que se leen en todas las consultas de grupos
+const groupColumns = `idGroup, parent_id, kind, name, owner_id, created_at`
+
+// MySQLDeviceGroupRepository implementa DeviceGroupRepository usando MySQL
+type MySQLDeviceGroupRepository struct {
+	db *sql.DB
+}
+
+// NewMySQLDeviceGroupRepository crea una nueva instancia de MySQLDeviceGroupRepository
+func NewMySQLDeviceGroupRepository(db *sql.DB) repositories.DeviceGroupRepository {
+	return &MySQLDeviceGroupRepository{
+		db: db,
+	}
+}
+
+// Create inserta un nuevo grupo
+func (r *MySQLDeviceGroupRepository) Create(ctx context.Context, group *entities.DeviceGroup) (*entities.DeviceGroup, error) {
+	query := `INSERT INTO device_groups (parent_id, kind, name, owner_id, created_at) VALUES (?, ?, ?, ?, ?)`
+
+	result, err := r.db.ExecContext(ctx, query, group.ParentID, string(group.Kind), group.Name, group.OwnerID, group.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	group.ID = int(id)
+
+	return group, nil
+}
+
+// FindByID busca un grupo por su ID
+func (r *MySQLDeviceGroupRepository) FindByID(ctx context.Context, id int) (*entities.DeviceGroup, error) {
+	query := `SELECT ` + groupColumns + ` FROM device_groups WHERE idGroup = ?`
+
+	group, err := scanGroup(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // No error, just no group found
+		}
+		return nil, err
+	}
+
+	return group, nil
+}
+
+// FindByParentID busca los subgrupos directos de un grupo
+func (r *MySQLDeviceGroupRepository) FindByParentID(ctx context.Context, parentID int) ([]*entities.DeviceGroup, error) {
+	query := `SELECT ` + groupColumns + ` FROM device_groups WHERE parent_id = ? ORDER BY name, idGroup`
+
+	return r.findMany(ctx, query, parentID)
+}
+
+// FindByOwnerID busca todos los grupos de un cliente
+func (r *MySQLDeviceGroupRepository) FindByOwnerID(ctx context.Context, ownerID int) ([]*entities.DeviceGroup, error) {
+	query := `SELECT ` + groupColumns + ` FROM device_groups WHERE owner_id = ? ORDER BY name, idGroup`
+
+	return r.findMany(ctx, query, ownerID)
+}
+
+// FindByIDs busca los grupos con los IDs indicados
+func (r *MySQLDeviceGroupRepository) FindByIDs(ctx context.Context, ids []int) ([]*entities.DeviceGroup, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	query := `SELECT ` + groupColumns + ` FROM device_groups
+              WHERE idGroup IN (?` + strings.Repeat(", ?", len(ids)-1) + `) ORDER BY name, idGroup`
+
+	return r.findMany(ctx, query, args...)
+}
+
+// Update actualiza el nombre de un grupo
+func (r *MySQLDeviceGroupRepository) Update(ctx context.Context, group *entities.DeviceGroup) error {
+	query := `UPDATE device_groups SET name = ? WHERE idGroup = ?`
+
+	_, err := r.db.ExecContext(ctx, query, group.Name, group.ID)
+	return err
+}
+
+// Delete elimina un grupo; sus ESP32 quedan sin grupo y sus miembros se eliminan
+func (r *MySQLDeviceGroupRepository) Delete(ctx context.Context, id int) error {
+	query := `DELETE FROM device_groups WHERE idGroup = ?`
+
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
+
+// SaveMember agrega un miembro al grupo o cambia su rol si ya lo era
+func (r *MySQLDeviceGroupRepository) SaveMember(ctx context.Context, member *entities.GroupMember) error {
+	query := `INSERT INTO device_group_members (idGroup, idUser, role, created_at) VALUES (?, ?, ?, ?)
+              ON DUPLICATE KEY UPDATE role = VALUES(role)`
+
+	_, err := r.db.ExecContext(ctx, query, member.GroupID, member.UserID, string(member.Role), member.CreatedAt)
+	return err
+}
+
+// DeleteMember quita a un usuario de los miembros de un grupo
+func (r *MySQLDeviceGroupRepository) DeleteMember(ctx context.Context, groupID, userID int) error {
+	query := `DELETE FROM device_group_members WHERE idGroup = ? AND idUser = ?`
+
+	_, err := r.db.ExecContext(ctx, query, groupID, userID)
+	return err
+}
+
+// FindMembers busca los miembros de un grupo junto con su email
+func (r *MySQLDeviceGroupRepository) FindMembers(ctx context.Context, groupID int) ([]*entities.GroupMember, error) {
+	query := `SELECT m.idGroup, m.idUser, u.email, m.role, m.created_at
+              FROM device_group_members m JOIN users u ON u.id = m.idUser
+              WHERE m.idGroup = ? ORDER BY u.email`
+
+	return r.findMembers(ctx, query, groupID)
+}
+
+// FindMembershipsByUserID busca los grupos de los que un usuario es miembro
+func (r *MySQLDeviceGroupRepository) FindMembershipsByUserID(ctx context.Context, userID int) ([]*entities.GroupMember, error) {
+	query := `SELECT m.idGroup, m.idUser, u.email, m.role, m.created_at
+              FROM device_group_members m JOIN users u ON u.id = m.idUser
+              WHERE m.idUser = ? ORDER BY m.idGroup`
+
+	return r.findMembers(ctx, query, userID)
+}
+
+// findMany ejecuta una consulta que devuelve una lista de grupos
+func (r *MySQLDeviceGroupRepository) findMany(ctx context.Context, query string, args ...interface{}) ([]*entities.DeviceGroup, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var groups []*entities.DeviceGroup
+
+	for rows.Next() {
+		group, err := scanGroup(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		groups = append(groups, group)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+// findMembers ejecuta una consulta que devuelve una lista de miembros de grupos
+func (r *MySQLDeviceGroupRepository) findMembers(ctx context.Context, query string, args ...interface{}) ([]*entities.GroupMember, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []*entities.GroupMember
+
+	for rows.Next() {
+		var member entities.GroupMember
+		var role string
+
+		if err := rows.Scan(&member.GroupID, &member.UserID, &member.Email, &role, &member.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		member.Role = entities.GroupRole(role)
+		members = append(members, &member)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// scanGroup convierte una fila con las columnas de groupColumns en una entidad DeviceGroup
+func scanGroup(row rowScanner) (*entities.DeviceGroup, error) {
+	var group entities.DeviceGroup
+	var parentID sql.NullInt64
+	var kind string
+
+	err := row.Scan(
+		&group.ID,
+		&parentID,
+		&kind,
+		&group.Name,
+		&group.OwnerID,
+		&group.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	group.Kind = entities.GroupKind(kind)
+	if parentID.Valid {
+		parentIDInt := int(parentID.Int64)
+		group.ParentID = &parentIDInt
+	}
+
+	return &group, nil
+}
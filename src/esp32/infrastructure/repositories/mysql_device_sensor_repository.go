@@ -0,0 +1,268 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// deviceSensorColumns son las columnas que se leen en todas las consultas de sensores instalados
+const deviceSensorColumns = `idSensor, idESP32, sensor_type, numero_serie, label, alarm, fecha_activacion, installed_at, retired_at`
+
+// MySQLDeviceSensorRepository implementa DeviceSensorRepository usando MySQL
+type MySQLDeviceSensorRepository struct {
+	db *sql.DB
+}
+
+// NewMySQLDeviceSensorRepository crea una nueva instancia de MySQLDeviceSensorRepository
+func NewMySQLDeviceSensorRepository(db *sql.DB) repositories.DeviceSensorRepository {
+	return &MySQLDeviceSensorRepository{
+		db: db,
+	}
+}
+
+// Create instala un sensor en un ESP32
+func (r *MySQLDeviceSensorRepository) Create(ctx context.Context, sensor *entities.DeviceSensor) (*entities.DeviceSensor, error) {
+	if err := insertDeviceSensor(ctx, r.db, sensor); err != nil {
+		return nil, err
+	}
+
+	return sensor, nil
+}
+
+// FindByID busca un sensor por su ID, incluso si fue retirado
+func (r *MySQLDeviceSensorRepository) FindByID(ctx context.Context, id int) (*entities.DeviceSensor, error) {
+	query := `SELECT ` + deviceSensorColumns + ` FROM device_sensors WHERE idSensor = ?`
+
+	sensor, err := scanDeviceSensor(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // No error, just no sensor found
+		}
+		return nil, err
+	}
+
+	return sensor, nil
+}
+
+// FindByESP32ID busca los sensores instalados en un ESP32 en el orden en que se instalaron
+func (r *MySQLDeviceSensorRepository) FindByESP32ID(ctx context.Context, esp32ID int) ([]*entities.DeviceSensor, error) {
+	query := `SELECT ` + deviceSensorColumns + ` FROM device_sensors
+              WHERE idESP32 = ? AND retired_at IS NULL ORDER BY idSensor`
+
+	return r.findMany(ctx, query, esp32ID)
+}
+
+// FindByESP32IDs busca los sensores instalados en cualquiera de los ESP32 indicados
+func (r *MySQLDeviceSensorRepository) FindByESP32IDs(ctx context.Context, esp32IDs []int) ([]*entities.DeviceSensor, error) {
+	if len(esp32IDs) == 0 {
+		return nil, nil
+	}
+
+	args := make([]interface{}, len(esp32IDs))
+	for i, esp32ID := range esp32IDs {
+		args[i] = esp32ID
+	}
+
+	query := `SELECT ` + deviceSensorColumns + ` FROM device_sensors
+              WHERE idESP32 IN (?` + strings.Repeat(", ?", len(esp32IDs)-1) + `) AND retired_at IS NULL
+              ORDER BY idESP32, idSensor`
+
+	return r.findMany(ctx, query, args...)
+}
+
+// findMany ejecuta una consulta que devuelve una lista de sensores instalados
+func (r *MySQLDeviceSensorRepository) findMany(ctx context.Context, query string, args ...interface{}) ([]*entities.DeviceSensor, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sensors []*entities.DeviceSensor
+
+	for rows.Next() {
+		sensor, err := scanDeviceSensor(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		sensors = append(sensors, sensor)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sensors, nil
+}
+
+// Delete retira un sensor de su ESP32
+func (r *MySQLDeviceSensorRepository) Delete(ctx context.Context, id int) error {
+	query := `DELETE FROM device_sensors WHERE idSensor = ?`
+
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
+
+// Replace reemplaza un sensor en una transacción. La condición sobre retired_at evita que dos
+// reemplazos simultáneos del mismo sensor instalen dos sensores nuevos.
+func (r *MySQLDeviceSensorRepository) Replace(ctx context.Context, replacement *entities.SensorReplacement, newSensor *entities.DeviceSensor) (*entities.SensorReplacement, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `UPDATE device_sensors SET retired_at = ? WHERE idSensor = ? AND retired_at IS NULL`,
+		replacement.ReplacedAt, replacement.OldSensorID)
+	if err != nil {
+		return nil, err
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return nil, err
+	} else if affected == 0 {
+		return nil, repositories.ErrSensorRetired
+	}
+
+	if err := insertDeviceSensor(ctx, tx, newSensor); err != nil {
+		return nil, err
+	}
+	replacement.NewSensorID = newSensor.ID
+	replacement.NewNumeroSerie = newSensor.NumeroSerie
+
+	result, err = tx.ExecContext(ctx, `INSERT INTO sensor_replacements (idESP32, sensor_type, old_sensor, new_sensor,
+              reason, technician, replaced_by, replaced_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		replacement.ESP32ID, replacement.SensorType, replacement.OldSensorID, replacement.NewSensorID,
+		replacement.Reason, replacement.Technician, replacement.ReplacedBy, replacement.ReplacedAt)
+	if err != nil {
+		return nil, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	replacement.ID = int(id)
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return replacement, nil
+}
+
+// FindReplacementsByESP32ID busca los reemplazos de sensores de un ESP32 junto con los números de serie de ambos sensores
+func (r *MySQLDeviceSensorRepository) FindReplacementsByESP32ID(ctx context.Context, esp32ID int) ([]*entities.SensorReplacement, error) {
+	query := `SELECT rp.idReplacement, rp.idESP32, rp.sensor_type, rp.old_sensor, rp.new_sensor,
+              o.numero_serie, n.numero_serie, rp.reason, rp.technician, rp.replaced_by, rp.replaced_at
+              FROM sensor_replacements rp
+              LEFT JOIN device_sensors o ON o.idSensor = rp.old_sensor
+              LEFT JOIN device_sensors n ON n.idSensor = rp.new_sensor
+              WHERE rp.idESP32 = ? ORDER BY rp.replaced_at DESC, rp.idReplacement DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var replacements []*entities.SensorReplacement
+
+	for rows.Next() {
+		var replacement entities.SensorReplacement
+		var oldNumeroSerie, newNumeroSerie sql.NullString
+		var replacedBy sql.NullInt64
+
+		err := rows.Scan(
+			&replacement.ID,
+			&replacement.ESP32ID,
+			&replacement.SensorType,
+			&replacement.OldSensorID,
+			&replacement.NewSensorID,
+			&oldNumeroSerie,
+			&newNumeroSerie,
+			&replacement.Reason,
+			&replacement.Technician,
+			&replacedBy,
+			&replacement.ReplacedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		replacement.OldNumeroSerie = oldNumeroSerie.String
+		replacement.NewNumeroSerie = newNumeroSerie.String
+		if replacedBy.Valid {
+			userID := int(replacedBy.Int64)
+			replacement.ReplacedBy = &userID
+		}
+
+		replacements = append(replacements, &replacement)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return replacements, nil
+}
+
+// insertDeviceSensor inserta un sensor instalado con la conexión o transacción indicada
+func insertDeviceSensor(ctx context.Context, db execer, sensor *entities.DeviceSensor) error {
+	query := `INSERT INTO device_sensors (idESP32, sensor_type, numero_serie, label, alarm, fecha_activacion, installed_at)
+              VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	result, err := db.ExecContext(ctx, query, sensor.ESP32ID, sensor.SensorType,
+		nullableString(sensor.NumeroSerie), nullableString(sensor.Label),
+		sensor.Alarm, sensor.FechaActivacion, sensor.InstalledAt)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	sensor.ID = int(id)
+
+	return nil
+}
+
+// scanDeviceSensor convierte una fila con las columnas de deviceSensorColumns en una entidad DeviceSensor
+func scanDeviceSensor(row rowScanner) (*entities.DeviceSensor, error) {
+	var sensor entities.DeviceSensor
+	var numeroSerie sql.NullString
+	var label sql.NullString
+	var fechaActivacion sql.NullTime
+	var retiredAt sql.NullTime
+
+	err := row.Scan(
+		&sensor.ID,
+		&sensor.ESP32ID,
+		&sensor.SensorType,
+		&numeroSerie,
+		&label,
+		&sensor.Alarm,
+		&fechaActivacion,
+		&sensor.InstalledAt,
+		&retiredAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sensor.NumeroSerie = numeroSerie.String
+	sensor.Label = label.String
+	if fechaActivacion.Valid {
+		sensor.FechaActivacion = &fechaActivacion.Time
+	}
+	if retiredAt.Valid {
+		sensor.RetiredAt = &retiredAt.Time
+	}
+
+	return &sensor, nil
+}
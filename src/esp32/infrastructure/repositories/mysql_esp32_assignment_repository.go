@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// MySQLESP32AssignmentRepository implementa ESP32AssignmentRepository usando MySQL
+type MySQLESP32AssignmentRepository struct {
+	db *sql.DB
+}
+
+// NewMySQLESP32AssignmentRepository crea una nueva instancia de MySQLESP32AssignmentRepository
+func NewMySQLESP32AssignmentRepository(db *sql.DB) repositories.ESP32AssignmentRepository {
+	return &MySQLESP32AssignmentRepository{
+		db: db,
+	}
+}
+
+// FindByESP32ID busca el historial de dueños de un ESP32, de la asignación más reciente a la más antigua
+func (r *MySQLESP32AssignmentRepository) FindByESP32ID(ctx context.Context, esp32ID int) ([]*entities.ESP32Assignment, error) {
+	query := `SELECT a.idAssignment, a.idESP32, a.idUser, u.email, a.assigned_at, a.unassigned_at,
+              a.assign_reason, a.unassign_reason
+              FROM esp32_assignments a
+              LEFT JOIN users u ON a.idUser = u.id
+              WHERE a.idESP32 = ? ORDER BY a.assigned_at DESC, a.idAssignment DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var assignments []*entities.ESP32Assignment
+
+	for rows.Next() {
+		var assignment entities.ESP32Assignment
+		var userID sql.NullInt64
+		var email sql.NullString
+		var unassignedAt sql.NullTime
+		var assignReason string
+		var unassignReason sql.NullString
+
+		err := rows.Scan(
+			&assignment.ID,
+			&assignment.ESP32ID,
+			&userID,
+			&email,
+			&assignment.AssignedAt,
+			&unassignedAt,
+			&assignReason,
+			&unassignReason,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if userID.Valid {
+			userIDInt := int(userID.Int64)
+			assignment.UserID = &userIDInt
+		}
+		if unassignedAt.Valid {
+			assignment.UnassignedAt = &unassignedAt.Time
+		}
+		assignment.Email = email.String
+		assignment.AssignReason = entities.AssignmentReason(assignReason)
+		assignment.UnassignReason = entities.AssignmentReason(unassignReason.String)
+
+		assignments = append(assignments, &assignment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return assignments, nil
+}
+
+// openAssignment registra el comienzo de una asignación. Antes cierra cualquier asignación
+// que hubiera quedado abierta, para que un ESP32 nunca tenga dos dueños vigentes en el historial.
+func openAssignment(ctx context.Context, db execer, esp32ID, userID int, reason entities.AssignmentReason, at time.Time) error {
+	if err := closeAssignment(ctx, db, esp32ID, reason, at); err != nil {
+		return err
+	}
+
+	query := `INSERT INTO esp32_assignments (idESP32, idUser, assigned_at, assign_reason) VALUES (?, ?, ?, ?)`
+
+	_, err := db.ExecContext(ctx, query, esp32ID, userID, at, string(reason))
+	return err
+}
+
+// closeAssignment registra el final de la asignación vigente de un ESP32, si la hay
+func closeAssignment(ctx context.Context, db execer, esp32ID int, reason entities.AssignmentReason, at time.Time) error {
+	query := `UPDATE esp32_assignments SET unassigned_at = ?, unassign_reason = ?
+              WHERE idESP32 = ? AND unassigned_at IS NULL`
+
+	_, err := db.ExecContext(ctx, query, at, string(reason), esp32ID)
+	return err
+}
@@ -3,13 +3,20 @@ package repositories
 import (
 	"context"
 	"database/sql"
-	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"hex_go/src/esp32/domain/entities"
 	"hex_go/src/esp32/domain/repositories"
 )
 
+// esp32Columns son las columnas que se leen en todas las consultas de ESP32, en el orden de scanESP32
+const esp32Columns = `idESP32, numero_serie, idUser,
+              claim_code_hash, device_token_hash, last_seen_at, online,
+              nickname, room, address, latitude, longitude, assigned_at, idGroup,
+              created_at, decommissioned_at, hardware_revision, purged_at`
+
 // MySQLESP32Repository implementa ESP32Repository usando MySQL
 type MySQLESP32Repository struct {
 	db *sql.DB
@@ -24,311 +31,542 @@ func NewMySQLESP32Repository(db *sql.DB) repositories.ESP32Repository {
 
 // Create inserta un nuevo ESP32 en la base de datos
 func (r *MySQLESP32Repository) Create(ctx context.Context, esp32 *entities.ESP32) (*entities.ESP32, error) {
-	query := `INSERT INTO esp32 (idKY_026, idMQ_2, idMQ_135, idDHT_22, numero_serie, idUser) 
+	query := `INSERT INTO esp32 (numero_serie, idUser, claim_code_hash, device_token_hash, created_at, hardware_revision) 
               VALUES (?, ?, ?, ?, ?, ?)`
-	
+
+	if esp32.CreatedAt.IsZero() {
+		esp32.CreatedAt = time.Now()
+	}
+
 	var userID interface{}
 	if esp32.UserID != nil {
 		userID = *esp32.UserID
 	} else {
 		userID = nil
 	}
-	
-	result, err := r.db.ExecContext(ctx, query, 
-		esp32.IDKY026, esp32.IDMQ2, esp32.IDMQ135, esp32.IDDHT22, esp32.NumeroSerie, userID)
+
+	result, err := r.db.ExecContext(ctx, query,
+		esp32.NumeroSerie, userID,
+		nullableString(esp32.ClaimCodeHash), nullableString(esp32.DeviceTokenHash), esp32.CreatedAt,
+		nullableString(esp32.HardwareRevision))
 	if err != nil {
 		return nil, err
 	}
-	
+
 	id, err := result.LastInsertId()
 	if err != nil {
 		return nil, err
 	}
-	
+
 	esp32.ID = int(id)
-	
+
+	return esp32, nil
+}
+
+// CreateWithSensors crea el ESP32 y los sensores de esp32.Sensors dentro de una misma transacción
+func (r *MySQLESP32Repository) CreateWithSensors(ctx context.Context, esp32 *entities.ESP32) (*entities.ESP32, error) {
+	if err := r.CreateManyWithSensors(ctx, []*entities.ESP32{esp32}); err != nil {
+		return nil, err
+	}
+
 	return esp32, nil
 }
 
+// CreateManyWithSensors crea varios ESP32 con sus sensores en una sola transacción:
+// si alguno falla no se crea ninguno
+func (r *MySQLESP32Repository) CreateManyWithSensors(ctx context.Context, esp32s []*entities.ESP32) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, esp32 := range esp32s {
+		if err := insertWithSensors(ctx, tx, esp32); err != nil {
+			return fmt.Errorf("failed to create ESP32 %s: %w", esp32.NumeroSerie, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// insertWithSensors inserta un ESP32 y luego sus sensores dentro de la transacción indicada
+func insertWithSensors(ctx context.Context, tx *sql.Tx, esp32 *entities.ESP32) error {
+	query := `INSERT INTO esp32 (numero_serie, idUser, claim_code_hash, device_token_hash, created_at, hardware_revision) 
+              VALUES (?, NULL, ?, ?, ?, ?)`
+
+	if esp32.CreatedAt.IsZero() {
+		esp32.CreatedAt = time.Now()
+	}
+
+	result, err := tx.ExecContext(ctx, query, esp32.NumeroSerie,
+		nullableString(esp32.ClaimCodeHash), nullableString(esp32.DeviceTokenHash), esp32.CreatedAt,
+		nullableString(esp32.HardwareRevision))
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	esp32.ID = int(id)
+	esp32.UserID = nil
+
+	for _, sensor := range esp32.Sensors {
+		sensor.ESP32ID = esp32.ID
+		if err := insertDeviceSensor(ctx, tx, sensor); err != nil {
+			return fmt.Errorf("failed to create %s sensor: %w", sensor.SensorType, err)
+		}
+	}
+
+	return nil
+}
+
 // FindByID busca un ESP32 por su ID
 func (r *MySQLESP32Repository) FindByID(ctx context.Context, id int) (*entities.ESP32, error) {
-	query := `SELECT idESP32, idKY_026, idMQ_2, idMQ_135, idDHT_22, numero_serie, idUser
-              FROM esp32 WHERE idESP32 = ?`
-	
-	var esp32 entities.ESP32
-	var userID sql.NullInt64
-	var idKY026 sql.NullInt64
-	var idMQ2 sql.NullInt64
-	var idMQ135 sql.NullInt64
-	var idDHT22 sql.NullInt64
-	
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&esp32.ID,
-		&idKY026,
-		&idMQ2,
-		&idMQ135,
-		&idDHT22,
-		&esp32.NumeroSerie,
-		&userID,
-	)
-	
+	query := `SELECT ` + esp32Columns + ` FROM esp32 WHERE idESP32 = ?`
+
+	return r.findOne(ctx, query, id)
+}
+
+// FindByUserID busca todos los ESP32 asignados a un usuario
+func (r *MySQLESP32Repository) FindByUserID(ctx context.Context, userID int) ([]*entities.ESP32, error) {
+	query := `SELECT ` + esp32Columns + ` FROM esp32 WHERE idUser = ? AND decommissioned_at IS NULL`
+
+	return r.findMany(ctx, query, userID)
+}
+
+// FindByGroupIDs busca los ESP32 que pertenecen a cualquiera de los grupos indicados
+func (r *MySQLESP32Repository) FindByGroupIDs(ctx context.Context, groupIDs []int) ([]*entities.ESP32, error) {
+	if len(groupIDs) == 0 {
+		return nil, nil
+	}
+
+	args := make([]interface{}, len(groupIDs))
+	for i, groupID := range groupIDs {
+		args[i] = groupID
+	}
+
+	query := `SELECT ` + esp32Columns + ` FROM esp32 WHERE idGroup IN (?` + strings.Repeat(", ?", len(groupIDs)-1) + `)
+              AND decommissioned_at IS NULL ORDER BY idESP32`
+
+	return r.findMany(ctx, query, args...)
+}
+
+// FindUnassigned busca todos los ESP32 en servicio no asignados a ningún usuario
+func (r *MySQLESP32Repository) FindUnassigned(ctx context.Context) ([]*entities.ESP32, error) {
+	query := `SELECT ` + esp32Columns + ` FROM esp32 WHERE idUser IS NULL AND decommissioned_at IS NULL`
+
+	return r.findMany(ctx, query)
+}
+
+// FindDecommissioned busca los ESP32 retirados de servicio que todavía no se eliminaron
+func (r *MySQLESP32Repository) FindDecommissioned(ctx context.Context) ([]*entities.ESP32, error) {
+	query := `SELECT ` + esp32Columns + ` FROM esp32 WHERE decommissioned_at IS NOT NULL
+              ORDER BY decommissioned_at DESC`
+
+	return r.findMany(ctx, query)
+}
+
+// FindStaleOnline busca los ESP32 marcados como en línea cuyo último heartbeat es anterior a cutoff
+func (r *MySQLESP32Repository) FindStaleOnline(ctx context.Context, cutoff time.Time) ([]*entities.ESP32, error) {
+	query := `SELECT ` + esp32Columns + ` FROM esp32
+              WHERE online = 1 AND last_seen_at < ? AND decommissioned_at IS NULL`
+
+	return r.findMany(ctx, query, cutoff)
+}
+
+// Update actualiza un ESP32 existente
+func (r *MySQLESP32Repository) Update(ctx context.Context, esp32 *entities.ESP32) error {
+	query := `UPDATE esp32 SET numero_serie = ?, idUser = ?, claim_code_hash = ?, device_token_hash = ?,
+              hardware_revision = ? WHERE idESP32 = ?`
+
+	var userID interface{}
+	if esp32.UserID != nil {
+		userID = *esp32.UserID
+	} else {
+		userID = nil
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		esp32.NumeroSerie, userID,
+		nullableString(esp32.ClaimCodeHash), nullableString(esp32.DeviceTokenHash),
+		nullableString(esp32.HardwareRevision), esp32.ID)
+	return err
+}
+
+// UpdateMetadata actualiza los datos descriptivos que edita el dueño del ESP32
+func (r *MySQLESP32Repository) UpdateMetadata(ctx context.Context, esp32 *entities.ESP32) error {
+	query := `UPDATE esp32 SET nickname = ?, room = ?, address = ?, latitude = ?, longitude = ?
+              WHERE idESP32 = ?`
+
+	_, err := r.db.ExecContext(ctx, query,
+		nullableString(esp32.Nickname), nullableString(esp32.Room), nullableString(esp32.Address),
+		nullableFloat(esp32.Latitude), nullableFloat(esp32.Longitude), esp32.ID)
+	return err
+}
+
+// SetGroup mueve un ESP32 a un grupo, o lo saca de su grupo si groupID es nulo. La condición
+// sobre el dueño evita que una transferencia aceptada entre la verificación y la actualización
+// deje el ESP32 del nuevo dueño dentro de la jerarquía del anterior.
+func (r *MySQLESP32Repository) SetGroup(ctx context.Context, esp32ID, ownerID int, groupID *int) error {
+	query := `UPDATE esp32 SET idGroup = ? WHERE idESP32 = ? AND idUser = ?`
+
+	result, err := r.db.ExecContext(ctx, query, groupID, esp32ID, ownerID)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, errors.New("ESP32 not found")
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repositories.ErrGroupOwnerConflict
+	}
+	return nil
+}
+
+// Decommission marca un ESP32 como retirado e invalida sus credenciales, para que no se pueda
+// reclamar ni autenticar. La fila se conserva para que las alertas y el historial sigan resolviendo.
+func (r *MySQLESP32Repository) Decommission(ctx context.Context, id int, at time.Time) (bool, error) {
+	query := `UPDATE esp32 SET decommissioned_at = ?, online = 0, claim_code_hash = NULL,
+              device_token_hash = NULL, idGroup = NULL
+              WHERE idESP32 = ? AND idUser IS NULL AND decommissioned_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, at, id)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// purgedTables son las tablas con los datos operativos de un ESP32 que se eliminan al purgarlo.
+// Las alertas, las asignaciones y transferencias, los sensores y sus reemplazos, los comisionamientos,
+// el mantenimiento y los reportes de firmware se conservan para el historial.
+var purgedTables = []string{
+	"sensor_readings",
+	"sensor_alarm_episodes",
+	"esp32_events",
+	"esp32_commands",
+	"esp32_configs",
+	"firmware_rollout_devices",
+}
+
+// PurgeDecommissioned purga los ESP32 retirados antes de cutoff que no se habían purgado. La fila
+// del ESP32 se conserva, porque las claves foráneas eliminarían en cascada su historial, pero se
+// borran sus datos operativos y su ubicación, y se registra el momento de la purga.
+func (r *MySQLESP32Repository) PurgeDecommissioned(ctx context.Context, cutoff time.Time) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	const pending = `e.decommissioned_at IS NOT NULL AND e.decommissioned_at < ? AND e.purged_at IS NULL`
+
+	for _, table := range purgedTables {
+		query := `DELETE t FROM ` + table + ` t JOIN esp32 e ON e.idESP32 = t.idESP32 WHERE ` + pending
+		if _, err := tx.ExecContext(ctx, query, cutoff); err != nil {
+			return 0, err
 		}
-		return nil, err
 	}
-	
-	// Convert nullable fields to int
-	if idKY026.Valid {
-		esp32.IDKY026 = int(idKY026.Int64)
+
+	query := `UPDATE esp32 e SET e.purged_at = ?, e.nickname = NULL, e.room = NULL, e.address = NULL,
+              e.latitude = NULL, e.longitude = NULL, e.last_seen_at = NULL
+              WHERE ` + pending
+	result, err := tx.ExecContext(ctx, query, time.Now(), cutoff)
+	if err != nil {
+		return 0, err
+	}
+	purged, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return purged, tx.Commit()
+}
+
+// AssignToUser asigna un ESP32 a un usuario, invalida su código de reclamo y registra la
+// asignación en el historial. Los grupos pertenecen al dueño anterior, por eso el ESP32 sale de su grupo.
+// Solo asigna ESP32 sin dueño, en servicio y con código vigente, así de dos reclamos simultáneos gana uno.
+func (r *MySQLESP32Repository) AssignToUser(ctx context.Context, esp32ID, userID int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+
+	query := `UPDATE esp32 SET idUser = ?, assigned_at = ?, claim_code_hash = NULL, idGroup = NULL
+		WHERE idESP32 = ? AND idUser IS NULL AND decommissioned_at IS NULL AND claim_code_hash IS NOT NULL`
+	result, err := tx.ExecContext(ctx, query, userID, now, esp32ID)
+	if err != nil {
+		return err
 	}
-	if idMQ2.Valid {
-		esp32.IDMQ2 = int(idMQ2.Int64)
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
 	}
-	if idMQ135.Valid {
-		esp32.IDMQ135 = int(idMQ135.Int64)
+	if affected != 1 {
+		return repositories.ErrClaimConflict
 	}
-	if idDHT22.Valid {
-		esp32.IDDHT22 = int(idDHT22.Int64)
+
+	if err := openAssignment(ctx, tx, esp32ID, userID, entities.AssignmentClaim, now); err != nil {
+		return err
 	}
-	
-	if userID.Valid {
-		userIDInt := int(userID.Int64)
-		esp32.UserID = &userIDInt
+
+	return tx.Commit()
+}
+
+// UnassignFromUser desasigna un ESP32 de cualquier usuario y de su grupo, guarda su nuevo código
+// de reclamo y cierra la asignación vigente en el historial
+func (r *MySQLESP32Repository) UnassignFromUser(ctx context.Context, esp32ID int, claimCodeHash string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
 	}
-	
-	// Set current time as created_at since it's not in the database
-	esp32.CreatedAt = time.Now()
-	
-	return &esp32, nil
+	defer tx.Rollback()
+
+	query := `UPDATE esp32 SET idUser = NULL, assigned_at = NULL, claim_code_hash = ?, idGroup = NULL WHERE idESP32 = ?`
+	if _, err := tx.ExecContext(ctx, query, claimCodeHash, esp32ID); err != nil {
+		return err
+	}
+
+	if err := closeAssignment(ctx, tx, esp32ID, entities.AssignmentUnassign, time.Now()); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
-// FindByUserID busca todos los ESP32 asignados a un usuario
-func (r *MySQLESP32Repository) FindByUserID(ctx context.Context, userID int) ([]*entities.ESP32, error) {
-	query := `SELECT idESP32, idKY_026, idMQ_2, idMQ_135, idDHT_22, numero_serie, idUser
-              FROM esp32 WHERE idUser = ?`
-	
-	rows, err := r.db.QueryContext(ctx, query, userID)
+// UpdateHeartbeat registra un heartbeat del ESP32 y lo marca en línea.
+// Devuelve true solo si este heartbeat fue el que pasó el ESP32 de desconectado a en línea,
+// de modo que entre heartbeats concurrentes uno solo registre la reconexión.
+func (r *MySQLESP32Repository) UpdateHeartbeat(ctx context.Context, esp32ID int, seenAt time.Time) (bool, error) {
+	query := `UPDATE esp32 SET last_seen_at = ?, online = 1 WHERE idESP32 = ? AND online = 0`
+
+	result, err := r.db.ExecContext(ctx, query, seenAt, esp32ID)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if affected == 1 {
+		return true, nil
+	}
+
+	// Ya estaba en línea: solo se actualiza la hora del último heartbeat
+	query = `UPDATE esp32 SET last_seen_at = ? WHERE idESP32 = ?`
+	_, err = r.db.ExecContext(ctx, query, seenAt, esp32ID)
+	return false, err
+}
+
+// MarkOffline marca un ESP32 como desconectado si no envió heartbeats desde cutoff.
+// Devuelve false si otro heartbeat llegó mientras tanto y el ESP32 sigue en línea.
+func (r *MySQLESP32Repository) MarkOffline(ctx context.Context, esp32ID int, cutoff time.Time) (bool, error) {
+	query := `UPDATE esp32 SET online = 0 WHERE idESP32 = ? AND online = 1 AND last_seen_at < ?`
+
+	result, err := r.db.ExecContext(ctx, query, esp32ID, cutoff)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// FindByNumeroSerie busca un ESP32 por su número de serie
+func (r *MySQLESP32Repository) FindByNumeroSerie(ctx context.Context, numeroSerie string) (*entities.ESP32, error) {
+	query := `SELECT ` + esp32Columns + ` FROM esp32 WHERE numero_serie = ?`
+
+	return r.findOne(ctx, query, numeroSerie)
+}
+
+// FindExistingNumerosSerie devuelve cuáles de los números de serie indicados ya están registrados
+func (r *MySQLESP32Repository) FindExistingNumerosSerie(ctx context.Context, numerosSerie []string) (map[string]bool, error) {
+	existing := make(map[string]bool)
+	if len(numerosSerie) == 0 {
+		return existing, nil
+	}
+
+	args := make([]interface{}, len(numerosSerie))
+	for i, numeroSerie := range numerosSerie {
+		args[i] = numeroSerie
+	}
+
+	query := `SELECT numero_serie FROM esp32 WHERE numero_serie IN (?` + strings.Repeat(", ?", len(numerosSerie)-1) + `)`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	
-	var esp32s []*entities.ESP32
-	
+
 	for rows.Next() {
-		var esp32 entities.ESP32
-		var userIDSQL sql.NullInt64
-		var idKY026 sql.NullInt64
-		var idMQ2 sql.NullInt64
-		var idMQ135 sql.NullInt64
-		var idDHT22 sql.NullInt64
-		
-		err := rows.Scan(
-			&esp32.ID,
-			&idKY026,
-			&idMQ2,
-			&idMQ135,
-			&idDHT22,
-			&esp32.NumeroSerie,
-			&userIDSQL,
-		)
-		
-		if err != nil {
+		var numeroSerie string
+		if err := rows.Scan(&numeroSerie); err != nil {
 			return nil, err
 		}
-		
-		// Convert nullable fields to int
-		if idKY026.Valid {
-			esp32.IDKY026 = int(idKY026.Int64)
-		}
-		if idMQ2.Valid {
-			esp32.IDMQ2 = int(idMQ2.Int64)
-		}
-		if idMQ135.Valid {
-			esp32.IDMQ135 = int(idMQ135.Int64)
-		}
-		if idDHT22.Valid {
-			esp32.IDDHT22 = int(idDHT22.Int64)
-		}
-		
-		if userIDSQL.Valid {
-			userIDInt := int(userIDSQL.Int64)
-			esp32.UserID = &userIDInt
-		}
-		
-		// Set current time as created_at since it's not in the database
-		esp32.CreatedAt = time.Now()
-		
-		esp32s = append(esp32s, &esp32)
+		existing[numeroSerie] = true
 	}
-	
+
 	if err = rows.Err(); err != nil {
 		return nil, err
 	}
-	
-	return esp32s, nil
+
+	return existing, nil
 }
 
-// FindUnassigned busca todos los ESP32 no asignados a ningún usuario
-func (r *MySQLESP32Repository) FindUnassigned(ctx context.Context) ([]*entities.ESP32, error) {
-	query := `SELECT idESP32, idKY_026, idMQ_2, idMQ_135, idDHT_22, numero_serie, idUser
-              FROM esp32 WHERE idUser IS NULL`
-	
-	rows, err := r.db.QueryContext(ctx, query)
+// findOne ejecuta una consulta que devuelve como máximo un ESP32
+func (r *MySQLESP32Repository) findOne(ctx context.Context, query string, args ...interface{}) (*entities.ESP32, error) {
+	esp32, err := scanESP32(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // No error, just no ESP32 found
+		}
+		return nil, err
+	}
+
+	return esp32, nil
+}
+
+// findMany ejecuta una consulta que devuelve una lista de ESP32
+func (r *MySQLESP32Repository) findMany(ctx context.Context, query string, args ...interface{}) ([]*entities.ESP32, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	
+
 	var esp32s []*entities.ESP32
-	
+
 	for rows.Next() {
-		var esp32 entities.ESP32
-		var userIDSQL sql.NullInt64
-		var idKY026 sql.NullInt64
-		var idMQ2 sql.NullInt64
-		var idMQ135 sql.NullInt64
-		var idDHT22 sql.NullInt64
-		
-		err := rows.Scan(
-			&esp32.ID,
-			&idKY026,
-			&idMQ2,
-			&idMQ135,
-			&idDHT22,
-			&esp32.NumeroSerie,
-			&userIDSQL,
-		)
-		
+		esp32, err := scanESP32(rows)
 		if err != nil {
 			return nil, err
 		}
-		
-		// Convert nullable fields to int
-		if idKY026.Valid {
-			esp32.IDKY026 = int(idKY026.Int64)
-		}
-		if idMQ2.Valid {
-			esp32.IDMQ2 = int(idMQ2.Int64)
-		}
-		if idMQ135.Valid {
-			esp32.IDMQ135 = int(idMQ135.Int64)
-		}
-		if idDHT22.Valid {
-			esp32.IDDHT22 = int(idDHT22.Int64)
-		}
-		
-		if userIDSQL.Valid {
-			userIDInt := int(userIDSQL.Int64)
-			esp32.UserID = &userIDInt
-		}
-		
-		// Set current time as created_at since it's not in the database
-		esp32.CreatedAt = time.Now()
-		
-		esp32s = append(esp32s, &esp32)
+
+		esp32s = append(esp32s, esp32)
 	}
-	
+
 	if err = rows.Err(); err != nil {
 		return nil, err
 	}
-	
-	return esp32s, nil
-}
-
-// Update actualiza un ESP32 existente
-func (r *MySQLESP32Repository) Update(ctx context.Context, esp32 *entities.ESP32) error {
-	query := `UPDATE esp32 SET idKY_026 = ?, idMQ_2 = ?, idMQ_135 = ?, idDHT_22 = ?, 
-              numero_serie = ?, idUser = ? WHERE idESP32 = ?`
-	
-	var userID interface{}
-	if esp32.UserID != nil {
-		userID = *esp32.UserID
-	} else {
-		userID = nil
-	}
-	
-	_, err := r.db.ExecContext(ctx, query, 
-		esp32.IDKY026, esp32.IDMQ2, esp32.IDMQ135, esp32.IDDHT22, esp32.NumeroSerie, userID, esp32.ID)
-	return err
-}
 
-// Delete elimina un ESP32 por su ID
-func (r *MySQLESP32Repository) Delete(ctx context.Context, id int) error {
-	query := `DELETE FROM esp32 WHERE idESP32 = ?`
-	
-	_, err := r.db.ExecContext(ctx, query, id)
-	return err
-}
-
-// AssignToUser asigna un ESP32 a un usuario
-func (r *MySQLESP32Repository) AssignToUser(ctx context.Context, esp32ID, userID int) error {
-	query := `UPDATE esp32 SET idUser = ? WHERE idESP32 = ?`
-	
-	_, err := r.db.ExecContext(ctx, query, userID, esp32ID)
-	return err
+	return esp32s, nil
 }
 
-// UnassignFromUser desasigna un ESP32 de cualquier usuario
-func (r *MySQLESP32Repository) UnassignFromUser(ctx context.Context, esp32ID int) error {
-	query := `UPDATE esp32 SET idUser = NULL WHERE idESP32 = ?`
-	
-	_, err := r.db.ExecContext(ctx, query, esp32ID)
-	return err
+// rowScanner permite escanear tanto *sql.Row como *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
 }
 
-// FindByNumeroSerie busca un ESP32 por su número de serie
-func (r *MySQLESP32Repository) FindByNumeroSerie(ctx context.Context, numeroSerie string) (*entities.ESP32, error) {
-	query := `SELECT idESP32, idKY_026, idMQ_2, idMQ_135, idDHT_22, numero_serie, idUser 
-              FROM esp32 WHERE numero_serie = ?`
-	
+// scanESP32 convierte una fila con las columnas de esp32Columns en una entidad ESP32
+func scanESP32(row rowScanner) (*entities.ESP32, error) {
 	var esp32 entities.ESP32
 	var userID sql.NullInt64
-	var idKY026 sql.NullInt64
-	var idMQ2 sql.NullInt64
-	var idMQ135 sql.NullInt64
-	var idDHT22 sql.NullInt64
-	
-	err := r.db.QueryRowContext(ctx, query, numeroSerie).Scan(
+	var claimCodeHash sql.NullString
+	var deviceTokenHash sql.NullString
+	var lastSeenAt sql.NullTime
+	var nickname sql.NullString
+	var room sql.NullString
+	var address sql.NullString
+	var latitude sql.NullFloat64
+	var longitude sql.NullFloat64
+	var assignedAt sql.NullTime
+	var groupID sql.NullInt64
+	var decommissionedAt sql.NullTime
+	var hardwareRevision sql.NullString
+	var purgedAt sql.NullTime
+
+	err := row.Scan(
 		&esp32.ID,
-		&idKY026,
-		&idMQ2,
-		&idMQ135,
-		&idDHT22,
 		&esp32.NumeroSerie,
 		&userID,
+		&claimCodeHash,
+		&deviceTokenHash,
+		&lastSeenAt,
+		&esp32.Online,
+		&nickname,
+		&room,
+		&address,
+		&latitude,
+		&longitude,
+		&assignedAt,
+		&groupID,
+		&esp32.CreatedAt,
+		&decommissionedAt,
+		&hardwareRevision,
+		&purgedAt,
 	)
-	
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil // No error, just no ESP32 found
-		}
 		return nil, err
 	}
-	
+
 	// Convert nullable fields to int
-	if idKY026.Valid {
-		esp32.IDKY026 = int(idKY026.Int64)
+	if userID.Valid {
+		userIDInt := int(userID.Int64)
+		esp32.UserID = &userIDInt
 	}
-	if idMQ2.Valid {
-		esp32.IDMQ2 = int(idMQ2.Int64)
+	if lastSeenAt.Valid {
+		esp32.LastSeenAt = &lastSeenAt.Time
 	}
-	if idMQ135.Valid {
-		esp32.IDMQ135 = int(idMQ135.Int64)
+	if assignedAt.Valid {
+		esp32.AssignedAt = &assignedAt.Time
 	}
-	if idDHT22.Valid {
-		esp32.IDDHT22 = int(idDHT22.Int64)
+	if groupID.Valid {
+		groupIDInt := int(groupID.Int64)
+		esp32.GroupID = &groupIDInt
 	}
-	
-	if userID.Valid {
-		userIDInt := int(userID.Int64)
-		esp32.UserID = &userIDInt
+	if decommissionedAt.Valid {
+		esp32.DecommissionedAt = &decommissionedAt.Time
 	}
-	
-	// Set current time as created_at since it's not in the database
-	esp32.CreatedAt = time.Now()
-	
+	if purgedAt.Valid {
+		esp32.PurgedAt = &purgedAt.Time
+	}
+	esp32.ClaimCodeHash = claimCodeHash.String
+	esp32.HardwareRevision = hardwareRevision.String
+	esp32.DeviceTokenHash = deviceTokenHash.String
+	esp32.Nickname = nickname.String
+	esp32.Room = room.String
+	esp32.Address = address.String
+	if latitude.Valid {
+		esp32.Latitude = &latitude.Float64
+	}
+	if longitude.Valid {
+		esp32.Longitude = &longitude.Float64
+	}
+
 	return &esp32, nil
-}
\ No newline at end of file
+}
+
+// nullableString convierte una cadena vacía en NULL para la base de datos
+func nullableString(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+// nullableFloat convierte un puntero nulo en NULL para la base de datos
+func nullableFloat(value *float64) interface{} {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
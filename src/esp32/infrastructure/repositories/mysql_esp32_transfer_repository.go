@@ -0,0 +1,219 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// transferColumns son las columnas que se leen en todas las consultas de transferencias
+const transferColumns = `t.idTransfer, t.idESP32, e.numero_serie, t.from_user, t.to_email, t.to_user,
+              t.status, t.created_at, t.expires_at, t.resolved_at`
+
+// MySQLESP32TransferRepository implementa ESP32TransferRepository usando MySQL
+type MySQLESP32TransferRepository struct {
+	db *sql.DB
+}
+
+// NewMySQLESP32TransferRepository crea una nueva instancia de MySQLESP32TransferRepository
+func NewMySQLESP32TransferRepository(db *sql.DB) repositories.ESP32TransferRepository {
+	return &MySQLESP32TransferRepository{
+		db: db,
+	}
+}
+
+// Create inserta una nueva transferencia
+func (r *MySQLESP32TransferRepository) Create(ctx context.Context, transfer *entities.ESP32Transfer) (*entities.ESP32Transfer, error) {
+	query := `INSERT INTO esp32_transfers (idESP32, from_user, to_email, status, created_at, expires_at)
+              VALUES (?, ?, ?, ?, ?, ?)`
+
+	result, err := r.db.ExecContext(ctx, query, transfer.ESP32ID, transfer.FromUserID, transfer.ToEmail,
+		string(transfer.Status), transfer.CreatedAt, transfer.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	transfer.ID = int(id)
+
+	return transfer, nil
+}
+
+// FindByID busca una transferencia por su ID
+func (r *MySQLESP32TransferRepository) FindByID(ctx context.Context, id int) (*entities.ESP32Transfer, error) {
+	query := `SELECT ` + transferColumns + ` FROM esp32_transfers t
+              JOIN esp32 e ON t.idESP32 = e.idESP32 WHERE t.idTransfer = ?`
+
+	transfer, err := scanTransfer(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // No error, just no transfer found
+		}
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+// FindPendingByESP32ID busca la transferencia pendiente y no vencida de un ESP32
+func (r *MySQLESP32TransferRepository) FindPendingByESP32ID(ctx context.Context, esp32ID int) (*entities.ESP32Transfer, error) {
+	query := `SELECT ` + transferColumns + ` FROM esp32_transfers t
+              JOIN esp32 e ON t.idESP32 = e.idESP32
+              WHERE t.idESP32 = ? AND t.status = 'pending' AND t.expires_at > ?
+              ORDER BY t.created_at DESC LIMIT 1`
+
+	transfer, err := scanTransfer(r.db.QueryRowContext(ctx, query, esp32ID, time.Now()))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // No error, just no transfer found
+		}
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+// FindPendingByEmail busca las transferencias pendientes y no vencidas dirigidas a un email
+func (r *MySQLESP32TransferRepository) FindPendingByEmail(ctx context.Context, email string) ([]*entities.ESP32Transfer, error) {
+	query := `SELECT ` + transferColumns + ` FROM esp32_transfers t
+              JOIN esp32 e ON t.idESP32 = e.idESP32
+              WHERE t.to_email = ? AND t.status = 'pending' AND t.expires_at > ?
+              ORDER BY t.created_at DESC`
+
+	return r.findMany(ctx, query, entities.NormalizeEmail(email), time.Now())
+}
+
+// FindByFromUserID busca las transferencias iniciadas por un usuario
+func (r *MySQLESP32TransferRepository) FindByFromUserID(ctx context.Context, userID int) ([]*entities.ESP32Transfer, error) {
+	query := `SELECT ` + transferColumns + ` FROM esp32_transfers t
+              JOIN esp32 e ON t.idESP32 = e.idESP32
+              WHERE t.from_user = ? ORDER BY t.created_at DESC`
+
+	return r.findMany(ctx, query, userID)
+}
+
+// UpdateStatus cambia el estado de una transferencia pendiente y registra cuándo se resolvió
+func (r *MySQLESP32TransferRepository) UpdateStatus(ctx context.Context, id int, status entities.TransferStatus) error {
+	query := `UPDATE esp32_transfers SET status = ?, resolved_at = ? WHERE idTransfer = ? AND status = 'pending'`
+
+	_, err := r.db.ExecContext(ctx, query, string(status), time.Now(), id)
+	return err
+}
+
+// Accept asigna el ESP32 al destinatario y marca la transferencia como aceptada en una misma transacción
+func (r *MySQLESP32TransferRepository) Accept(ctx context.Context, transfer *entities.ESP32Transfer, toUserID int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+
+	// Solo se reasigna si el remitente sigue siendo el dueño
+	result, err := tx.ExecContext(ctx,
+		`UPDATE esp32 SET idUser = ?, assigned_at = ?, idGroup = NULL WHERE idESP32 = ? AND idUser = ?`,
+		toUserID, now, transfer.ESP32ID, transfer.FromUserID)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return repositories.ErrTransferConflict
+	}
+
+	// El historial de dueños refleja el cambio en la misma transacción: se cierra la asignación
+	// del remitente y se abre la del destinatario
+	if err := openAssignment(ctx, tx, transfer.ESP32ID, toUserID, entities.AssignmentTransfer, now); err != nil {
+		return err
+	}
+
+	result, err = tx.ExecContext(ctx,
+		`UPDATE esp32_transfers SET status = 'accepted', to_user = ?, resolved_at = ?
+         WHERE idTransfer = ? AND status = 'pending'`,
+		toUserID, now, transfer.ID)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return repositories.ErrTransferConflict
+	}
+
+	return tx.Commit()
+}
+
+// findMany ejecuta una consulta que devuelve una lista de transferencias
+func (r *MySQLESP32TransferRepository) findMany(ctx context.Context, query string, args ...interface{}) ([]*entities.ESP32Transfer, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transfers []*entities.ESP32Transfer
+
+	for rows.Next() {
+		transfer, err := scanTransfer(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		transfers = append(transfers, transfer)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return transfers, nil
+}
+
+// scanTransfer convierte una fila con las columnas de transferColumns en una entidad ESP32Transfer
+func scanTransfer(row rowScanner) (*entities.ESP32Transfer, error) {
+	var transfer entities.ESP32Transfer
+	var toUserID sql.NullInt64
+	var status string
+	var resolvedAt sql.NullTime
+
+	err := row.Scan(
+		&transfer.ID,
+		&transfer.ESP32ID,
+		&transfer.NumeroSerie,
+		&transfer.FromUserID,
+		&transfer.ToEmail,
+		&toUserID,
+		&status,
+		&transfer.CreatedAt,
+		&transfer.ExpiresAt,
+		&resolvedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer.Status = entities.TransferStatus(status)
+	if toUserID.Valid {
+		toUserIDInt := int(toUserID.Int64)
+		transfer.ToUserID = &toUserIDInt
+	}
+	if resolvedAt.Valid {
+		transfer.ResolvedAt = &resolvedAt.Time
+	}
+
+	// Las transferencias vencidas se reportan como tales aunque nadie las haya resuelto
+	if transfer.Status == entities.TransferPending && !time.Now().Before(transfer.ExpiresAt) {
+		transfer.Status = entities.TransferExpired
+	}
+
+	return &transfer, nil
+}
@@ -0,0 +1,339 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// maintenanceScheduleColumns son las columnas que se leen en todas las consultas de tareas, en el orden de scanMaintenanceSchedule
+const maintenanceScheduleColumns = `m.idSchedule, m.idESP32, m.idSensor, m.task, m.interval_days, m.due_at,
+              m.last_performed_at, m.last_result, m.reminder_level, m.created_at`
+
+// maintenanceRecordColumns son las columnas que se leen en todas las consultas de realizaciones, en el orden de scanMaintenanceRecord
+const maintenanceRecordColumns = `idRecord, idSchedule, idESP32, idSensor, task, result, performed_at, performed_by,
+              technician, notes, created_at`
+
+// MySQLMaintenanceRepository implementa MaintenanceRepository usando MySQL
+type MySQLMaintenanceRepository struct {
+	db *sql.DB
+}
+
+// NewMySQLMaintenanceRepository crea una nueva instancia de MySQLMaintenanceRepository
+func NewMySQLMaintenanceRepository(db *sql.DB) repositories.MaintenanceRepository {
+	return &MySQLMaintenanceRepository{
+		db: db,
+	}
+}
+
+// CreateSchedule inserta una nueva tarea de mantenimiento
+func (r *MySQLMaintenanceRepository) CreateSchedule(ctx context.Context, schedule *entities.MaintenanceSchedule) (*entities.MaintenanceSchedule, error) {
+	query := `INSERT INTO maintenance_schedules (idESP32, idSensor, task, interval_days, due_at, created_at)
+              VALUES (?, ?, ?, ?, ?, ?)`
+
+	result, err := r.db.ExecContext(ctx, query, schedule.ESP32ID, schedule.SensorID, string(schedule.Task),
+		schedule.IntervalDays, schedule.DueAt, schedule.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	schedule.ID = int(id)
+
+	return schedule, nil
+}
+
+// FindScheduleByID busca una tarea por su ID
+func (r *MySQLMaintenanceRepository) FindScheduleByID(ctx context.Context, id int) (*entities.MaintenanceSchedule, error) {
+	query := `SELECT ` + maintenanceScheduleColumns + ` FROM maintenance_schedules m WHERE m.idSchedule = ?`
+
+	schedule, err := scanMaintenanceSchedule(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // No error, just no schedule found
+		}
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+// FindSchedulesByESP32ID busca las tareas de un ESP32 ordenadas por vencimiento
+func (r *MySQLMaintenanceRepository) FindSchedulesByESP32ID(ctx context.Context, esp32ID int) ([]*entities.MaintenanceSchedule, error) {
+	query := `SELECT ` + maintenanceScheduleColumns + ` FROM maintenance_schedules m
+              WHERE m.idESP32 = ? ORDER BY m.due_at, m.idSchedule`
+
+	return r.findSchedules(ctx, query, esp32ID)
+}
+
+// FindSchedulesByESP32IDs busca las tareas de varios ESP32
+func (r *MySQLMaintenanceRepository) FindSchedulesByESP32IDs(ctx context.Context, esp32IDs []int) ([]*entities.MaintenanceSchedule, error) {
+	if len(esp32IDs) == 0 {
+		return nil, nil
+	}
+
+	args := make([]interface{}, len(esp32IDs))
+	for i, esp32ID := range esp32IDs {
+		args[i] = esp32ID
+	}
+
+	query := `SELECT ` + maintenanceScheduleColumns + ` FROM maintenance_schedules m
+              WHERE m.idESP32 IN (?` + strings.Repeat(", ?", len(esp32IDs)-1) + `) ORDER BY m.idESP32, m.due_at, m.idSchedule`
+
+	return r.findSchedules(ctx, query, args...)
+}
+
+// UpdateSchedule actualiza el intervalo y el vencimiento de una tarea
+func (r *MySQLMaintenanceRepository) UpdateSchedule(ctx context.Context, schedule *entities.MaintenanceSchedule) error {
+	query := `UPDATE maintenance_schedules SET interval_days = ?, due_at = ?, reminder_level = ? WHERE idSchedule = ?`
+
+	_, err := r.db.ExecContext(ctx, query, schedule.IntervalDays, schedule.DueAt,
+		nullableString(string(schedule.ReminderLevel)), schedule.ID)
+	return err
+}
+
+// DeleteSchedule elimina una tarea; sus realizaciones se conservan en el historial
+func (r *MySQLMaintenanceRepository) DeleteSchedule(ctx context.Context, id int) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM maintenance_schedules WHERE idSchedule = ?`, id)
+	return err
+}
+
+// RecordMaintenance inserta la realización y actualiza el estado de la tarea en una transacción
+func (r *MySQLMaintenanceRepository) RecordMaintenance(ctx context.Context, record *entities.MaintenanceRecord, schedule *entities.MaintenanceSchedule) (*entities.MaintenanceRecord, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `INSERT INTO maintenance_records (idSchedule, idESP32, idSensor, task, result,
+              performed_at, performed_by, technician, notes, created_at)
+              VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		record.ScheduleID, record.ESP32ID, record.SensorID, string(record.Task), string(record.Result),
+		record.PerformedAt, record.PerformedBy, nullableString(record.Technician), nullableString(record.Notes),
+		record.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	record.ID = int(id)
+
+	_, err = tx.ExecContext(ctx, `UPDATE maintenance_schedules
+              SET idSensor = ?, due_at = ?, last_performed_at = ?, last_result = ?, reminder_level = ?
+              WHERE idSchedule = ?`,
+		schedule.SensorID, schedule.DueAt, schedule.LastPerformedAt, nullableString(string(schedule.LastResult)),
+		nullableString(string(schedule.ReminderLevel)), schedule.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// FindRecordsByESP32ID busca las realizaciones de un ESP32, de la más reciente a la más antigua
+func (r *MySQLMaintenanceRepository) FindRecordsByESP32ID(ctx context.Context, esp32ID, limit int) ([]*entities.MaintenanceRecord, error) {
+	query := `SELECT ` + maintenanceRecordColumns + ` FROM maintenance_records
+              WHERE idESP32 = ? ORDER BY performed_at DESC, idRecord DESC LIMIT ?`
+
+	return r.findRecords(ctx, query, esp32ID, limit)
+}
+
+// FindLatestRecords busca la última realización de la tarea en cada uno de los ESP32
+func (r *MySQLMaintenanceRepository) FindLatestRecords(ctx context.Context, esp32IDs []int, task entities.MaintenanceTask) (map[int]*entities.MaintenanceRecord, error) {
+	latest := make(map[int]*entities.MaintenanceRecord)
+	if len(esp32IDs) == 0 {
+		return latest, nil
+	}
+
+	args := []interface{}{string(task)}
+	for _, esp32ID := range esp32IDs {
+		args = append(args, esp32ID)
+	}
+
+	query := `SELECT ` + maintenanceRecordColumns + ` FROM maintenance_records
+              WHERE task = ? AND idESP32 IN (?` + strings.Repeat(", ?", len(esp32IDs)-1) + `)
+              ORDER BY performed_at, idRecord`
+
+	records, err := r.findRecords(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Las filas vienen en orden cronológico, así que la última de cada ESP32 es la más reciente
+	for _, record := range records {
+		latest[record.ESP32ID] = record
+	}
+
+	return latest, nil
+}
+
+// FindPendingReminders busca las tareas que necesitan un recordatorio: las próximas a vencer que
+// no recibieron ninguno y las vencidas que solo recibieron el de próximo vencimiento
+func (r *MySQLMaintenanceRepository) FindPendingReminders(ctx context.Context, now, dueBefore time.Time) ([]*entities.MaintenanceSchedule, error) {
+	query := `SELECT ` + maintenanceScheduleColumns + ` FROM maintenance_schedules m
+              JOIN esp32 e ON e.idESP32 = m.idESP32
+              WHERE e.decommissioned_at IS NULL AND m.due_at <= ?
+                AND (m.reminder_level IS NULL OR (m.reminder_level = 'due' AND m.due_at <= ?))
+              ORDER BY m.due_at, m.idSchedule`
+
+	return r.findSchedules(ctx, query, dueBefore, now)
+}
+
+// SetReminderLevel registra el último recordatorio enviado para una tarea
+func (r *MySQLMaintenanceRepository) SetReminderLevel(ctx context.Context, id int, level entities.MaintenanceStatus, at time.Time) error {
+	query := `UPDATE maintenance_schedules SET reminder_level = ?, reminded_at = ? WHERE idSchedule = ?`
+
+	_, err := r.db.ExecContext(ctx, query, string(level), at, id)
+	return err
+}
+
+// findSchedules ejecuta una consulta que devuelve una lista de tareas
+func (r *MySQLMaintenanceRepository) findSchedules(ctx context.Context, query string, args ...interface{}) ([]*entities.MaintenanceSchedule, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var schedules []*entities.MaintenanceSchedule
+
+	for rows.Next() {
+		schedule, err := scanMaintenanceSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		schedules = append(schedules, schedule)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return schedules, nil
+}
+
+// findRecords ejecuta una consulta que devuelve una lista de realizaciones
+func (r *MySQLMaintenanceRepository) findRecords(ctx context.Context, query string, args ...interface{}) ([]*entities.MaintenanceRecord, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []*entities.MaintenanceRecord
+
+	for rows.Next() {
+		record, err := scanMaintenanceRecord(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, record)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// scanMaintenanceSchedule convierte una fila con las columnas de maintenanceScheduleColumns en una entidad MaintenanceSchedule
+func scanMaintenanceSchedule(row rowScanner) (*entities.MaintenanceSchedule, error) {
+	var schedule entities.MaintenanceSchedule
+	var task string
+	var sensorID sql.NullInt64
+	var lastPerformedAt sql.NullTime
+	var lastResult, reminderLevel sql.NullString
+
+	err := row.Scan(
+		&schedule.ID,
+		&schedule.ESP32ID,
+		&sensorID,
+		&task,
+		&schedule.IntervalDays,
+		&schedule.DueAt,
+		&lastPerformedAt,
+		&lastResult,
+		&reminderLevel,
+		&schedule.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule.Task = entities.MaintenanceTask(task)
+	schedule.LastResult = entities.MaintenanceResult(lastResult.String)
+	schedule.ReminderLevel = entities.MaintenanceStatus(reminderLevel.String)
+	if sensorID.Valid {
+		sensorIDInt := int(sensorID.Int64)
+		schedule.SensorID = &sensorIDInt
+	}
+	if lastPerformedAt.Valid {
+		schedule.LastPerformedAt = &lastPerformedAt.Time
+	}
+	schedule.Status = schedule.StatusAt(time.Now())
+
+	return &schedule, nil
+}
+
+// scanMaintenanceRecord convierte una fila con las columnas de maintenanceRecordColumns en una entidad MaintenanceRecord
+func scanMaintenanceRecord(row rowScanner) (*entities.MaintenanceRecord, error) {
+	var record entities.MaintenanceRecord
+	var task, result string
+	var scheduleID, sensorID, performedBy sql.NullInt64
+	var technician, notes sql.NullString
+
+	err := row.Scan(
+		&record.ID,
+		&scheduleID,
+		&record.ESP32ID,
+		&sensorID,
+		&task,
+		&result,
+		&record.PerformedAt,
+		&performedBy,
+		&technician,
+		&notes,
+		&record.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	record.Task = entities.MaintenanceTask(task)
+	record.Result = entities.MaintenanceResult(result)
+	record.Technician = technician.String
+	record.Notes = notes.String
+	if scheduleID.Valid {
+		scheduleIDInt := int(scheduleID.Int64)
+		record.ScheduleID = &scheduleIDInt
+	}
+	if sensorID.Valid {
+		sensorIDInt := int(sensorID.Int64)
+		record.SensorID = &sensorIDInt
+	}
+	if performedBy.Valid {
+		performedByInt := int(performedBy.Int64)
+		record.PerformedBy = &performedByInt
+	}
+
+	return &record, nil
+}
@@ -0,0 +1,147 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// calibrationColumns son las columnas que se leen en todas las consultas de calibraciones, en el orden de scanCalibration
+const calibrationColumns = `c.idCalibration, c.idSensor, c.r0, c.source, c.technician, c.calibrated_by,
+              c.calibrated_at, c.temperature, c.humidity, c.notes, c.created_at`
+
+// MySQLSensorCalibrationRepository implementa SensorCalibrationRepository usando MySQL
+type MySQLSensorCalibrationRepository struct {
+	db *sql.DB
+}
+
+// NewMySQLSensorCalibrationRepository crea una nueva instancia de MySQLSensorCalibrationRepository
+func NewMySQLSensorCalibrationRepository(db *sql.DB) repositories.SensorCalibrationRepository {
+	return &MySQLSensorCalibrationRepository{
+		db: db,
+	}
+}
+
+// Create registra una calibración
+func (r *MySQLSensorCalibrationRepository) Create(ctx context.Context, calibration *entities.SensorCalibration) (*entities.SensorCalibration, error) {
+	query := `INSERT INTO sensor_calibrations (idSensor, r0, source, technician, calibrated_by,
+              calibrated_at, temperature, humidity, notes, created_at)
+              VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	result, err := r.db.ExecContext(ctx, query, calibration.SensorID, calibration.R0, string(calibration.Source),
+		nullableString(calibration.Technician), calibration.CalibratedBy, calibration.CalibratedAt,
+		nullableFloat(calibration.TemperatureC), nullableFloat(calibration.HumidityPct),
+		nullableString(calibration.Notes), calibration.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	calibration.ID = int(id)
+
+	return calibration, nil
+}
+
+// FindBySensorID busca las calibraciones de un sensor, de la más reciente a la más antigua
+func (r *MySQLSensorCalibrationRepository) FindBySensorID(ctx context.Context, sensorID int) ([]*entities.SensorCalibration, error) {
+	query := `SELECT ` + calibrationColumns + ` FROM sensor_calibrations c
+              WHERE c.idSensor = ? ORDER BY c.calibrated_at DESC, c.idCalibration DESC`
+
+	return r.findMany(ctx, query, sensorID)
+}
+
+// FindLatestByESP32ID busca la última calibración de cada sensor del ESP32
+func (r *MySQLSensorCalibrationRepository) FindLatestByESP32ID(ctx context.Context, esp32ID int) (map[int]*entities.SensorCalibration, error) {
+	query := `SELECT ` + calibrationColumns + ` FROM sensor_calibrations c
+              JOIN device_sensors s ON s.idSensor = c.idSensor
+              WHERE s.idESP32 = ? ORDER BY c.calibrated_at, c.idCalibration`
+
+	calibrations, err := r.findMany(ctx, query, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Las filas vienen en orden cronológico, así que la última de cada sensor es la vigente
+	latest := make(map[int]*entities.SensorCalibration)
+	for _, calibration := range calibrations {
+		latest[calibration.SensorID] = calibration
+	}
+
+	return latest, nil
+}
+
+// findMany ejecuta una consulta que devuelve una lista de calibraciones
+func (r *MySQLSensorCalibrationRepository) findMany(ctx context.Context, query string, args ...interface{}) ([]*entities.SensorCalibration, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var calibrations []*entities.SensorCalibration
+
+	for rows.Next() {
+		calibration, err := scanCalibration(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		calibrations = append(calibrations, calibration)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return calibrations, nil
+}
+
+// scanCalibration convierte una fila con las columnas de calibrationColumns en una entidad SensorCalibration
+func scanCalibration(row rowScanner) (*entities.SensorCalibration, error) {
+	var calibration entities.SensorCalibration
+	var source string
+	var technician sql.NullString
+	var calibratedBy sql.NullInt64
+	var temperature sql.NullFloat64
+	var humidity sql.NullFloat64
+	var notes sql.NullString
+
+	err := row.Scan(
+		&calibration.ID,
+		&calibration.SensorID,
+		&calibration.R0,
+		&source,
+		&technician,
+		&calibratedBy,
+		&calibration.CalibratedAt,
+		&temperature,
+		&humidity,
+		&notes,
+		&calibration.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	calibration.Source = entities.CalibrationSource(source)
+	calibration.Technician = technician.String
+	calibration.Notes = notes.String
+	if calibratedBy.Valid {
+		calibratedByInt := int(calibratedBy.Int64)
+		calibration.CalibratedBy = &calibratedByInt
+	}
+	if temperature.Valid {
+		calibration.TemperatureC = &temperature.Float64
+	}
+	if humidity.Valid {
+		calibration.HumidityPct = &humidity.Float64
+	}
+
+	return &calibration, nil
+}
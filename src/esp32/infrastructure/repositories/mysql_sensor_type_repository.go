@@ -0,0 +1,157 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"hex_go/src/esp32/domain/entities"
+	"hex_go/src/esp32/domain/repositories"
+)
+
+// sensorTypeColumns son las columnas que se leen en todas las consultas de tipos de sensor
+const sensorTypeColumns = `code, name, unit, min_value, max_value, digital, default_kit,
+              calibration_interval_days, ppm_curve_a, ppm_curve_b, created_at`
+
+// MySQLSensorTypeRepository implementa SensorTypeRepository usando MySQL
+type MySQLSensorTypeRepository struct {
+	db *sql.DB
+}
+
+// NewMySQLSensorTypeRepository crea una nueva instancia de MySQLSensorTypeRepository
+func NewMySQLSensorTypeRepository(db *sql.DB) repositories.SensorTypeRepository {
+	return &MySQLSensorTypeRepository{
+		db: db,
+	}
+}
+
+// FindAll devuelve todos los tipos de sensor registrados ordenados por código
+func (r *MySQLSensorTypeRepository) FindAll(ctx context.Context) ([]*entities.SensorType, error) {
+	query := `SELECT ` + sensorTypeColumns + ` FROM sensor_types ORDER BY code`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sensorTypes []*entities.SensorType
+
+	for rows.Next() {
+		sensorType, err := scanSensorType(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		sensorTypes = append(sensorTypes, sensorType)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sensorTypes, nil
+}
+
+// FindByCode busca un tipo de sensor por su código
+func (r *MySQLSensorTypeRepository) FindByCode(ctx context.Context, code string) (*entities.SensorType, error) {
+	query := `SELECT ` + sensorTypeColumns + ` FROM sensor_types WHERE code = ?`
+
+	sensorType, err := scanSensorType(r.db.QueryRowContext(ctx, query, code))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // No error, just no sensor type found
+		}
+		return nil, err
+	}
+
+	return sensorType, nil
+}
+
+// Create registra un nuevo tipo de sensor
+func (r *MySQLSensorTypeRepository) Create(ctx context.Context, sensorType *entities.SensorType) error {
+	return insertSensorType(ctx, r.db, sensorType)
+}
+
+// Update actualiza la definición de un tipo de sensor existente
+func (r *MySQLSensorTypeRepository) Update(ctx context.Context, sensorType *entities.SensorType) error {
+	query := `UPDATE sensor_types SET name = ?, unit = ?, min_value = ?, max_value = ?, digital = ?, default_kit = ?,
+              calibration_interval_days = ?, ppm_curve_a = ?, ppm_curve_b = ?
+              WHERE code = ?`
+
+	_, err := r.db.ExecContext(ctx, query, sensorType.Name, nullableString(sensorType.Unit),
+		nullableFloat(sensorType.MinValue), nullableFloat(sensorType.MaxValue),
+		sensorType.Digital, sensorType.DefaultKit, sensorType.CalibrationIntervalDays,
+		nullableFloat(sensorType.PPMCurveA), nullableFloat(sensorType.PPMCurveB), sensorType.Code)
+	return err
+}
+
+// execer permite ejecutar sentencias tanto sobre *sql.DB como dentro de una *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// insertSensorType inserta un tipo de sensor con la conexión o transacción indicada
+func insertSensorType(ctx context.Context, db execer, sensorType *entities.SensorType) error {
+	if sensorType.CreatedAt.IsZero() {
+		sensorType.CreatedAt = time.Now()
+	}
+
+	query := `INSERT INTO sensor_types (code, name, unit, min_value, max_value, digital, default_kit,
+              calibration_interval_days, ppm_curve_a, ppm_curve_b, created_at)
+              VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := db.ExecContext(ctx, query, sensorType.Code, sensorType.Name, nullableString(sensorType.Unit),
+		nullableFloat(sensorType.MinValue), nullableFloat(sensorType.MaxValue),
+		sensorType.Digital, sensorType.DefaultKit, sensorType.CalibrationIntervalDays,
+		nullableFloat(sensorType.PPMCurveA), nullableFloat(sensorType.PPMCurveB), sensorType.CreatedAt)
+	return err
+}
+
+// scanSensorType convierte una fila con las columnas de sensorTypeColumns en una entidad SensorType
+func scanSensorType(row rowScanner) (*entities.SensorType, error) {
+	var sensorType entities.SensorType
+	var unit sql.NullString
+	var minValue sql.NullFloat64
+	var maxValue sql.NullFloat64
+	var calibrationIntervalDays sql.NullInt64
+	var ppmCurveA sql.NullFloat64
+	var ppmCurveB sql.NullFloat64
+
+	err := row.Scan(
+		&sensorType.Code,
+		&sensorType.Name,
+		&unit,
+		&minValue,
+		&maxValue,
+		&sensorType.Digital,
+		&sensorType.DefaultKit,
+		&calibrationIntervalDays,
+		&ppmCurveA,
+		&ppmCurveB,
+		&sensorType.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sensorType.Unit = unit.String
+	if minValue.Valid {
+		sensorType.MinValue = &minValue.Float64
+	}
+	if maxValue.Valid {
+		sensorType.MaxValue = &maxValue.Float64
+	}
+	if calibrationIntervalDays.Valid {
+		days := int(calibrationIntervalDays.Int64)
+		sensorType.CalibrationIntervalDays = &days
+	}
+	if ppmCurveA.Valid {
+		sensorType.PPMCurveA = &ppmCurveA.Float64
+	}
+	if ppmCurveB.Valid {
+		sensorType.PPMCurveB = &ppmCurveB.Float64
+	}
+
+	return &sensorType, nil
+}
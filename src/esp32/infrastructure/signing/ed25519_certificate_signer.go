@@ -0,0 +1,45 @@
+package signing
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+)
+
+// Ed25519CertificateSigner firma los certificados de comisionamiento con una clave Ed25519
+type Ed25519CertificateSigner struct {
+	privateKey ed25519.PrivateKey
+}
+
+// NewEd25519CertificateSigner crea el firmante a partir de la clave en base64, que puede ser
+// la semilla de 32 bytes o la clave privada completa de 64 bytes
+func NewEd25519CertificateSigner(encodedKey string) (*Ed25519CertificateSigner, error) {
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, errors.New("signing key is not valid base64")
+	}
+
+	switch len(key) {
+	case ed25519.SeedSize:
+		return &Ed25519CertificateSigner{privateKey: ed25519.NewKeyFromSeed(key)}, nil
+	case ed25519.PrivateKeySize:
+		return &Ed25519CertificateSigner{privateKey: ed25519.PrivateKey(key)}, nil
+	}
+	return nil, errors.New("signing key must be an Ed25519 seed or private key")
+}
+
+// Sign devuelve la firma en base64 de los bytes del certificado
+func (s *Ed25519CertificateSigner) Sign(payload []byte) (string, error) {
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(s.privateKey, payload)), nil
+}
+
+// Algorithm devuelve el nombre del algoritmo de firma
+func (s *Ed25519CertificateSigner) Algorithm() string {
+	return "Ed25519"
+}
+
+// PublicKey devuelve en base64 la clave pública con la que se verifica la firma
+func (s *Ed25519CertificateSigner) PublicKey() string {
+	publicKey := s.privateKey.Public().(ed25519.PublicKey)
+	return base64.StdEncoding.EncodeToString(publicKey)
+}
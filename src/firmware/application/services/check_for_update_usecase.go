@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/firmware/domain/entities"
+	"hex_go/src/firmware/domain/repositories"
+)
+
+// CheckForUpdateUseCase implementa el caso de uso con el que un ESP32 consulta si tiene firmware asignado
+type CheckForUpdateUseCase struct {
+	firmwareRepository repositories.FirmwareRepository
+	rolloutRepository  repositories.RolloutRepository
+}
+
+// NewCheckForUpdateUseCase crea una nueva instancia de CheckForUpdateUseCase
+func NewCheckForUpdateUseCase(firmwareRepo repositories.FirmwareRepository, rolloutRepo repositories.RolloutRepository) *CheckForUpdateUseCase {
+	return &CheckForUpdateUseCase{
+		firmwareRepository: firmwareRepo,
+		rolloutRepository:  rolloutRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso y devuelve la imagen asignada, o nil si el ESP32 está al día.
+// hardware es el modelo de placa registrado para el ESP32, no el que informa el firmware.
+func (uc *CheckForUpdateUseCase) Execute(ctx context.Context, esp32ID int, numeroSerie, hardware, currentVersion string) (*entities.Firmware, error) {
+	firmware, err := findAssignedFirmware(ctx, uc.firmwareRepository, uc.rolloutRepository, esp32ID, numeroSerie, hardware)
+	if err != nil {
+		return nil, err
+	}
+	if firmware == nil || firmware.Version == currentVersion {
+		return nil, nil
+	}
+
+	return firmware, nil
+}
+
+// findAssignedFirmware devuelve la imagen que los despliegues activos asignan al ESP32, o nil si no tiene ninguna
+func findAssignedFirmware(ctx context.Context, firmwareRepo repositories.FirmwareRepository, rolloutRepo repositories.RolloutRepository,
+	esp32ID int, numeroSerie, hardware string) (*entities.Firmware, error) {
+	if hardware == "" {
+		return nil, ErrHardwareNotRegistered
+	}
+
+	rollouts, err := rolloutRepo.FindActiveByHardware(ctx, hardware)
+	if err != nil {
+		return nil, err
+	}
+
+	// El despliegue más reciente que incluya al ESP32 decide qué imagen le corresponde
+	for _, rollout := range rollouts {
+		if rollout.Includes(esp32ID, numeroSerie) {
+			return firmwareRepo.FindByID(ctx, rollout.FirmwareID)
+		}
+	}
+
+	return nil, nil
+}
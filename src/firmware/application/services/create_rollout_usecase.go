@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"hex_go/src/firmware/domain/entities"
+	"hex_go/src/firmware/domain/repositories"
+)
+
+// CreateRolloutUseCase implementa el caso de uso para desplegar una imagen de firmware
+type CreateRolloutUseCase struct {
+	firmwareRepository repositories.FirmwareRepository
+	rolloutRepository  repositories.RolloutRepository
+}
+
+// NewCreateRolloutUseCase crea una nueva instancia de CreateRolloutUseCase
+func NewCreateRolloutUseCase(firmwareRepo repositories.FirmwareRepository, rolloutRepo repositories.RolloutRepository) *CreateRolloutUseCase {
+	return &CreateRolloutUseCase{
+		firmwareRepository: firmwareRepo,
+		rolloutRepository:  rolloutRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *CreateRolloutUseCase) Execute(ctx context.Context, firmwareID, percentage int, esp32IDs []int) (*entities.Rollout, error) {
+	if percentage < 0 || percentage > 100 {
+		return nil, errors.New("percentage must be between 0 and 100")
+	}
+	if percentage == 0 && len(esp32IDs) == 0 {
+		return nil, errors.New("a rollout needs a percentage or a list of ESP32 devices")
+	}
+
+	firmware, err := uc.firmwareRepository.FindByID(ctx, firmwareID)
+	if err != nil {
+		return nil, err
+	}
+	if firmware == nil {
+		return nil, ErrFirmwareNotFound
+	}
+
+	rollout := entities.NewRollout(firmwareID, percentage, esp32IDs)
+	return uc.rolloutRepository.Create(ctx, rollout)
+}
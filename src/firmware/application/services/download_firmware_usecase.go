@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+	"io"
+
+	"hex_go/src/firmware/domain/entities"
+	"hex_go/src/firmware/domain/repositories"
+)
+
+// DownloadFirmwareUseCase implementa el caso de uso para descargar el binario de una imagen
+type DownloadFirmwareUseCase struct {
+	firmwareRepository repositories.FirmwareRepository
+	rolloutRepository  repositories.RolloutRepository
+	storage            repositories.FirmwareStorage
+}
+
+// NewDownloadFirmwareUseCase crea una nueva instancia de DownloadFirmwareUseCase
+func NewDownloadFirmwareUseCase(firmwareRepo repositories.FirmwareRepository, rolloutRepo repositories.RolloutRepository,
+	storage repositories.FirmwareStorage) *DownloadFirmwareUseCase {
+	return &DownloadFirmwareUseCase{
+		firmwareRepository: firmwareRepo,
+		rolloutRepository:  rolloutRepo,
+		storage:            storage,
+	}
+}
+
+// Execute ejecuta el caso de uso; quien llama debe cerrar el contenido devuelto.
+// Un ESP32 solo puede descargar la imagen que le asignan los despliegues activos; cualquier
+// otra se informa como inexistente.
+func (uc *DownloadFirmwareUseCase) Execute(ctx context.Context, esp32ID int, numeroSerie, hardware string, firmwareID int) (*entities.Firmware, io.ReadSeekCloser, error) {
+	firmware, err := findAssignedFirmware(ctx, uc.firmwareRepository, uc.rolloutRepository, esp32ID, numeroSerie, hardware)
+	if err != nil {
+		return nil, nil, err
+	}
+	if firmware == nil || firmware.ID != firmwareID {
+		return nil, nil, ErrFirmwareNotFound
+	}
+
+	content, err := uc.storage.Open(firmware.FilePath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return firmware, content, nil
+}
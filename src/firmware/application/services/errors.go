@@ -0,0 +1,16 @@
+package services
+
+import "errors"
+
+var (
+	// ErrFirmwareNotFound se devuelve cuando la imagen de firmware solicitada no existe
+	ErrFirmwareNotFound = errors.New("firmware not found")
+	// ErrRolloutNotFound se devuelve cuando el despliegue solicitado no existe
+	ErrRolloutNotFound = errors.New("rollout not found")
+	// ErrInvalidSignature se devuelve cuando la firma no corresponde al binario subido
+	ErrInvalidSignature = errors.New("invalid firmware signature")
+	// ErrSigningKeyNotConfigured se devuelve si la API no tiene la clave pública para verificar firmas
+	ErrSigningKeyNotConfigured = errors.New("firmware signing key is not configured")
+	// ErrHardwareNotRegistered se devuelve cuando el ESP32 no tiene registrado su modelo de placa
+	ErrHardwareNotRegistered = errors.New("ESP32 has no hardware revision registered")
+)
@@ -0,0 +1,25 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/firmware/domain/entities"
+	"hex_go/src/firmware/domain/repositories"
+)
+
+// GetFirmwaresUseCase implementa el caso de uso para listar las imágenes de firmware
+type GetFirmwaresUseCase struct {
+	firmwareRepository repositories.FirmwareRepository
+}
+
+// NewGetFirmwaresUseCase crea una nueva instancia de GetFirmwaresUseCase
+func NewGetFirmwaresUseCase(firmwareRepo repositories.FirmwareRepository) *GetFirmwaresUseCase {
+	return &GetFirmwaresUseCase{
+		firmwareRepository: firmwareRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *GetFirmwaresUseCase) Execute(ctx context.Context) ([]*entities.Firmware, error) {
+	return uc.firmwareRepository.FindAll(ctx)
+}
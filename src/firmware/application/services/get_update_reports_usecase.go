@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+
+	"hex_go/src/firmware/domain/entities"
+	"hex_go/src/firmware/domain/repositories"
+)
+
+// GetUpdateReportsUseCase implementa el caso de uso para consultar el resultado de un despliegue
+type GetUpdateReportsUseCase struct {
+	firmwareRepository     repositories.FirmwareRepository
+	rolloutRepository      repositories.RolloutRepository
+	updateReportRepository repositories.UpdateReportRepository
+}
+
+// FirmwareStatusResponse resume el estado de una imagen: sus despliegues y lo informado por cada ESP32
+type FirmwareStatusResponse struct {
+	Firmware  *entities.Firmware       `json:"firmware"`
+	Rollouts  []*entities.Rollout      `json:"rollouts"`
+	Succeeded int                      `json:"succeeded"`
+	Failed    int                      `json:"failed"`
+	Reports   []*entities.UpdateReport `json:"reports"`
+}
+
+// NewGetUpdateReportsUseCase crea una nueva instancia de GetUpdateReportsUseCase
+func NewGetUpdateReportsUseCase(firmwareRepo repositories.FirmwareRepository, rolloutRepo repositories.RolloutRepository, updateReportRepo repositories.UpdateReportRepository) *GetUpdateReportsUseCase {
+	return &GetUpdateReportsUseCase{
+		firmwareRepository:     firmwareRepo,
+		rolloutRepository:      rolloutRepo,
+		updateReportRepository: updateReportRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *GetUpdateReportsUseCase) Execute(ctx context.Context, firmwareID int) (*FirmwareStatusResponse, error) {
+	firmware, err := uc.firmwareRepository.FindByID(ctx, firmwareID)
+	if err != nil {
+		return nil, err
+	}
+	if firmware == nil {
+		return nil, ErrFirmwareNotFound
+	}
+
+	rollouts, err := uc.rolloutRepository.FindByFirmwareID(ctx, firmwareID)
+	if err != nil {
+		return nil, err
+	}
+
+	reports, err := uc.updateReportRepository.FindByFirmwareID(ctx, firmwareID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &FirmwareStatusResponse{
+		Firmware: firmware,
+		Rollouts: rollouts,
+		Reports:  reports,
+	}
+
+	// Contar solo el último resultado informado por cada ESP32 (los reportes vienen del más reciente)
+	seen := make(map[int]bool)
+	for _, report := range reports {
+		if seen[report.ESP32ID] {
+			continue
+		}
+		seen[report.ESP32ID] = true
+
+		if report.Status == entities.UpdateSucceeded {
+			response.Succeeded++
+		} else {
+			response.Failed++
+		}
+	}
+
+	return response, nil
+}
@@ -0,0 +1,41 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"hex_go/src/firmware/domain/entities"
+	"hex_go/src/firmware/domain/repositories"
+)
+
+// ReportUpdateUseCase implementa el caso de uso con el que un ESP32 informa el resultado de una actualización
+type ReportUpdateUseCase struct {
+	firmwareRepository     repositories.FirmwareRepository
+	updateReportRepository repositories.UpdateReportRepository
+}
+
+// NewReportUpdateUseCase crea una nueva instancia de ReportUpdateUseCase
+func NewReportUpdateUseCase(firmwareRepo repositories.FirmwareRepository, updateReportRepo repositories.UpdateReportRepository) *ReportUpdateUseCase {
+	return &ReportUpdateUseCase{
+		firmwareRepository:     firmwareRepo,
+		updateReportRepository: updateReportRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *ReportUpdateUseCase) Execute(ctx context.Context, esp32ID, firmwareID int, status entities.UpdateStatus, message string) (*entities.UpdateReport, error) {
+	if status != entities.UpdateSucceeded && status != entities.UpdateFailed {
+		return nil, errors.New("status must be success or failed")
+	}
+
+	firmware, err := uc.firmwareRepository.FindByID(ctx, firmwareID)
+	if err != nil {
+		return nil, err
+	}
+	if firmware == nil {
+		return nil, ErrFirmwareNotFound
+	}
+
+	report := entities.NewUpdateReport(esp32ID, firmwareID, status, message)
+	return uc.updateReportRepository.Create(ctx, report)
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"hex_go/src/firmware/domain/entities"
+	"hex_go/src/firmware/domain/repositories"
+)
+
+// UpdateRolloutUseCase implementa el caso de uso para ampliar, pausar o cerrar un despliegue
+type UpdateRolloutUseCase struct {
+	rolloutRepository repositories.RolloutRepository
+}
+
+// NewUpdateRolloutUseCase crea una nueva instancia de UpdateRolloutUseCase
+func NewUpdateRolloutUseCase(rolloutRepo repositories.RolloutRepository) *UpdateRolloutUseCase {
+	return &UpdateRolloutUseCase{
+		rolloutRepository: rolloutRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso; los valores nulos no se modifican
+func (uc *UpdateRolloutUseCase) Execute(ctx context.Context, rolloutID int, percentage *int, status *entities.RolloutStatus) (*entities.Rollout, error) {
+	rollout, err := uc.rolloutRepository.FindByID(ctx, rolloutID)
+	if err != nil {
+		return nil, err
+	}
+	if rollout == nil {
+		return nil, ErrRolloutNotFound
+	}
+
+	if percentage != nil {
+		if *percentage < 0 || *percentage > 100 {
+			return nil, errors.New("percentage must be between 0 and 100")
+		}
+		rollout.Percentage = *percentage
+	}
+	if status != nil {
+		switch *status {
+		case entities.RolloutActive, entities.RolloutPaused, entities.RolloutCompleted:
+			rollout.Status = *status
+		default:
+			return nil, errors.New("status must be active, paused or completed")
+		}
+	}
+
+	if err := uc.rolloutRepository.Update(ctx, rollout); err != nil {
+		return nil, err
+	}
+
+	return rollout, nil
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+
+	"hex_go/src/firmware/domain/entities"
+	"hex_go/src/firmware/domain/repositories"
+)
+
+// fileNameUnsafe coincide con los caracteres que no se permiten en el nombre del archivo guardado
+var fileNameUnsafe = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// UploadFirmwareUseCase implementa el caso de uso para subir una imagen de firmware firmada
+type UploadFirmwareUseCase struct {
+	firmwareRepository repositories.FirmwareRepository
+	storage            repositories.FirmwareStorage
+	publicKey          ed25519.PublicKey
+}
+
+// NewUploadFirmwareUseCase crea una nueva instancia de UploadFirmwareUseCase.
+// publicKey es la clave Ed25519 con la que se verifican las firmas; si es nula se rechazan las subidas.
+func NewUploadFirmwareUseCase(firmwareRepo repositories.FirmwareRepository, storage repositories.FirmwareStorage, publicKey ed25519.PublicKey) *UploadFirmwareUseCase {
+	return &UploadFirmwareUseCase{
+		firmwareRepository: firmwareRepo,
+		storage:            storage,
+		publicKey:          publicKey,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *UploadFirmwareUseCase) Execute(ctx context.Context, version, hardware, signature string, content io.Reader) (*entities.Firmware, error) {
+	version = strings.TrimSpace(version)
+	hardware = strings.TrimSpace(hardware)
+	if version == "" || hardware == "" {
+		return nil, errors.New("version and hardware are required")
+	}
+	if uc.publicKey == nil {
+		return nil, ErrSigningKeyNotConfigured
+	}
+
+	signatureBytes, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil || len(signatureBytes) != ed25519.SignatureSize {
+		return nil, ErrInvalidSignature
+	}
+
+	// Verificar que la versión no exista para este hardware
+	existing, err := uc.firmwareRepository.FindByVersion(ctx, hardware, version)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, errors.New("firmware version already exists for this hardware")
+	}
+
+	// Guardar el binario y verificar la firma sobre su SHA-256
+	name := fileNameUnsafe.ReplaceAllString(hardware+"-"+version, "_") + ".bin"
+	stored, err := uc.storage.Save(ctx, name, content)
+	if err != nil {
+		return nil, err
+	}
+
+	digest, err := hex.DecodeString(stored.SHA256)
+	if err != nil || !ed25519.Verify(uc.publicKey, digest, signatureBytes) {
+		uc.storage.Remove(stored.Path)
+		return nil, ErrInvalidSignature
+	}
+
+	firmware := entities.NewFirmware(version, hardware, stored.SHA256, signature, stored.Path, stored.Size)
+	created, err := uc.firmwareRepository.Create(ctx, firmware)
+	if err != nil {
+		uc.storage.Remove(stored.Path)
+		return nil, err
+	}
+
+	return created, nil
+}
@@ -0,0 +1,30 @@
+package entities
+
+import (
+	"time"
+)
+
+// Firmware representa una imagen de firmware firmada para un modelo de hardware
+type Firmware struct {
+	ID        int       `json:"id"`
+	Version   string    `json:"version"`
+	Hardware  string    `json:"hardware"` // Modelo de placa al que va dirigida la imagen
+	SHA256    string    `json:"sha256"`
+	Size      int64     `json:"size"`
+	Signature string    `json:"signature"` // Firma Ed25519 del SHA-256 en base64
+	FilePath  string    `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewFirmware crea una nueva instancia de Firmware
+func NewFirmware(version, hardware, sha256, signature, filePath string, size int64) *Firmware {
+	return &Firmware{
+		Version:   version,
+		Hardware:  hardware,
+		SHA256:    sha256,
+		Size:      size,
+		Signature: signature,
+		FilePath:  filePath,
+		CreatedAt: time.Now(),
+	}
+}
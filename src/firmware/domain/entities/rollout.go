@@ -0,0 +1,58 @@
+package entities
+
+import (
+	"hash/fnv"
+	"strconv"
+	"time"
+)
+
+// RolloutStatus representa el estado de un despliegue de firmware
+type RolloutStatus string
+
+const (
+	RolloutActive    RolloutStatus = "active"
+	RolloutPaused    RolloutStatus = "paused"
+	RolloutCompleted RolloutStatus = "completed"
+)
+
+// Rollout representa el despliegue escalonado de una imagen de firmware.
+// Un ESP32 recibe la imagen si está en la lista explícita de dispositivos
+// o si cae dentro del porcentaje del despliegue.
+type Rollout struct {
+	ID         int           `json:"id"`
+	FirmwareID int           `json:"firmware_id"`
+	Percentage int           `json:"percentage"`
+	ESP32IDs   []int         `json:"esp32_ids"`
+	Status     RolloutStatus `json:"status"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+// NewRollout crea una nueva instancia de Rollout activo
+func NewRollout(firmwareID, percentage int, esp32IDs []int) *Rollout {
+	return &Rollout{
+		FirmwareID: firmwareID,
+		Percentage: percentage,
+		ESP32IDs:   esp32IDs,
+		Status:     RolloutActive,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// Includes indica si el ESP32 forma parte del despliegue. El porcentaje se calcula con un hash
+// estable del número de serie, de modo que subir el porcentaje solo agrega dispositivos nuevos.
+func (r *Rollout) Includes(esp32ID int, numeroSerie string) bool {
+	for _, id := range r.ESP32IDs {
+		if id == esp32ID {
+			return true
+		}
+	}
+
+	return rolloutBucket(r.ID, numeroSerie) < r.Percentage
+}
+
+// rolloutBucket asigna a cada número de serie un valor estable entre 0 y 99 para el despliegue
+func rolloutBucket(rolloutID int, numeroSerie string) int {
+	h := fnv.New32a()
+	h.Write([]byte(strconv.Itoa(rolloutID) + ":" + numeroSerie))
+	return int(h.Sum32() % 100)
+}
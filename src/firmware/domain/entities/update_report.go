@@ -0,0 +1,34 @@
+package entities
+
+import (
+	"time"
+)
+
+// UpdateStatus representa el resultado de una actualización informado por el ESP32
+type UpdateStatus string
+
+const (
+	UpdateSucceeded UpdateStatus = "success"
+	UpdateFailed    UpdateStatus = "failed"
+)
+
+// UpdateReport representa el resultado de aplicar una imagen de firmware en un ESP32
+type UpdateReport struct {
+	ID         int          `json:"id"`
+	ESP32ID    int          `json:"esp32_id"`
+	FirmwareID int          `json:"firmware_id"`
+	Status     UpdateStatus `json:"status"`
+	Message    string       `json:"message"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+// NewUpdateReport crea una nueva instancia de UpdateReport
+func NewUpdateReport(esp32ID, firmwareID int, status UpdateStatus, message string) *UpdateReport {
+	return &UpdateReport{
+		ESP32ID:    esp32ID,
+		FirmwareID: firmwareID,
+		Status:     status,
+		Message:    message,
+		CreatedAt:  time.Now(),
+	}
+}
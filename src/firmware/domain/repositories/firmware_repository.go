@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+
+	"hex_go/src/firmware/domain/entities"
+)
+
+// FirmwareRepository define las operaciones sobre las imágenes de firmware
+type FirmwareRepository interface {
+	Create(ctx context.Context, firmware *entities.Firmware) (*entities.Firmware, error)
+	FindByID(ctx context.Context, id int) (*entities.Firmware, error)
+	FindByVersion(ctx context.Context, hardware, version string) (*entities.Firmware, error)
+	FindAll(ctx context.Context) ([]*entities.Firmware, error)
+}
@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+	"io"
+)
+
+// StoredFile describe un binario guardado en el almacenamiento de firmware
+type StoredFile struct {
+	Path   string
+	Size   int64
+	SHA256 string
+}
+
+// FirmwareStorage define dónde se guardan los binarios de firmware
+type FirmwareStorage interface {
+	Save(ctx context.Context, name string, content io.Reader) (*StoredFile, error)
+	Open(path string) (io.ReadSeekCloser, error)
+	Remove(path string) error
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+
+	"hex_go/src/firmware/domain/entities"
+)
+
+// RolloutRepository define las operaciones sobre los despliegues de firmware
+type RolloutRepository interface {
+	Create(ctx context.Context, rollout *entities.Rollout) (*entities.Rollout, error)
+	FindByID(ctx context.Context, id int) (*entities.Rollout, error)
+	FindByFirmwareID(ctx context.Context, firmwareID int) ([]*entities.Rollout, error)
+	// FindActiveByHardware devuelve los despliegues activos para un modelo de hardware, del más reciente al más antiguo
+	FindActiveByHardware(ctx context.Context, hardware string) ([]*entities.Rollout, error)
+	Update(ctx context.Context, rollout *entities.Rollout) error
+}
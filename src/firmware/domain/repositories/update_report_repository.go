@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"context"
+
+	"hex_go/src/firmware/domain/entities"
+)
+
+// UpdateReportRepository define las operaciones sobre los resultados de actualización
+type UpdateReportRepository interface {
+	Create(ctx context.Context, report *entities.UpdateReport) (*entities.UpdateReport, error)
+	FindByFirmwareID(ctx context.Context, firmwareID int) ([]*entities.UpdateReport, error)
+}
@@ -0,0 +1,165 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"hex_go/src/firmware/application/services"
+	"hex_go/src/firmware/domain/entities"
+)
+
+// AdminFirmwareController maneja las solicitudes HTTP de administración de firmware
+type AdminFirmwareController struct {
+	uploadFirmwareUseCase   *services.UploadFirmwareUseCase
+	getFirmwaresUseCase     *services.GetFirmwaresUseCase
+	getUpdateReportsUseCase *services.GetUpdateReportsUseCase
+	createRolloutUseCase    *services.CreateRolloutUseCase
+	updateRolloutUseCase    *services.UpdateRolloutUseCase
+}
+
+// NewAdminFirmwareController crea una nueva instancia de AdminFirmwareController
+func NewAdminFirmwareController(
+	uploadFirmwareUseCase *services.UploadFirmwareUseCase,
+	getFirmwaresUseCase *services.GetFirmwaresUseCase,
+	getUpdateReportsUseCase *services.GetUpdateReportsUseCase,
+	createRolloutUseCase *services.CreateRolloutUseCase,
+	updateRolloutUseCase *services.UpdateRolloutUseCase,
+) *AdminFirmwareController {
+	return &AdminFirmwareController{
+		uploadFirmwareUseCase:   uploadFirmwareUseCase,
+		getFirmwaresUseCase:     getFirmwaresUseCase,
+		getUpdateReportsUseCase: getUpdateReportsUseCase,
+		createRolloutUseCase:    createRolloutUseCase,
+		updateRolloutUseCase:    updateRolloutUseCase,
+	}
+}
+
+// CreateRolloutRequest representa la estructura de la solicitud para desplegar una imagen
+type CreateRolloutRequest struct {
+	Percentage int   `json:"percentage"`
+	ESP32IDs   []int `json:"esp32_ids"`
+}
+
+// UpdateRolloutRequest representa la estructura de la solicitud para modificar un despliegue
+type UpdateRolloutRequest struct {
+	Percentage *int                    `json:"percentage"`
+	Status     *entities.RolloutStatus `json:"status"`
+}
+
+// UploadFirmware maneja la solicitud HTTP multipart para subir una imagen firmada.
+// Campos: file (binario), version, hardware y signature (firma Ed25519 del SHA-256 en base64).
+func (c *AdminFirmwareController) UploadFirmware(ctx *gin.Context) {
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	firmware, err := c.uploadFirmwareUseCase.Execute(ctx,
+		ctx.PostForm("version"), ctx.PostForm("hardware"), ctx.PostForm("signature"), file)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, firmware)
+}
+
+// GetFirmwares maneja la solicitud HTTP para listar las imágenes de firmware
+func (c *AdminFirmwareController) GetFirmwares(ctx *gin.Context) {
+	firmwares, err := c.getFirmwaresUseCase.Execute(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, firmwares)
+}
+
+// GetFirmwareStatus maneja la solicitud HTTP para ver los despliegues y resultados de una imagen
+func (c *AdminFirmwareController) GetFirmwareStatus(ctx *gin.Context) {
+	firmwareID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid firmware ID"})
+		return
+	}
+
+	status, err := c.getUpdateReportsUseCase.Execute(ctx, firmwareID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, status)
+}
+
+// CreateRollout maneja la solicitud HTTP para desplegar una imagen a un porcentaje o a una lista de ESP32
+func (c *AdminFirmwareController) CreateRollout(ctx *gin.Context) {
+	firmwareID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid firmware ID"})
+		return
+	}
+
+	var req CreateRolloutRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rollout, err := c.createRolloutUseCase.Execute(ctx, firmwareID, req.Percentage, req.ESP32IDs)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, rollout)
+}
+
+// UpdateRollout maneja la solicitud HTTP para ampliar, pausar o cerrar un despliegue
+func (c *AdminFirmwareController) UpdateRollout(ctx *gin.Context) {
+	rolloutID, err := strconv.Atoi(ctx.Param("rolloutId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid rollout ID"})
+		return
+	}
+
+	var req UpdateRolloutRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rollout, err := c.updateRolloutUseCase.Execute(ctx, rolloutID, req.Percentage, req.Status)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rollout)
+}
+
+// SetupRoutes configura las rutas de administración de firmware
+func (c *AdminFirmwareController) SetupRoutes(router *gin.Engine, authMiddleware, adminMiddleware gin.HandlerFunc) {
+	api := router.Group("/api")
+	{
+		admin := api.Group("/admin/firmware")
+		// Rutas de administración (requieren autenticación y rol de administrador)
+		admin.Use(authMiddleware, adminMiddleware)
+		{
+			admin.POST("", c.UploadFirmware)
+			admin.GET("", c.GetFirmwares)
+			admin.GET("/:id", c.GetFirmwareStatus)
+			admin.POST("/:id/rollouts", c.CreateRollout)
+			admin.PATCH("/rollouts/:rolloutId", c.UpdateRollout)
+		}
+	}
+}
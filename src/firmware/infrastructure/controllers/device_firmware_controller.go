@@ -0,0 +1,115 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"hex_go/src/firmware/application/services"
+	"hex_go/src/firmware/domain/entities"
+)
+
+// DeviceFirmwareController maneja las solicitudes HTTP de firmware que realizan los ESP32
+type DeviceFirmwareController struct {
+	checkForUpdateUseCase   *services.CheckForUpdateUseCase
+	downloadFirmwareUseCase *services.DownloadFirmwareUseCase
+	reportUpdateUseCase     *services.ReportUpdateUseCase
+}
+
+// NewDeviceFirmwareController crea una nueva instancia de DeviceFirmwareController
+func NewDeviceFirmwareController(
+	checkForUpdateUseCase *services.CheckForUpdateUseCase,
+	downloadFirmwareUseCase *services.DownloadFirmwareUseCase,
+	reportUpdateUseCase *services.ReportUpdateUseCase,
+) *DeviceFirmwareController {
+	return &DeviceFirmwareController{
+		checkForUpdateUseCase:   checkForUpdateUseCase,
+		downloadFirmwareUseCase: downloadFirmwareUseCase,
+		reportUpdateUseCase:     reportUpdateUseCase,
+	}
+}
+
+// ReportUpdateRequest representa la estructura de la solicitud con el resultado de una actualización
+type ReportUpdateRequest struct {
+	FirmwareID int                   `json:"firmware_id" binding:"required"`
+	Status     entities.UpdateStatus `json:"status" binding:"required"`
+	Message    string                `json:"message"`
+}
+
+// CheckForUpdate maneja la solicitud HTTP con la que un ESP32 consulta si tiene una imagen asignada.
+// Responde 204 si el ESP32 ya tiene la versión que le corresponde. El modelo de placa es el
+// registrado para el ESP32, no uno informado por el firmware.
+func (c *DeviceFirmwareController) CheckForUpdate(ctx *gin.Context) {
+	firmware, err := c.checkForUpdateUseCase.Execute(ctx,
+		ctx.GetInt("esp32ID"), ctx.GetString("numeroSerie"), ctx.GetString("hardwareRevision"), ctx.Query("version"))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	if firmware == nil {
+		ctx.Status(http.StatusNoContent)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"firmware":     firmware,
+		"download_url": fmt.Sprintf("/api/devices/firmware/%d/download", firmware.ID),
+	})
+}
+
+// DownloadFirmware maneja la descarga del binario. Admite solicitudes Range para reanudar
+// descargas interrumpidas y envía el SHA-256 en X-Checksum-SHA256.
+func (c *DeviceFirmwareController) DownloadFirmware(ctx *gin.Context) {
+	firmwareID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid firmware ID"})
+		return
+	}
+
+	firmware, content, err := c.downloadFirmwareUseCase.Execute(ctx,
+		ctx.GetInt("esp32ID"), ctx.GetString("numeroSerie"), ctx.GetString("hardwareRevision"), firmwareID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	defer content.Close()
+
+	ctx.Header("Content-Type", "application/octet-stream")
+	ctx.Header("ETag", `"`+firmware.SHA256+`"`)
+	ctx.Header("X-Checksum-SHA256", firmware.SHA256)
+	ctx.Header("X-Firmware-Signature", firmware.Signature)
+	http.ServeContent(ctx.Writer, ctx.Request, firmware.Hardware+"-"+firmware.Version+".bin", firmware.CreatedAt, content)
+}
+
+// ReportUpdate maneja la solicitud HTTP con la que un ESP32 informa el resultado de una actualización
+func (c *DeviceFirmwareController) ReportUpdate(ctx *gin.Context) {
+	var req ReportUpdateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := c.reportUpdateUseCase.Execute(ctx, ctx.GetInt("esp32ID"), req.FirmwareID, req.Status, req.Message)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, report)
+}
+
+// SetupRoutes configura las rutas de firmware para los dispositivos
+func (c *DeviceFirmwareController) SetupRoutes(router *gin.Engine, deviceAuthMiddleware gin.HandlerFunc) {
+	api := router.Group("/api")
+	{
+		firmware := api.Group("/devices/firmware")
+		// Rutas de dispositivos (requieren número de serie y token del ESP32)
+		firmware.Use(deviceAuthMiddleware)
+		{
+			firmware.GET("", c.CheckForUpdate)
+			firmware.GET("/:id/download", c.DownloadFirmware)
+			firmware.POST("/report", c.ReportUpdate)
+		}
+	}
+}
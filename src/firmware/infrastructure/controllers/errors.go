@@ -0,0 +1,24 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"hex_go/src/firmware/application/services"
+)
+
+// respondError traduce los errores de los casos de uso a respuestas HTTP
+func respondError(ctx *gin.Context, err error) {
+	status := http.StatusBadRequest
+	switch {
+	case errors.Is(err, services.ErrFirmwareNotFound), errors.Is(err, services.ErrRolloutNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, services.ErrHardwareNotRegistered):
+		status = http.StatusConflict
+	case errors.Is(err, services.ErrSigningKeyNotConfigured):
+		status = http.StatusServiceUnavailable
+	}
+
+	ctx.JSON(status, gin.H{"error": err.Error()})
+}
@@ -0,0 +1,132 @@
+package infrastructure
+
+import (
+	"crypto/ed25519"
+	"database/sql"
+	"encoding/base64"
+	"log"
+
+	"github.com/gin-gonic/gin"
+	"hex_go/src/config"
+	esp32Services "hex_go/src/esp32/application/services"
+	esp32Repo "hex_go/src/esp32/infrastructure/repositories"
+	"hex_go/src/firmware/application/services"
+	"hex_go/src/firmware/infrastructure/controllers"
+	"hex_go/src/firmware/infrastructure/repositories"
+	"hex_go/src/firmware/infrastructure/storage"
+	"hex_go/src/middleware"
+)
+
+// Init inicializa la infraestructura de firmware
+func Init(router *gin.Engine, db *sql.DB) {
+	// Crear tablas de firmware si no existen
+	createFirmwareTables(db)
+
+	// Inicializar almacenamiento y repositorios
+	firmwareStorage, err := storage.NewLocalFirmwareStorage(config.GetEnv("FIRMWARE_STORAGE_DIR", "./firmware"))
+	if err != nil {
+		log.Fatalf("Failed to initialize firmware storage: %v", err)
+	}
+	firmwareRepo := repositories.NewMySQLFirmwareRepository(db)
+	rolloutRepo := repositories.NewMySQLRolloutRepository(db)
+	updateReportRepo := repositories.NewMySQLUpdateReportRepository(db)
+	esp32Repository := esp32Repo.NewMySQLESP32Repository(db)
+
+	// Inicializar casos de uso
+	uploadFirmwareUseCase := services.NewUploadFirmwareUseCase(firmwareRepo, firmwareStorage, loadSigningPublicKey())
+	getFirmwaresUseCase := services.NewGetFirmwaresUseCase(firmwareRepo)
+	getUpdateReportsUseCase := services.NewGetUpdateReportsUseCase(firmwareRepo, rolloutRepo, updateReportRepo)
+	createRolloutUseCase := services.NewCreateRolloutUseCase(firmwareRepo, rolloutRepo)
+	updateRolloutUseCase := services.NewUpdateRolloutUseCase(rolloutRepo)
+	checkForUpdateUseCase := services.NewCheckForUpdateUseCase(firmwareRepo, rolloutRepo)
+	downloadFirmwareUseCase := services.NewDownloadFirmwareUseCase(firmwareRepo, rolloutRepo, firmwareStorage)
+	reportUpdateUseCase := services.NewReportUpdateUseCase(firmwareRepo, updateReportRepo)
+	authenticateDeviceUseCase := esp32Services.NewAuthenticateDeviceUseCase(esp32Repository)
+
+	// Inicializar controladores
+	adminFirmwareController := controllers.NewAdminFirmwareController(
+		uploadFirmwareUseCase,
+		getFirmwaresUseCase,
+		getUpdateReportsUseCase,
+		createRolloutUseCase,
+		updateRolloutUseCase,
+	)
+	deviceFirmwareController := controllers.NewDeviceFirmwareController(
+		checkForUpdateUseCase,
+		downloadFirmwareUseCase,
+		reportUpdateUseCase,
+	)
+
+	// Configurar rutas
+	authMiddleware := middleware.AuthMiddleware()
+	adminMiddleware := middleware.AdminMiddleware()
+	deviceAuthMiddleware := middleware.DeviceAuthMiddleware(authenticateDeviceUseCase)
+	adminFirmwareController.SetupRoutes(router, authMiddleware, adminMiddleware)
+	deviceFirmwareController.SetupRoutes(router, deviceAuthMiddleware)
+}
+
+// loadSigningPublicKey lee la clave pública Ed25519 (en base64) con la que se firman las imágenes
+func loadSigningPublicKey() ed25519.PublicKey {
+	encoded := config.GetEnv("FIRMWARE_SIGNING_PUBLIC_KEY", "")
+	if encoded == "" {
+		log.Println("Warning: FIRMWARE_SIGNING_PUBLIC_KEY not set, firmware uploads are disabled")
+		return nil
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		log.Println("Warning: FIRMWARE_SIGNING_PUBLIC_KEY is not a valid base64 Ed25519 public key, firmware uploads are disabled")
+		return nil
+	}
+
+	return ed25519.PublicKey(key)
+}
+
+// createFirmwareTables crea las tablas de firmware si no existen
+func createFirmwareTables(db *sql.DB) {
+	queries := []string{
+		`CREATE TABLE IF NOT EXISTS firmware_images (
+			idFirmware INT AUTO_INCREMENT PRIMARY KEY,
+			version VARCHAR(50) NOT NULL,
+			hardware VARCHAR(50) NOT NULL,
+			sha256 CHAR(64) NOT NULL,
+			size BIGINT NOT NULL,
+			signature VARCHAR(255) NOT NULL,
+			file_path VARCHAR(512) NOT NULL,
+			created_at DATETIME NOT NULL,
+			UNIQUE KEY uq_firmware_version (hardware, version)
+		)`,
+		`CREATE TABLE IF NOT EXISTS firmware_rollouts (
+			idRollout INT AUTO_INCREMENT PRIMARY KEY,
+			idFirmware INT NOT NULL,
+			percentage INT NOT NULL DEFAULT 0,
+			status VARCHAR(20) NOT NULL,
+			created_at DATETIME NOT NULL,
+			FOREIGN KEY (idFirmware) REFERENCES firmware_images(idFirmware) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS firmware_rollout_devices (
+			idRollout INT NOT NULL,
+			idESP32 INT NOT NULL,
+			PRIMARY KEY (idRollout, idESP32),
+			FOREIGN KEY (idRollout) REFERENCES firmware_rollouts(idRollout) ON DELETE CASCADE,
+			FOREIGN KEY (idESP32) REFERENCES esp32(idESP32) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS firmware_update_reports (
+			idReport INT AUTO_INCREMENT PRIMARY KEY,
+			idESP32 INT NOT NULL,
+			idFirmware INT NOT NULL,
+			status VARCHAR(20) NOT NULL,
+			message VARCHAR(500) NOT NULL DEFAULT '',
+			created_at DATETIME NOT NULL,
+			INDEX idx_firmware_reports (idFirmware, created_at),
+			FOREIGN KEY (idESP32) REFERENCES esp32(idESP32) ON DELETE CASCADE,
+			FOREIGN KEY (idFirmware) REFERENCES firmware_images(idFirmware) ON DELETE CASCADE
+		)`,
+	}
+
+	for _, query := range queries {
+		if _, err := db.Exec(query); err != nil {
+			log.Printf("Warning: Failed to create firmware table: %v", err)
+		}
+	}
+}
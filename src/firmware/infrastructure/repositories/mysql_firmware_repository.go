@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"hex_go/src/firmware/domain/entities"
+	"hex_go/src/firmware/domain/repositories"
+)
+
+// firmwareColumns son las columnas que se leen en todas las consultas de firmware
+const firmwareColumns = `idFirmware, version, hardware, sha256, size, signature, file_path, created_at`
+
+// MySQLFirmwareRepository implementa FirmwareRepository usando MySQL
+type MySQLFirmwareRepository struct {
+	db *sql.DB
+}
+
+// NewMySQLFirmwareRepository crea una nueva instancia de MySQLFirmwareRepository
+func NewMySQLFirmwareRepository(db *sql.DB) repositories.FirmwareRepository {
+	return &MySQLFirmwareRepository{
+		db: db,
+	}
+}
+
+// Create inserta una nueva imagen de firmware
+func (r *MySQLFirmwareRepository) Create(ctx context.Context, firmware *entities.Firmware) (*entities.Firmware, error) {
+	query := `INSERT INTO firmware_images (version, hardware, sha256, size, signature, file_path, created_at)
+              VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	result, err := r.db.ExecContext(ctx, query, firmware.Version, firmware.Hardware, firmware.SHA256,
+		firmware.Size, firmware.Signature, firmware.FilePath, firmware.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	firmware.ID = int(id)
+
+	return firmware, nil
+}
+
+// FindByID busca una imagen de firmware por su ID
+func (r *MySQLFirmwareRepository) FindByID(ctx context.Context, id int) (*entities.Firmware, error) {
+	query := `SELECT ` + firmwareColumns + ` FROM firmware_images WHERE idFirmware = ?`
+
+	return r.findOne(ctx, query, id)
+}
+
+// FindByVersion busca una imagen de firmware por hardware y versión
+func (r *MySQLFirmwareRepository) FindByVersion(ctx context.Context, hardware, version string) (*entities.Firmware, error) {
+	query := `SELECT ` + firmwareColumns + ` FROM firmware_images WHERE hardware = ? AND version = ?`
+
+	return r.findOne(ctx, query, hardware, version)
+}
+
+// FindAll busca todas las imágenes de firmware, de la más reciente a la más antigua
+func (r *MySQLFirmwareRepository) FindAll(ctx context.Context) ([]*entities.Firmware, error) {
+	query := `SELECT ` + firmwareColumns + ` FROM firmware_images ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var firmwares []*entities.Firmware
+
+	for rows.Next() {
+		firmware, err := scanFirmware(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		firmwares = append(firmwares, firmware)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return firmwares, nil
+}
+
+// findOne ejecuta una consulta que devuelve como máximo una imagen de firmware
+func (r *MySQLFirmwareRepository) findOne(ctx context.Context, query string, args ...interface{}) (*entities.Firmware, error) {
+	firmware, err := scanFirmware(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // No error, just no firmware found
+		}
+		return nil, err
+	}
+
+	return firmware, nil
+}
+
+// rowScanner permite escanear tanto *sql.Row como *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanFirmware convierte una fila con las columnas de firmwareColumns en una entidad Firmware
+func scanFirmware(row rowScanner) (*entities.Firmware, error) {
+	var firmware entities.Firmware
+
+	err := row.Scan(
+		&firmware.ID,
+		&firmware.Version,
+		&firmware.Hardware,
+		&firmware.SHA256,
+		&firmware.Size,
+		&firmware.Signature,
+		&firmware.FilePath,
+		&firmware.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &firmware, nil
+}
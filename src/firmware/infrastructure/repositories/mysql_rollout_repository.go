@@ -0,0 +1,158 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"hex_go/src/firmware/domain/entities"
+	"hex_go/src/firmware/domain/repositories"
+)
+
+// MySQLRolloutRepository implementa RolloutRepository usando MySQL
+type MySQLRolloutRepository struct {
+	db *sql.DB
+}
+
+// NewMySQLRolloutRepository crea una nueva instancia de MySQLRolloutRepository
+func NewMySQLRolloutRepository(db *sql.DB) repositories.RolloutRepository {
+	return &MySQLRolloutRepository{
+		db: db,
+	}
+}
+
+// Create inserta un nuevo despliegue junto con su lista de ESP32 en una misma transacción
+func (r *MySQLRolloutRepository) Create(ctx context.Context, rollout *entities.Rollout) (*entities.Rollout, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		`INSERT INTO firmware_rollouts (idFirmware, percentage, status, created_at) VALUES (?, ?, ?, ?)`,
+		rollout.FirmwareID, rollout.Percentage, string(rollout.Status), rollout.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, esp32ID := range rollout.ESP32IDs {
+		_, err := tx.ExecContext(ctx,
+			`INSERT IGNORE INTO firmware_rollout_devices (idRollout, idESP32) VALUES (?, ?)`, id, esp32ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	rollout.ID = int(id)
+
+	return rollout, nil
+}
+
+// FindByID busca un despliegue por su ID
+func (r *MySQLRolloutRepository) FindByID(ctx context.Context, id int) (*entities.Rollout, error) {
+	query := `SELECT idRollout, idFirmware, percentage, status, created_at FROM firmware_rollouts WHERE idRollout = ?`
+
+	rollouts, err := r.findMany(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(rollouts) == 0 {
+		return nil, nil // No error, just no rollout found
+	}
+
+	return rollouts[0], nil
+}
+
+// FindByFirmwareID busca los despliegues de una imagen de firmware
+func (r *MySQLRolloutRepository) FindByFirmwareID(ctx context.Context, firmwareID int) ([]*entities.Rollout, error) {
+	query := `SELECT idRollout, idFirmware, percentage, status, created_at FROM firmware_rollouts
+              WHERE idFirmware = ? ORDER BY created_at DESC`
+
+	return r.findMany(ctx, query, firmwareID)
+}
+
+// FindActiveByHardware busca los despliegues activos de un modelo de hardware, del más reciente al más antiguo
+func (r *MySQLRolloutRepository) FindActiveByHardware(ctx context.Context, hardware string) ([]*entities.Rollout, error) {
+	query := `SELECT r.idRollout, r.idFirmware, r.percentage, r.status, r.created_at
+              FROM firmware_rollouts r
+              JOIN firmware_images f ON r.idFirmware = f.idFirmware
+              WHERE f.hardware = ? AND r.status = 'active'
+              ORDER BY r.created_at DESC, r.idRollout DESC`
+
+	return r.findMany(ctx, query, hardware)
+}
+
+// Update actualiza el porcentaje y el estado de un despliegue
+func (r *MySQLRolloutRepository) Update(ctx context.Context, rollout *entities.Rollout) error {
+	query := `UPDATE firmware_rollouts SET percentage = ?, status = ? WHERE idRollout = ?`
+
+	_, err := r.db.ExecContext(ctx, query, rollout.Percentage, string(rollout.Status), rollout.ID)
+	return err
+}
+
+// findMany ejecuta una consulta de despliegues y completa la lista de ESP32 de cada uno
+func (r *MySQLRolloutRepository) findMany(ctx context.Context, query string, args ...interface{}) ([]*entities.Rollout, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rollouts []*entities.Rollout
+
+	for rows.Next() {
+		var rollout entities.Rollout
+		var status string
+
+		if err := rows.Scan(&rollout.ID, &rollout.FirmwareID, &rollout.Percentage, &status, &rollout.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		rollout.Status = entities.RolloutStatus(status)
+		rollouts = append(rollouts, &rollout)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, rollout := range rollouts {
+		esp32IDs, err := r.findDevices(ctx, rollout.ID)
+		if err != nil {
+			return nil, err
+		}
+		rollout.ESP32IDs = esp32IDs
+	}
+
+	return rollouts, nil
+}
+
+// findDevices busca los ESP32 incluidos explícitamente en un despliegue
+func (r *MySQLRolloutRepository) findDevices(ctx context.Context, rolloutID int) ([]int, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT idESP32 FROM firmware_rollout_devices WHERE idRollout = ?`, rolloutID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	esp32IDs := []int{}
+
+	for rows.Next() {
+		var esp32ID int
+		if err := rows.Scan(&esp32ID); err != nil {
+			return nil, err
+		}
+		esp32IDs = append(esp32IDs, esp32ID)
+	}
+
+	return esp32IDs, rows.Err()
+}
@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"hex_go/src/firmware/domain/entities"
+	"hex_go/src/firmware/domain/repositories"
+)
+
+// MySQLUpdateReportRepository implementa UpdateReportRepository usando MySQL
+type MySQLUpdateReportRepository struct {
+	db *sql.DB
+}
+
+// NewMySQLUpdateReportRepository crea una nueva instancia de MySQLUpdateReportRepository
+func NewMySQLUpdateReportRepository(db *sql.DB) repositories.UpdateReportRepository {
+	return &MySQLUpdateReportRepository{
+		db: db,
+	}
+}
+
+// Create inserta un nuevo resultado de actualización
+func (r *MySQLUpdateReportRepository) Create(ctx context.Context, report *entities.UpdateReport) (*entities.UpdateReport, error) {
+	query := `INSERT INTO firmware_update_reports (idESP32, idFirmware, status, message, created_at)
+              VALUES (?, ?, ?, ?, ?)`
+
+	result, err := r.db.ExecContext(ctx, query, report.ESP32ID, report.FirmwareID,
+		string(report.Status), report.Message, report.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	report.ID = int(id)
+
+	return report, nil
+}
+
+// FindByFirmwareID busca los resultados informados para una imagen, del más reciente al más antiguo
+func (r *MySQLUpdateReportRepository) FindByFirmwareID(ctx context.Context, firmwareID int) ([]*entities.UpdateReport, error) {
+	query := `SELECT idReport, idESP32, idFirmware, status, message, created_at
+              FROM firmware_update_reports WHERE idFirmware = ? ORDER BY created_at DESC, idReport DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, firmwareID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reports []*entities.UpdateReport
+
+	for rows.Next() {
+		var report entities.UpdateReport
+		var status string
+
+		err := rows.Scan(&report.ID, &report.ESP32ID, &report.FirmwareID, &status, &report.Message, &report.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		report.Status = entities.UpdateStatus(status)
+		reports = append(reports, &report)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"hex_go/src/firmware/domain/repositories"
+)
+
+// LocalFirmwareStorage implementa FirmwareStorage guardando los binarios en un directorio local
+type LocalFirmwareStorage struct {
+	dir string
+}
+
+// NewLocalFirmwareStorage crea una nueva instancia de LocalFirmwareStorage
+func NewLocalFirmwareStorage(dir string) (repositories.FirmwareStorage, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &LocalFirmwareStorage{
+		dir: dir,
+	}, nil
+}
+
+// Save guarda el contenido en un archivo nuevo y calcula su tamaño y SHA-256
+func (s *LocalFirmwareStorage) Save(ctx context.Context, name string, content io.Reader) (*repositories.StoredFile, error) {
+	// Prefijo con la hora para no pisar un archivo subido anteriormente con el mismo nombre
+	path := filepath.Join(s.dir, fmt.Sprintf("%d-%s", time.Now().UnixNano(), filepath.Base(name)))
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(file, hash), content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return nil, err
+	}
+
+	return &repositories.StoredFile{
+		Path:   path,
+		Size:   size,
+		SHA256: hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
+
+// Open abre un binario guardado para leerlo
+func (s *LocalFirmwareStorage) Open(path string) (io.ReadSeekCloser, error) {
+	return os.Open(path)
+}
+
+// Remove elimina un binario guardado
+func (s *LocalFirmwareStorage) Remove(path string) error {
+	return os.Remove(path)
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"hex_go/src/users/domain/entities"
+)
+
+// AdminMiddleware middleware que restringe el acceso a usuarios administradores.
+// Debe usarse después de AuthMiddleware, que es quien guarda el rol en el contexto.
+func AdminMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString("role") != entities.RoleAdmin {
+			c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"hex_go/src/esp32/domain/entities"
+)
+
+// DeviceAuthenticator valida las credenciales que envía el firmware de un ESP32
+type DeviceAuthenticator interface {
+	Execute(ctx context.Context, numeroSerie, token string) (*entities.ESP32, error)
+}
+
+// DeviceAuthMiddleware middleware para autenticar dispositivos ESP32.
+// El firmware debe enviar su número de serie en X-Device-Serial y su token en X-Device-Token.
+func DeviceAuthMiddleware(authenticator DeviceAuthenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		numeroSerie := c.GetHeader("X-Device-Serial")
+		token := c.GetHeader("X-Device-Token")
+		if numeroSerie == "" || token == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "X-Device-Serial and X-Device-Token headers are required"})
+			c.Abort()
+			return
+		}
+
+		esp32, err := authenticator.Execute(c, numeroSerie, token)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			c.Abort()
+			return
+		}
+
+		// Guardar el dispositivo en el contexto para uso posterior
+		c.Set("esp32ID", esp32.ID)
+		c.Set("numeroSerie", esp32.NumeroSerie)
+		c.Set("hardwareRevision", esp32.HardwareRevision)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,310 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"hex_go/src/telemetry/domain/entities"
+	"hex_go/src/telemetry/domain/repositories"
+)
+
+// Límites de las lecturas aceptadas en un mismo envío
+const (
+	MaxReadingsPerRequest = 100
+	MaxReadingsPerBatch   = 5000
+	maxClockSkew          = time.Minute
+)
+
+// HeartbeatRecorder registra que un ESP32 sigue en línea
+type HeartbeatRecorder interface {
+	Execute(ctx context.Context, esp32ID int) error
+}
+
+// ReadingInput representa una lectura tal como la envía el firmware, por HTTP o por MQTT.
+// El sensor se identifica por sensor_id o, si el ESP32 tiene un solo sensor de ese tipo, por sensor_type.
+type ReadingInput struct {
+	SensorID   *int       `json:"sensor_id,omitempty"`
+	SensorType string     `json:"sensor_type"`
+	Value      *float64   `json:"value"`
+	Alarm      *bool      `json:"alarm"`       // Si se omite se evalúa con los umbrales configurados
+	RecordedAt *time.Time `json:"recorded_at"` // Si se omite se usa la hora de recepción
+	// Resistencia medida (Rs, en kΩ) por los sensores de gas; si se omite value, la API la
+	// convierte en ppm con la última calibración del sensor
+	Raw *float64 `json:"raw,omitempty"`
+	// Número de secuencia asignado por el firmware; las lecturas con una secuencia ya
+	// recibida se descartan, lo que permite reenviar un lote sin duplicar datos
+	Seq *uint64 `json:"seq,omitempty"`
+}
+
+// IngestResult resume el procesamiento de un envío de lecturas
+type IngestResult struct {
+	Accepted   int      `json:"accepted"`
+	Duplicates int      `json:"duplicates"` // Lecturas descartadas por tener una secuencia ya recibida
+	Alarms     []string `json:"alarms"`     // Tipos de los sensores que quedaron en alarma
+}
+
+// IngestReadingsUseCase implementa el caso de uso para registrar lecturas de sensores y
+// actualizar el estado de alarma que consulta el módulo de alertas
+type IngestReadingsUseCase struct {
+	readingRepository     repositories.ReadingRepository
+	sensorRepository      repositories.SensorRepository
+	sensorStateRepository repositories.SensorStateRepository
+	thresholdRepository   repositories.ThresholdRepository
+	heartbeatRecorder     HeartbeatRecorder
+}
+
+// NewIngestReadingsUseCase crea una nueva instancia de IngestReadingsUseCase
+func NewIngestReadingsUseCase(
+	readingRepo repositories.ReadingRepository,
+	sensorRepo repositories.SensorRepository,
+	sensorStateRepo repositories.SensorStateRepository,
+	thresholdRepo repositories.ThresholdRepository,
+	heartbeatRecorder HeartbeatRecorder,
+) *IngestReadingsUseCase {
+	return &IngestReadingsUseCase{
+		readingRepository:     readingRepo,
+		sensorRepository:      sensorRepo,
+		sensorStateRepository: sensorStateRepo,
+		thresholdRepository:   thresholdRepo,
+		heartbeatRecorder:     heartbeatRecorder,
+	}
+}
+
+// Execute ejecuta el caso de uso para las lecturas en tiempo real
+func (uc *IngestReadingsUseCase) Execute(ctx context.Context, esp32ID int, inputs []ReadingInput) (*IngestResult, error) {
+	return uc.ingest(ctx, esp32ID, inputs, MaxReadingsPerRequest)
+}
+
+// ExecuteBatch ejecuta el caso de uso para un lote de lecturas acumuladas mientras el ESP32
+// estuvo sin conexión. Las lecturas pueden llegar desordenadas o repetidas.
+func (uc *IngestReadingsUseCase) ExecuteBatch(ctx context.Context, esp32ID int, inputs []ReadingInput) (*IngestResult, error) {
+	return uc.ingest(ctx, esp32ID, inputs, MaxReadingsPerBatch)
+}
+
+// ingest valida y guarda las lecturas y actualiza el estado de alarma de cada sensor
+// según las marcas de tiempo del ESP32, no según el momento de llegada
+func (uc *IngestReadingsUseCase) ingest(ctx context.Context, esp32ID int, inputs []ReadingInput, maxReadings int) (*IngestResult, error) {
+	if len(inputs) == 0 {
+		return nil, errors.New("at least one reading is required")
+	}
+	if len(inputs) > maxReadings {
+		return nil, fmt.Errorf("at most %d readings are accepted per request", maxReadings)
+	}
+
+	sensors, err := uc.sensorRepository.FindByESP32ID(ctx, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+	thresholds, err := uc.thresholdRepository.FindByESP32ID(ctx, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	readings := make([]*entities.Reading, 0, len(inputs))
+	seen := make(map[uint64]bool)
+	duplicates := 0
+
+	for i, input := range inputs {
+		sensor, err := resolveSensor(sensors, input)
+		if err != nil {
+			return nil, fmt.Errorf("reading %d: %w", i, err)
+		}
+		value := input.Value
+		if value == nil && input.Raw != nil {
+			if ppm, ok := sensor.ToPPM(*input.Raw); ok {
+				value = &ppm
+			} else if input.Alarm == nil {
+				return nil, fmt.Errorf("reading %d: sensor %d has no calibration to convert raw readings, value or alarm is required", i, sensor.ID)
+			}
+		}
+		if value == nil && input.Alarm == nil {
+			return nil, fmt.Errorf("reading %d: value, raw or alarm is required", i)
+		}
+
+		recordedAt := now
+		if input.RecordedAt != nil {
+			if input.RecordedAt.After(now.Add(maxClockSkew)) {
+				return nil, fmt.Errorf("reading %d: recorded_at is in the future", i)
+			}
+			recordedAt = *input.RecordedAt
+		}
+
+		// Secuencias repetidas dentro del mismo envío
+		if input.Seq != nil {
+			if seen[*input.Seq] {
+				duplicates++
+				continue
+			}
+			seen[*input.Seq] = true
+		}
+
+		alarm := false
+		if input.Alarm != nil {
+			alarm = *input.Alarm
+		} else if threshold, ok := thresholds[sensor.SensorType]; ok {
+			alarm = threshold.Exceeds(*value)
+		}
+
+		reading := entities.NewReading(esp32ID, sensor, value, alarm, recordedAt)
+		reading.Raw = input.Raw
+		reading.Seq = input.Seq
+		readings = append(readings, reading)
+	}
+
+	// Momento de la última lectura guardada de cada sensor, antes de agregar las nuevas
+	lastRecorded, err := uc.readingRepository.FindLatestRecordedAt(ctx, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// El repositorio descarta las secuencias recibidas en envíos anteriores
+	inserted, err := uc.readingRepository.CreateMany(ctx, readings)
+	if err != nil {
+		return nil, err
+	}
+	duplicates += len(readings) - inserted
+
+	result := &IngestResult{Accepted: inserted, Duplicates: duplicates, Alarms: []string{}}
+	states := summarizeSensorStates(readings, sensors, lastRecorded)
+	var episodes []*entities.AlarmEpisode
+	for _, sensor := range sensors {
+		state, ok := states[sensor.ID]
+		if !ok {
+			continue
+		}
+		if state.alarm && !containsString(result.Alarms, sensor.SensorType) {
+			result.Alarms = append(result.Alarms, sensor.SensorType)
+		}
+		for _, episode := range state.episodes {
+			episode.ESP32ID = esp32ID
+			episodes = append(episodes, episode)
+		}
+
+		// Las lecturas anteriores a la última conocida no cambian el estado actual
+		if last, ok := lastRecorded[sensor.ID]; ok && !state.recordedAt.After(last) {
+			continue
+		}
+		if err := uc.sensorStateRepository.UpdateState(ctx, sensor.ID, state.alarm, state.since); err != nil {
+			return nil, err
+		}
+	}
+
+	// Las alarmas que empezaron y terminaron dentro del envío no quedan en el estado actual
+	if len(episodes) > 0 {
+		if err := uc.sensorStateRepository.CreateAlarmEpisodes(ctx, episodes); err != nil {
+			return nil, err
+		}
+	}
+
+	// Una lectura también demuestra que el ESP32 está en línea
+	if err := uc.heartbeatRecorder.Execute(ctx, esp32ID); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// sensorState es el estado de un sensor según la última lectura de un envío
+type sensorState struct {
+	alarm      bool
+	recordedAt time.Time // Momento de la última lectura
+	since      time.Time // Momento en que empezó la racha de alarma vigente
+	// Rachas de alarma que terminaron dentro del envío
+	episodes []*entities.AlarmEpisode
+}
+
+// summarizeSensorStates recorre las lecturas en orden cronológico y calcula el estado final
+// de cada sensor, el momento en que se activó su alarma y las rachas de alarma que terminaron.
+// Si el envío es posterior a la última lectura guardada, continúa la alarma vigente del sensor.
+func summarizeSensorStates(readings []*entities.Reading, sensors []*entities.Sensor, lastRecorded map[int]time.Time) map[int]sensorState {
+	ordered := make([]*entities.Reading, len(readings))
+	copy(ordered, readings)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].RecordedAt.Before(ordered[j].RecordedAt)
+	})
+
+	alarmSince := make(map[int]*time.Time, len(sensors))
+	for _, sensor := range sensors {
+		alarmSince[sensor.ID] = sensor.AlarmSince
+	}
+
+	states := make(map[int]sensorState)
+	for _, reading := range ordered {
+		state, ok := states[reading.SensorID]
+		if !ok {
+			last, known := lastRecorded[reading.SensorID]
+			if since := alarmSince[reading.SensorID]; since != nil && (!known || reading.RecordedAt.After(last)) {
+				state.alarm = true
+				state.since = *since
+			}
+		}
+		switch {
+		case reading.Alarm && !state.alarm:
+			state.since = reading.RecordedAt
+		case !reading.Alarm && state.alarm:
+			state.episodes = append(state.episodes, &entities.AlarmEpisode{
+				SensorID:  reading.SensorID,
+				StartedAt: state.since,
+				EndedAt:   reading.RecordedAt,
+			})
+		}
+		if !reading.Alarm {
+			state.since = reading.RecordedAt
+		}
+		state.alarm = reading.Alarm
+		state.recordedAt = reading.RecordedAt
+		states[reading.SensorID] = state
+	}
+
+	return states
+}
+
+// resolveSensor identifica el sensor instalado al que corresponde una lectura
+func resolveSensor(sensors []*entities.Sensor, input ReadingInput) (*entities.Sensor, error) {
+	if input.SensorID != nil {
+		for _, sensor := range sensors {
+			if sensor.ID != *input.SensorID {
+				continue
+			}
+			if input.SensorType != "" && input.SensorType != sensor.SensorType {
+				return nil, fmt.Errorf("sensor %d is a %s sensor, not %s", sensor.ID, sensor.SensorType, input.SensorType)
+			}
+			return sensor, nil
+		}
+		return nil, fmt.Errorf("unknown sensor_id %d", *input.SensorID)
+	}
+
+	if input.SensorType == "" {
+		return nil, errors.New("sensor_type or sensor_id is required")
+	}
+
+	var match *entities.Sensor
+	for _, sensor := range sensors {
+		if sensor.SensorType != input.SensorType {
+			continue
+		}
+		if match != nil {
+			return nil, fmt.Errorf("the ESP32 has several %s sensors, sensor_id is required", input.SensorType)
+		}
+		match = sensor
+	}
+	if match == nil {
+		return nil, fmt.Errorf("the ESP32 has no %s sensor", input.SensorType)
+	}
+
+	return match, nil
+}
+
+// containsString indica si la lista contiene el valor
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"hex_go/src/telemetry/domain/entities"
+)
+
+func TestSummarizeSensorStatesRecordsEndedAlarms(t *testing.T) {
+	const sensorID = 7
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 3, 14, hour, minute, 0, 0, time.UTC)
+	}
+	reading := func(minute int, alarm bool) *entities.Reading {
+		return &entities.Reading{SensorID: sensorID, Alarm: alarm, RecordedAt: at(2, minute)}
+	}
+	type episode struct{ start, end time.Time }
+
+	tests := []struct {
+		name         string
+		readings     []*entities.Reading
+		alarmSince   *time.Time
+		lastRecorded map[int]time.Time
+		wantAlarm    bool
+		wantSince    time.Time
+		wantEpisodes []episode
+	}{
+		{
+			name:         "alarma que empieza y termina dentro del lote",
+			readings:     []*entities.Reading{reading(0, true), reading(5, true), reading(10, false), reading(20, false)},
+			wantSince:    at(2, 20),
+			wantEpisodes: []episode{{at(2, 0), at(2, 10)}},
+		},
+		{
+			name:         "lecturas desordenadas con dos rachas y una vigente",
+			readings:     []*entities.Reading{reading(30, true), reading(10, false), reading(0, true), reading(20, false), reading(15, true)},
+			wantAlarm:    true,
+			wantSince:    at(2, 30),
+			wantEpisodes: []episode{{at(2, 0), at(2, 10)}, {at(2, 15), at(2, 20)}},
+		},
+		{
+			name:         "alarma vigente que termina en el lote",
+			readings:     []*entities.Reading{reading(0, true), reading(10, false)},
+			alarmSince:   timePtr(at(1, 0)),
+			lastRecorded: map[int]time.Time{sensorID: at(1, 30)},
+			wantSince:    at(2, 10),
+			wantEpisodes: []episode{{at(1, 0), at(2, 10)}},
+		},
+		{
+			name:         "alarma vigente que sigue activa",
+			readings:     []*entities.Reading{reading(0, true)},
+			alarmSince:   timePtr(at(1, 0)),
+			wantAlarm:    true,
+			wantSince:    at(1, 0),
+			wantEpisodes: nil,
+		},
+		{
+			name:         "lote anterior a la última lectura no continúa la alarma vigente",
+			readings:     []*entities.Reading{reading(0, true), reading(10, false)},
+			alarmSince:   timePtr(at(1, 0)),
+			lastRecorded: map[int]time.Time{sensorID: at(3, 0)},
+			wantSince:    at(2, 10),
+			wantEpisodes: []episode{{at(2, 0), at(2, 10)}},
+		},
+		{
+			name:      "sin alarmas",
+			readings:  []*entities.Reading{reading(0, false), reading(10, false)},
+			wantSince: at(2, 10),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sensors := []*entities.Sensor{{ID: sensorID, SensorType: "KY_026", AlarmSince: tt.alarmSince}}
+			state := summarizeSensorStates(tt.readings, sensors, tt.lastRecorded)[sensorID]
+
+			if state.alarm != tt.wantAlarm || !state.since.Equal(tt.wantSince) {
+				t.Errorf("state = alarm %v since %v, want alarm %v since %v", state.alarm, state.since, tt.wantAlarm, tt.wantSince)
+			}
+			if len(state.episodes) != len(tt.wantEpisodes) {
+				t.Fatalf("got %d episodes, want %d", len(state.episodes), len(tt.wantEpisodes))
+			}
+			for i, want := range tt.wantEpisodes {
+				got := state.episodes[i]
+				if got.SensorID != sensorID || !got.StartedAt.Equal(want.start) || !got.EndedAt.Equal(want.end) {
+					t.Errorf("episode %d = sensor %d %v - %v, want %v - %v", i, got.SensorID, got.StartedAt, got.EndedAt, want.start, want.end)
+				}
+			}
+		})
+	}
+}
+
+func timePtr(v time.Time) *time.Time { return &v }
@@ -0,0 +1,15 @@
+package entities
+
+import (
+	"time"
+)
+
+// AlarmEpisode es una racha de alarma de un sensor que empezó y terminó dentro de un mismo
+// envío, por ejemplo mientras el ESP32 estuvo sin conexión. El módulo de alertas la registra
+// como una alerta ya despejada aunque el estado actual del sensor no la refleje.
+type AlarmEpisode struct {
+	ESP32ID   int       `json:"esp32_id"`
+	SensorID  int       `json:"sensor_id"`
+	StartedAt time.Time `json:"started_at"` // Momento de la primera lectura en alarma
+	EndedAt   time.Time `json:"ended_at"`   // Momento de la primera lectura sin alarma posterior
+}
@@ -0,0 +1,32 @@
+package entities
+
+import (
+	"time"
+)
+
+// Reading representa una lectura de un sensor enviada por un ESP32
+type Reading struct {
+	ID         int       `json:"id"`
+	ESP32ID    int       `json:"esp32_id"`
+	SensorID   int       `json:"sensor_id"`
+	SensorType string    `json:"sensor_type"`
+	Value      *float64  `json:"value"`         // Nulo para sensores digitales que solo informan la alarma
+	Raw        *float64  `json:"raw,omitempty"` // Resistencia medida por los sensores de gas, en kΩ
+	Alarm      bool      `json:"alarm"`
+	Seq        *uint64   `json:"seq,omitempty"` // Número de secuencia del firmware, usado para descartar reenvíos
+	RecordedAt time.Time `json:"recorded_at"`   // Momento de la medición según el ESP32
+	ReceivedAt time.Time `json:"received_at"`
+}
+
+// NewReading crea una nueva instancia de Reading
+func NewReading(esp32ID int, sensor *Sensor, value *float64, alarm bool, recordedAt time.Time) *Reading {
+	return &Reading{
+		ESP32ID:    esp32ID,
+		SensorID:   sensor.ID,
+		SensorType: sensor.SensorType,
+		Value:      value,
+		Alarm:      alarm,
+		RecordedAt: recordedAt,
+		ReceivedAt: time.Now(),
+	}
+}
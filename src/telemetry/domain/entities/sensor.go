@@ -0,0 +1,28 @@
+package entities
+
+import (
+	"math"
+	"time"
+)
+
+// Sensor representa un sensor instalado en un ESP32 que puede reportar lecturas
+type Sensor struct {
+	ID         int
+	SensorType string
+	// Resistencia de referencia de la última calibración y curva del tipo de sensor, usadas para
+	// convertir en ppm las lecturas crudas de los sensores de gas; nulas si no aplican
+	R0        *float64
+	PPMCurveA *float64
+	PPMCurveB *float64
+	// Momento en que se activó la alarma vigente del sensor; nulo si no está en alarma
+	AlarmSince *time.Time
+}
+
+// ToPPM convierte la resistencia medida (Rs, en kΩ) en ppm; devuelve false si el sensor
+// no tiene calibración o curva de conversión
+func (s *Sensor) ToPPM(rs float64) (float64, bool) {
+	if s.R0 == nil || s.PPMCurveA == nil || s.PPMCurveB == nil || rs <= 0 || *s.R0 <= 0 {
+		return 0, false
+	}
+	return *s.PPMCurveA * math.Pow(rs / *s.R0, *s.PPMCurveB), true
+}
@@ -0,0 +1,12 @@
+package entities
+
+// Threshold define el rango de valores normales de un sensor; un límite nulo no se evalúa
+type Threshold struct {
+	Min *float64
+	Max *float64
+}
+
+// Exceeds indica si una lectura queda fuera del rango normal
+func (t Threshold) Exceeds(value float64) bool {
+	return (t.Min != nil && value < *t.Min) || (t.Max != nil && value > *t.Max)
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"hex_go/src/telemetry/domain/entities"
+)
+
+// ReadingRepository define las operaciones sobre el historial de lecturas de los sensores
+type ReadingRepository interface {
+	// CreateMany guarda las lecturas, descarta las que repiten una secuencia ya guardada
+	// del mismo ESP32 y devuelve cuántas se insertaron
+	CreateMany(ctx context.Context, readings []*entities.Reading) (int, error)
+	// FindLatestRecordedAt devuelve el momento de la última lectura guardada de cada sensor, por ID de sensor
+	FindLatestRecordedAt(ctx context.Context, esp32ID int) (map[int]time.Time, error)
+}
@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"context"
+
+	"hex_go/src/telemetry/domain/entities"
+)
+
+// SensorRepository obtiene los sensores instalados en un ESP32
+type SensorRepository interface {
+	FindByESP32ID(ctx context.Context, esp32ID int) ([]*entities.Sensor, error)
+}
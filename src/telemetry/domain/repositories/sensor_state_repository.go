@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"hex_go/src/telemetry/domain/entities"
+)
+
+// SensorStateRepository define las operaciones sobre el estado de alarma de cada sensor de un ESP32
+type SensorStateRepository interface {
+	// UpdateState activa o desactiva la alarma de un sensor; la fecha de activación solo
+	// cambia cuando la alarma pasa de inactiva a activa
+	UpdateState(ctx context.Context, sensorID int, active bool, at time.Time) error
+	// CreateAlarmEpisodes guarda las rachas de alarma ya terminadas; las que ya estaban
+	// guardadas, por ejemplo porque el ESP32 reenvió el lote, se ignoran
+	CreateAlarmEpisodes(ctx context.Context, episodes []*entities.AlarmEpisode) error
+}
@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"context"
+
+	"hex_go/src/telemetry/domain/entities"
+)
+
+// ThresholdRepository obtiene los umbrales configurados para los sensores de un ESP32
+type ThresholdRepository interface {
+	FindByESP32ID(ctx context.Context, esp32ID int) (map[string]entities.Threshold, error)
+}
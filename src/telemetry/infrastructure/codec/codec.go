@@ -0,0 +1,109 @@
+package codec
+
+import (
+	"encoding/json"
+	"errors"
+	"mime"
+
+	"github.com/fxamacker/cbor/v2"
+	"hex_go/src/telemetry/application/services"
+)
+
+// Tipos de contenido aceptados en los envíos de los dispositivos
+const (
+	ContentTypeJSON     = "application/json"
+	ContentTypeCBOR     = "application/cbor"
+	ContentTypeProtobuf = "application/x-protobuf"
+)
+
+// ErrUnsupportedContentType se devuelve cuando el cuerpo no está en un formato soportado
+var ErrUnsupportedContentType = errors.New("unsupported content type, use application/json, application/cbor or application/x-protobuf")
+
+// cborEncMode codifica las fechas en RFC 3339 con fracciones de segundo para no perder precisión
+var cborEncMode, _ = cbor.EncOptions{Time: cbor.TimeRFC3339Nano}.EncMode()
+
+// ReadingBatch es el contenido de un envío de lecturas, común a JSON, CBOR y Protobuf
+type ReadingBatch struct {
+	Readings []services.ReadingInput `json:"readings"`
+	Token    string                  `json:"token,omitempty"` // Solo se usa por MQTT
+}
+
+// Heartbeat es el contenido de un heartbeat, común a JSON, CBOR y Protobuf
+type Heartbeat struct {
+	Token string `json:"token,omitempty"` // Solo se usa por MQTT
+}
+
+// DecodeReadingBatch decodifica un envío de lecturas según su tipo de contenido
+func DecodeReadingBatch(contentType string, body []byte) (*ReadingBatch, error) {
+	var batch ReadingBatch
+	if err := decode(contentType, body, &batch, unmarshalReadingBatch); err != nil {
+		return nil, err
+	}
+	return &batch, nil
+}
+
+// DecodeHeartbeat decodifica un heartbeat según su tipo de contenido; un cuerpo vacío es válido
+func DecodeHeartbeat(contentType string, body []byte) (*Heartbeat, error) {
+	var heartbeat Heartbeat
+	if len(body) == 0 {
+		return &heartbeat, nil
+	}
+	if err := decode(contentType, body, &heartbeat, unmarshalHeartbeat); err != nil {
+		return nil, err
+	}
+	return &heartbeat, nil
+}
+
+// EncodeReadingBatch codifica un envío de lecturas en el formato indicado
+func EncodeReadingBatch(contentType string, batch *ReadingBatch) ([]byte, error) {
+	switch mediaType(contentType) {
+	case ContentTypeJSON:
+		return json.Marshal(batch)
+	case ContentTypeCBOR:
+		return cborEncMode.Marshal(batch)
+	case ContentTypeProtobuf:
+		return marshalReadingBatch(batch), nil
+	}
+	return nil, ErrUnsupportedContentType
+}
+
+// EncodeHeartbeat codifica un heartbeat en el formato indicado
+func EncodeHeartbeat(contentType string, heartbeat *Heartbeat) ([]byte, error) {
+	switch mediaType(contentType) {
+	case ContentTypeJSON:
+		return json.Marshal(heartbeat)
+	case ContentTypeCBOR:
+		return cborEncMode.Marshal(heartbeat)
+	case ContentTypeProtobuf:
+		return marshalHeartbeat(heartbeat), nil
+	}
+	return nil, ErrUnsupportedContentType
+}
+
+// decode aplica el decodificador que corresponde al tipo de contenido
+func decode[T any](contentType string, body []byte, target *T, unmarshalProtobuf func([]byte, *T) error) error {
+	switch mediaType(contentType) {
+	case ContentTypeJSON:
+		return json.Unmarshal(body, target)
+	case ContentTypeCBOR:
+		return cbor.Unmarshal(body, target)
+	case ContentTypeProtobuf:
+		return unmarshalProtobuf(body, target)
+	}
+	return ErrUnsupportedContentType
+}
+
+// mediaType obtiene el tipo de contenido sin parámetros; un tipo vacío se trata como JSON
+func mediaType(contentType string) string {
+	if contentType == "" {
+		return ContentTypeJSON
+	}
+	parsed, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	if parsed == "application/protobuf" {
+		return ContentTypeProtobuf
+	}
+	return parsed
+}
@@ -0,0 +1,254 @@
+package codec
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"google.golang.org/protobuf/encoding/protowire"
+	"hex_go/src/telemetry/application/services"
+)
+
+var contentTypes = []string{ContentTypeJSON, ContentTypeCBOR, ContentTypeProtobuf}
+
+func intPtr(v int) *int              { return &v }
+func floatPtr(v float64) *float64    { return &v }
+func boolPtr(v bool) *bool           { return &v }
+func uint64Ptr(v uint64) *uint64     { return &v }
+func timePtr(v time.Time) *time.Time { return &v }
+
+// testBatch cubre todos los campos de una lectura, con un sensor_id negativo y una fecha con milisegundos
+func testBatch() *ReadingBatch {
+	return &ReadingBatch{
+		Token: "device-token",
+		Readings: []services.ReadingInput{
+			{
+				SensorID:   intPtr(-3),
+				SensorType: "MQ_2",
+				Value:      floatPtr(412.75),
+				Alarm:      boolPtr(true),
+				RecordedAt: timePtr(time.Date(2026, 3, 14, 2, 0, 0, 123_000_000, time.UTC)),
+				Raw:        floatPtr(8.5),
+				Seq:        uint64Ptr(1 << 40),
+			},
+			{
+				SensorType: "KY_026",
+				Alarm:      boolPtr(false),
+				RecordedAt: timePtr(time.Date(2026, 3, 14, 2, 10, 0, 999_000_000, time.UTC)),
+			},
+			{
+				SensorID: intPtr(7),
+				Value:    floatPtr(-12.5),
+			},
+		},
+	}
+}
+
+func TestReadingBatchRoundTrip(t *testing.T) {
+	jsonBody, err := EncodeReadingBatch(ContentTypeJSON, testBatch())
+	if err != nil {
+		t.Fatalf("encode JSON: %v", err)
+	}
+	want, err := DecodeReadingBatch(ContentTypeJSON, jsonBody)
+	if err != nil {
+		t.Fatalf("decode JSON: %v", err)
+	}
+	assertBatchEqual(t, want, testBatch())
+
+	for _, contentType := range contentTypes {
+		t.Run(contentType, func(t *testing.T) {
+			body, err := EncodeReadingBatch(contentType, testBatch())
+			if err != nil {
+				t.Fatalf("encode: %v", err)
+			}
+			got, err := DecodeReadingBatch(contentType, body)
+			if err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			assertBatchEqual(t, got, want)
+		})
+	}
+}
+
+func TestHeartbeatRoundTrip(t *testing.T) {
+	want := &Heartbeat{Token: "device-token"}
+
+	for _, contentType := range contentTypes {
+		t.Run(contentType, func(t *testing.T) {
+			body, err := EncodeHeartbeat(contentType, want)
+			if err != nil {
+				t.Fatalf("encode: %v", err)
+			}
+			got, err := DecodeHeartbeat(contentType, body)
+			if err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if got.Token != want.Token {
+				t.Errorf("token = %q, want %q", got.Token, want.Token)
+			}
+
+			empty, err := DecodeHeartbeat(contentType, nil)
+			if err != nil || empty.Token != "" {
+				t.Errorf("empty body = %+v, %v, want an empty heartbeat", empty, err)
+			}
+		})
+	}
+}
+
+func TestDecodeIgnoresUnknownFields(t *testing.T) {
+	const jsonBody = `{
+		"token": "device-token",
+		"firmware": "1.4.2",
+		"readings": [
+			{"sensor_id": -3, "sensor_type": "MQ_2", "value": 412.75, "alarm": true,
+			 "recorded_at": "2026-03-14T02:00:00.123Z", "raw": 8.5, "seq": 1099511627776, "rssi": -71},
+			{"sensor_type": "KY_026", "alarm": false, "recorded_at": "2026-03-14T02:10:00.999Z", "extra": {"a": [1, 2]}},
+			{"sensor_id": 7, "value": -12.5}
+		]
+	}`
+	want := testBatch()
+
+	// El CBOR se arma desde el del lote para conservar los tipos enteros y se le agregan claves
+	encoded, err := EncodeReadingBatch(ContentTypeCBOR, want)
+	if err != nil {
+		t.Fatalf("encode CBOR: %v", err)
+	}
+	var generic map[string]interface{}
+	if err := cbor.Unmarshal(encoded, &generic); err != nil {
+		t.Fatalf("decode CBOR: %v", err)
+	}
+	generic["firmware"] = "1.4.2"
+	for _, reading := range generic["readings"].([]interface{}) {
+		reading.(map[interface{}]interface{})["rssi"] = -71
+	}
+	cborBody, err := cbor.Marshal(generic)
+	if err != nil {
+		t.Fatalf("encode CBOR: %v", err)
+	}
+
+	// Campos desconocidos de cada tipo de cable, en la lectura y en el lote
+	var protobufBody []byte
+	for i := range want.Readings {
+		reading := marshalReading(&want.Readings[i])
+		reading = protowire.AppendTag(reading, 20, protowire.VarintType)
+		reading = protowire.AppendVarint(reading, 71)
+		reading = protowire.AppendTag(reading, 21, protowire.Fixed32Type)
+		reading = protowire.AppendFixed32(reading, 42)
+		protobufBody = protowire.AppendTag(protobufBody, batchReadingsField, protowire.BytesType)
+		protobufBody = protowire.AppendBytes(protobufBody, reading)
+	}
+	protobufBody = protowire.AppendTag(protobufBody, 15, protowire.BytesType)
+	protobufBody = protowire.AppendString(protobufBody, "1.4.2")
+	protobufBody = protowire.AppendTag(protobufBody, batchTokenField, protowire.BytesType)
+	protobufBody = protowire.AppendString(protobufBody, want.Token)
+	protobufBody = protowire.AppendTag(protobufBody, 16, protowire.Fixed64Type)
+	protobufBody = protowire.AppendFixed64(protobufBody, 7)
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        []byte
+	}{
+		{"JSON con claves desconocidas", ContentTypeJSON, []byte(jsonBody)},
+		{"CBOR con claves desconocidas", ContentTypeCBOR, cborBody},
+		{"Protobuf con campos desconocidos", ContentTypeProtobuf, protobufBody},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeReadingBatch(tt.contentType, tt.body)
+			if err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			assertBatchEqual(t, got, want)
+		})
+	}
+
+	heartbeat := protowire.AppendTag(nil, 9, protowire.VarintType)
+	heartbeat = protowire.AppendVarint(heartbeat, 1)
+	heartbeat = append(heartbeat, marshalHeartbeat(&Heartbeat{Token: "device-token"})...)
+	got, err := DecodeHeartbeat(ContentTypeProtobuf, heartbeat)
+	if err != nil || got.Token != "device-token" {
+		t.Errorf("protobuf heartbeat with unknown field = %+v, %v", got, err)
+	}
+}
+
+func TestDecodeRejectsTruncatedInput(t *testing.T) {
+	for _, contentType := range contentTypes {
+		body, err := EncodeReadingBatch(contentType, testBatch())
+		if err != nil {
+			t.Fatalf("encode %s: %v", contentType, err)
+		}
+
+		tests := []struct {
+			name string
+			body []byte
+		}{
+			{"sin el último byte", body[:len(body)-1]},
+			{"cortado dentro de la primera lectura", body[:5]},
+		}
+
+		for _, tt := range tests {
+			t.Run(contentType+"/"+tt.name, func(t *testing.T) {
+				if _, err := DecodeReadingBatch(contentType, tt.body); err == nil {
+					t.Fatal("expected an error for truncated input")
+				}
+			})
+		}
+	}
+
+	body := marshalHeartbeat(&Heartbeat{Token: "device-token"})
+	_, err := DecodeHeartbeat(ContentTypeProtobuf, body[:len(body)-1])
+	if !errors.Is(err, errMalformedProtobuf) {
+		t.Errorf("truncated protobuf heartbeat error = %v, want %v", err, errMalformedProtobuf)
+	}
+}
+
+func TestUnsupportedContentType(t *testing.T) {
+	if _, err := DecodeReadingBatch("application/xml", []byte("<readings/>")); !errors.Is(err, ErrUnsupportedContentType) {
+		t.Errorf("decode error = %v, want %v", err, ErrUnsupportedContentType)
+	}
+	if _, err := EncodeHeartbeat("text/plain", &Heartbeat{}); !errors.Is(err, ErrUnsupportedContentType) {
+		t.Errorf("encode error = %v, want %v", err, ErrUnsupportedContentType)
+	}
+}
+
+// assertBatchEqual compara dos lotes campo por campo; las fechas se comparan como instantes
+func assertBatchEqual(t *testing.T, got, want *ReadingBatch) {
+	t.Helper()
+	if got.Token != want.Token {
+		t.Errorf("token = %q, want %q", got.Token, want.Token)
+	}
+	if len(got.Readings) != len(want.Readings) {
+		t.Fatalf("got %d readings, want %d", len(got.Readings), len(want.Readings))
+	}
+	for i := range want.Readings {
+		g, w := got.Readings[i], want.Readings[i]
+		if g.SensorType != w.SensorType {
+			t.Errorf("reading %d: sensor_type = %q, want %q", i, g.SensorType, w.SensorType)
+		}
+		assertPtrEqual(t, i, "sensor_id", g.SensorID, w.SensorID)
+		assertPtrEqual(t, i, "value", g.Value, w.Value)
+		assertPtrEqual(t, i, "alarm", g.Alarm, w.Alarm)
+		assertPtrEqual(t, i, "raw", g.Raw, w.Raw)
+		assertPtrEqual(t, i, "seq", g.Seq, w.Seq)
+		switch {
+		case (g.RecordedAt == nil) != (w.RecordedAt == nil):
+			t.Errorf("reading %d: recorded_at = %v, want %v", i, g.RecordedAt, w.RecordedAt)
+		case g.RecordedAt != nil && !g.RecordedAt.Equal(*w.RecordedAt):
+			t.Errorf("reading %d: recorded_at = %v, want %v", i, *g.RecordedAt, *w.RecordedAt)
+		}
+	}
+}
+
+func assertPtrEqual[T comparable](t *testing.T, i int, field string, got, want *T) {
+	t.Helper()
+	switch {
+	case got == nil && want == nil:
+	case got == nil || want == nil:
+		t.Errorf("reading %d: %s = %v, want %v", i, field, got, want)
+	case *got != *want:
+		t.Errorf("reading %d: %s = %v, want %v", i, field, *got, *want)
+	}
+}
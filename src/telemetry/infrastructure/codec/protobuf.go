@@ -0,0 +1,188 @@
+package codec
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"hex_go/src/telemetry/application/services"
+)
+
+// Números de campo definidos en proto/telemetry.proto
+const (
+	readingSensorTypeField   protowire.Number = 1
+	readingValueField        protowire.Number = 2
+	readingAlarmField        protowire.Number = 3
+	readingRecordedAtMsField protowire.Number = 4
+	readingSeqField          protowire.Number = 5
+	readingSensorIDField     protowire.Number = 6
+	readingRawField          protowire.Number = 7
+
+	batchReadingsField protowire.Number = 1
+	batchTokenField    protowire.Number = 2
+
+	heartbeatTokenField protowire.Number = 1
+)
+
+// errMalformedProtobuf se devuelve cuando el cuerpo no es un mensaje protobuf válido
+var errMalformedProtobuf = errors.New("malformed protobuf message")
+
+// unmarshalReadingBatch decodifica un mensaje ReadingBatch
+func unmarshalReadingBatch(data []byte, batch *ReadingBatch) error {
+	return walkFields(data, func(number protowire.Number, wireType protowire.Type, value []byte) (int, error) {
+		switch {
+		case number == batchReadingsField && wireType == protowire.BytesType:
+			message, n := protowire.ConsumeBytes(value)
+			if n < 0 {
+				return n, nil
+			}
+			var reading services.ReadingInput
+			if err := unmarshalReading(message, &reading); err != nil {
+				return 0, err
+			}
+			batch.Readings = append(batch.Readings, reading)
+			return n, nil
+		case number == batchTokenField && wireType == protowire.BytesType:
+			token, n := protowire.ConsumeString(value)
+			batch.Token = token
+			return n, nil
+		}
+		return protowire.ConsumeFieldValue(number, wireType, value), nil
+	})
+}
+
+// unmarshalReading decodifica un mensaje Reading
+func unmarshalReading(data []byte, reading *services.ReadingInput) error {
+	return walkFields(data, func(number protowire.Number, wireType protowire.Type, value []byte) (int, error) {
+		switch {
+		case number == readingSensorTypeField && wireType == protowire.BytesType:
+			sensorType, n := protowire.ConsumeString(value)
+			reading.SensorType = sensorType
+			return n, nil
+		case number == readingValueField && wireType == protowire.Fixed64Type:
+			bits, n := protowire.ConsumeFixed64(value)
+			measured := math.Float64frombits(bits)
+			reading.Value = &measured
+			return n, nil
+		case number == readingAlarmField && wireType == protowire.VarintType:
+			raw, n := protowire.ConsumeVarint(value)
+			alarm := protowire.DecodeBool(raw)
+			reading.Alarm = &alarm
+			return n, nil
+		case number == readingRecordedAtMsField && wireType == protowire.VarintType:
+			raw, n := protowire.ConsumeVarint(value)
+			recordedAt := time.UnixMilli(int64(raw))
+			reading.RecordedAt = &recordedAt
+			return n, nil
+		case number == readingSeqField && wireType == protowire.VarintType:
+			seq, n := protowire.ConsumeVarint(value)
+			reading.Seq = &seq
+			return n, nil
+		case number == readingSensorIDField && wireType == protowire.VarintType:
+			raw, n := protowire.ConsumeVarint(value)
+			sensorID := int(int32(raw))
+			reading.SensorID = &sensorID
+			return n, nil
+		case number == readingRawField && wireType == protowire.Fixed64Type:
+			bits, n := protowire.ConsumeFixed64(value)
+			raw := math.Float64frombits(bits)
+			reading.Raw = &raw
+			return n, nil
+		}
+		return protowire.ConsumeFieldValue(number, wireType, value), nil
+	})
+}
+
+// unmarshalHeartbeat decodifica un mensaje Heartbeat
+func unmarshalHeartbeat(data []byte, heartbeat *Heartbeat) error {
+	return walkFields(data, func(number protowire.Number, wireType protowire.Type, value []byte) (int, error) {
+		if number == heartbeatTokenField && wireType == protowire.BytesType {
+			token, n := protowire.ConsumeString(value)
+			heartbeat.Token = token
+			return n, nil
+		}
+		return protowire.ConsumeFieldValue(number, wireType, value), nil
+	})
+}
+
+// walkFields recorre los campos de un mensaje; visit devuelve cuántos bytes consumió el valor.
+// Los campos desconocidos se ignoran para que el firmware pueda agregar campos nuevos.
+func walkFields(data []byte, visit func(protowire.Number, protowire.Type, []byte) (int, error)) error {
+	for len(data) > 0 {
+		number, wireType, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return fmt.Errorf("%w: %v", errMalformedProtobuf, protowire.ParseError(n))
+		}
+		data = data[n:]
+
+		n, err := visit(number, wireType, data)
+		if err != nil {
+			return err
+		}
+		if n < 0 {
+			return fmt.Errorf("%w: %v", errMalformedProtobuf, protowire.ParseError(n))
+		}
+		data = data[n:]
+	}
+	return nil
+}
+
+// marshalReadingBatch codifica un mensaje ReadingBatch
+func marshalReadingBatch(batch *ReadingBatch) []byte {
+	var data []byte
+	for i := range batch.Readings {
+		data = protowire.AppendTag(data, batchReadingsField, protowire.BytesType)
+		data = protowire.AppendBytes(data, marshalReading(&batch.Readings[i]))
+	}
+	if batch.Token != "" {
+		data = protowire.AppendTag(data, batchTokenField, protowire.BytesType)
+		data = protowire.AppendString(data, batch.Token)
+	}
+	return data
+}
+
+// marshalReading codifica un mensaje Reading
+func marshalReading(reading *services.ReadingInput) []byte {
+	var data []byte
+	if reading.SensorType != "" {
+		data = protowire.AppendTag(data, readingSensorTypeField, protowire.BytesType)
+		data = protowire.AppendString(data, reading.SensorType)
+	}
+	if reading.Value != nil {
+		data = protowire.AppendTag(data, readingValueField, protowire.Fixed64Type)
+		data = protowire.AppendFixed64(data, math.Float64bits(*reading.Value))
+	}
+	if reading.Alarm != nil {
+		data = protowire.AppendTag(data, readingAlarmField, protowire.VarintType)
+		data = protowire.AppendVarint(data, protowire.EncodeBool(*reading.Alarm))
+	}
+	if reading.RecordedAt != nil {
+		data = protowire.AppendTag(data, readingRecordedAtMsField, protowire.VarintType)
+		data = protowire.AppendVarint(data, uint64(reading.RecordedAt.UnixMilli()))
+	}
+	if reading.Seq != nil {
+		data = protowire.AppendTag(data, readingSeqField, protowire.VarintType)
+		data = protowire.AppendVarint(data, *reading.Seq)
+	}
+	if reading.SensorID != nil {
+		data = protowire.AppendTag(data, readingSensorIDField, protowire.VarintType)
+		data = protowire.AppendVarint(data, uint64(int64(*reading.SensorID)))
+	}
+	if reading.Raw != nil {
+		data = protowire.AppendTag(data, readingRawField, protowire.Fixed64Type)
+		data = protowire.AppendFixed64(data, math.Float64bits(*reading.Raw))
+	}
+	return data
+}
+
+// marshalHeartbeat codifica un mensaje Heartbeat
+func marshalHeartbeat(heartbeat *Heartbeat) []byte {
+	var data []byte
+	if heartbeat.Token != "" {
+		data = protowire.AppendTag(data, heartbeatTokenField, protowire.BytesType)
+		data = protowire.AppendString(data, heartbeat.Token)
+	}
+	return data
+}
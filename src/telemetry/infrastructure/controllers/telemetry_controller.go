@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"hex_go/src/telemetry/application/services"
+	"hex_go/src/telemetry/infrastructure/codec"
+)
+
+// Tamaño máximo del cuerpo de un envío de lecturas
+const (
+	maxReadingsBodySize = 1 << 20
+	maxBatchBodySize    = 8 << 20
+)
+
+// TelemetryController maneja las solicitudes HTTP con las que los ESP32 envían sus lecturas
+type TelemetryController struct {
+	ingestReadingsUseCase *services.IngestReadingsUseCase
+}
+
+// NewTelemetryController crea una nueva instancia de TelemetryController
+func NewTelemetryController(ingestReadingsUseCase *services.IngestReadingsUseCase) *TelemetryController {
+	return &TelemetryController{
+		ingestReadingsUseCase: ingestReadingsUseCase,
+	}
+}
+
+// IngestReadings maneja la solicitud HTTP con la que un ESP32 envía lecturas de sus sensores.
+// El cuerpo puede estar en JSON, CBOR o Protobuf según el Content-Type (ver proto/telemetry.proto).
+func (c *TelemetryController) IngestReadings(ctx *gin.Context) {
+	batch, ok := decodeReadingBatch(ctx, maxReadingsBodySize)
+	if !ok {
+		return
+	}
+
+	result, err := c.ingestReadingsUseCase.Execute(ctx, ctx.GetInt("esp32ID"), batch.Readings)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// IngestReadingBatch maneja la solicitud HTTP con la que un ESP32 envía las lecturas que acumuló
+// sin conexión. Cada lectura debe incluir recorded_at y seq para poder reenviar el lote sin duplicarlo.
+func (c *TelemetryController) IngestReadingBatch(ctx *gin.Context) {
+	batch, ok := decodeReadingBatch(ctx, maxBatchBodySize)
+	if !ok {
+		return
+	}
+
+	result, err := c.ingestReadingsUseCase.ExecuteBatch(ctx, ctx.GetInt("esp32ID"), batch.Readings)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// decodeReadingBatch lee y decodifica el cuerpo de un envío de lecturas; si falla, responde el error
+func decodeReadingBatch(ctx *gin.Context, maxBodySize int64) (*codec.ReadingBatch, bool) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxBodySize)
+	body, err := ctx.GetRawData()
+	if err != nil {
+		ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "request body too large"})
+		return nil, false
+	}
+
+	batch, err := codec.DecodeReadingBatch(ctx.GetHeader("Content-Type"), body)
+	if err != nil {
+		if errors.Is(err, codec.ErrUnsupportedContentType) {
+			ctx.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+			return nil, false
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	return batch, true
+}
+
+// SetupRoutes configura las rutas de telemetría
+func (c *TelemetryController) SetupRoutes(router *gin.Engine, deviceAuthMiddleware gin.HandlerFunc) {
+	api := router.Group("/api")
+	{
+		devices := api.Group("/devices")
+		// Rutas de dispositivos (requieren número de serie y token del ESP32)
+		devices.Use(deviceAuthMiddleware)
+		{
+			devices.POST("/readings", c.IngestReadings)
+			devices.POST("/readings/batch", c.IngestReadingBatch)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"log"
+
+	"github.com/gin-gonic/gin"
+	"hex_go/src/config"
+	esp32Services "hex_go/src/esp32/application/services"
+	esp32Repo "hex_go/src/esp32/infrastructure/repositories"
+	"hex_go/src/middleware"
+	"hex_go/src/telemetry/application/services"
+	"hex_go/src/telemetry/infrastructure/controllers"
+	"hex_go/src/telemetry/infrastructure/mqtt"
+	"hex_go/src/telemetry/infrastructure/repositories"
+)
+
+// Init inicializa la infraestructura de telemetría
+func Init(router *gin.Engine, db *sql.DB) {
+	// Crear tabla de lecturas si no existe
+	createSensorReadingsTable(db)
+	migrateSensorReadingsTable(db)
+	createSensorAlarmEpisodesTable(db)
+
+	// Inicializar casos de uso
+	esp32Repository := esp32Repo.NewMySQLESP32Repository(db)
+	authenticateDeviceUseCase := esp32Services.NewAuthenticateDeviceUseCase(esp32Repository)
+	ingestReadingsUseCase := newIngestReadingsUseCase(db)
+
+	// Inicializar controladores
+	telemetryController := controllers.NewTelemetryController(ingestReadingsUseCase)
+
+	// Configurar rutas
+	deviceAuthMiddleware := middleware.DeviceAuthMiddleware(authenticateDeviceUseCase)
+	telemetryController.SetupRoutes(router, deviceAuthMiddleware)
+}
+
+// StartMQTTBridge inicia el puente MQTT si MQTT_BROKER_URL está definida
+func StartMQTTBridge(db *sql.DB) {
+	brokerURL := config.GetEnv("MQTT_BROKER_URL", "")
+	if brokerURL == "" {
+		log.Println("MQTT_BROKER_URL not set, MQTT bridge disabled")
+		return
+	}
+
+	esp32Repository := esp32Repo.NewMySQLESP32Repository(db)
+	deviceEventRepository := esp32Repo.NewMySQLDeviceEventRepository(db)
+
+	bridge := mqtt.NewBridge(
+		mqtt.Options{
+			BrokerURL:   brokerURL,
+			ClientID:    config.GetEnv("MQTT_CLIENT_ID", "stopfire-api"),
+			Username:    config.GetEnv("MQTT_USERNAME", ""),
+			Password:    config.GetEnv("MQTT_PASSWORD", ""),
+			TopicPrefix: config.GetEnv("MQTT_TOPIC_PREFIX", "stopfire"),
+		},
+		esp32Services.NewAuthenticateDeviceUseCase(esp32Repository),
+		newIngestReadingsUseCase(db),
+		esp32Services.NewRecordHeartbeatUseCase(esp32Repository, deviceEventRepository),
+	)
+
+	if err := bridge.Start(); err != nil {
+		log.Printf("Warning: Failed to start MQTT bridge: %v", err)
+		return
+	}
+	log.Printf("MQTT bridge started for %s", brokerURL)
+}
+
+// newIngestReadingsUseCase construye el caso de uso de ingesta compartido por HTTP y MQTT
+func newIngestReadingsUseCase(db *sql.DB) *services.IngestReadingsUseCase {
+	esp32Repository := esp32Repo.NewMySQLESP32Repository(db)
+	deviceEventRepository := esp32Repo.NewMySQLDeviceEventRepository(db)
+	deviceConfigRepository := esp32Repo.NewMySQLDeviceConfigRepository(db)
+	deviceSensorRepository := esp32Repo.NewMySQLDeviceSensorRepository(db)
+	sensorTypeRepository := esp32Repo.NewMySQLSensorTypeRepository(db)
+	sensorCalibrationRepository := esp32Repo.NewMySQLSensorCalibrationRepository(db)
+
+	return services.NewIngestReadingsUseCase(
+		repositories.NewMySQLReadingRepository(db),
+		repositories.NewESP32SensorRepository(deviceSensorRepository, sensorTypeRepository, sensorCalibrationRepository),
+		repositories.NewMySQLSensorStateRepository(db),
+		repositories.NewESP32ThresholdRepository(deviceConfigRepository),
+		esp32Services.NewRecordHeartbeatUseCase(esp32Repository, deviceEventRepository),
+	)
+}
+
+// createSensorReadingsTable crea la tabla de lecturas de sensores si no existe
+func createSensorReadingsTable(db *sql.DB) {
+	query := `
+		CREATE TABLE IF NOT EXISTS sensor_readings (
+			idReading BIGINT AUTO_INCREMENT PRIMARY KEY,
+			idESP32 INT NOT NULL,
+			idSensor INT NULL,
+			sensor_type VARCHAR(20) NOT NULL,
+			value DOUBLE NULL,
+			raw_value DOUBLE NULL,
+			alarm TINYINT(1) NOT NULL DEFAULT 0,
+			seq BIGINT UNSIGNED NULL,
+			recorded_at DATETIME(3) NOT NULL,
+			received_at DATETIME(3) NOT NULL,
+			INDEX idx_sensor_readings_esp32 (idESP32, sensor_type, recorded_at),
+			INDEX idx_sensor_readings_sensor (idSensor, recorded_at),
+			UNIQUE KEY uq_sensor_readings_seq (idESP32, seq),
+			FOREIGN KEY (idESP32) REFERENCES esp32(idESP32) ON DELETE CASCADE,
+			FOREIGN KEY (idSensor) REFERENCES device_sensors(idSensor) ON DELETE SET NULL
+		)
+	`
+
+	if _, err := db.Exec(query); err != nil {
+		log.Printf("Warning: Failed to create sensor readings table: %v", err)
+	}
+}
+
+// createSensorAlarmEpisodesTable crea la tabla de rachas de alarma terminadas dentro de un envío.
+// Las fechas se guardan sin fracciones, igual que la fecha de activación de los sensores, para
+// que el módulo de alertas las compare con las alertas ya registradas.
+func createSensorAlarmEpisodesTable(db *sql.DB) {
+	query := `
+		CREATE TABLE IF NOT EXISTS sensor_alarm_episodes (
+			idEpisode BIGINT AUTO_INCREMENT PRIMARY KEY,
+			idESP32 INT NOT NULL,
+			idSensor INT NOT NULL,
+			started_at DATETIME NOT NULL,
+			ended_at DATETIME NOT NULL,
+			UNIQUE KEY uq_sensor_alarm_episodes (idSensor, started_at),
+			INDEX idx_sensor_alarm_episodes_esp32 (idESP32, started_at),
+			FOREIGN KEY (idESP32) REFERENCES esp32(idESP32) ON DELETE CASCADE,
+			FOREIGN KEY (idSensor) REFERENCES device_sensors(idSensor) ON DELETE CASCADE
+		)
+	`
+
+	if _, err := db.Exec(query); err != nil {
+		log.Printf("Warning: Failed to create sensor alarm episodes table: %v", err)
+	}
+}
+
+// migrateSensorReadingsTable agrega a la tabla de lecturas las columnas introducidas después de su creación
+func migrateSensorReadingsTable(db *sql.DB) {
+	config.EnsureColumn(db, "sensor_readings", "seq", "BIGINT UNSIGNED NULL AFTER alarm")
+	config.EnsureIndex(db, "sensor_readings", "uq_sensor_readings_seq", "UNIQUE KEY uq_sensor_readings_seq (idESP32, seq)")
+	config.EnsureColumn(db, "sensor_readings", "idSensor", "INT NULL AFTER idESP32")
+	config.EnsureIndex(db, "sensor_readings", "idx_sensor_readings_sensor", "INDEX idx_sensor_readings_sensor (idSensor, recorded_at)")
+	config.EnsureColumn(db, "sensor_readings", "raw_value", "DOUBLE NULL AFTER value")
+}
@@ -0,0 +1,180 @@
+package mqtt
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	pahomqtt "github.com/eclipse/paho.mqtt.golang"
+	"hex_go/src/middleware"
+	"hex_go/src/telemetry/application/services"
+	"hex_go/src/telemetry/infrastructure/codec"
+)
+
+// messageTimeout limita el tiempo de procesamiento de cada mensaje
+const messageTimeout = 10 * time.Second
+
+// Options contiene los parámetros de conexión al broker
+type Options struct {
+	BrokerURL   string
+	ClientID    string
+	Username    string
+	Password    string
+	TopicPrefix string // Los ESP32 publican en <TopicPrefix>/<numero_serie>/readings, /batch y /heartbeat
+}
+
+// topicFormats asocia el sufijo opcional del tópico con el formato del mensaje;
+// sin sufijo el mensaje es JSON
+var topicFormats = map[string]string{
+	"":     codec.ContentTypeJSON,
+	"cbor": codec.ContentTypeCBOR,
+	"pb":   codec.ContentTypeProtobuf,
+}
+
+// Bridge recibe los mensajes MQTT de los ESP32 y los entrega a los mismos casos de uso que la API HTTP
+type Bridge struct {
+	options               Options
+	client                pahomqtt.Client
+	authenticator         middleware.DeviceAuthenticator
+	ingestReadingsUseCase *services.IngestReadingsUseCase
+	heartbeatRecorder     services.HeartbeatRecorder
+}
+
+// NewBridge crea una nueva instancia de Bridge
+func NewBridge(
+	options Options,
+	authenticator middleware.DeviceAuthenticator,
+	ingestReadingsUseCase *services.IngestReadingsUseCase,
+	heartbeatRecorder services.HeartbeatRecorder,
+) *Bridge {
+	return &Bridge{
+		options:               options,
+		authenticator:         authenticator,
+		ingestReadingsUseCase: ingestReadingsUseCase,
+		heartbeatRecorder:     heartbeatRecorder,
+	}
+}
+
+// Start conecta con el broker y se suscribe a los tópicos de los ESP32. Si el broker no
+// responde a tiempo, el cliente sigue reintentando en segundo plano; si la conexión se
+// pierde, reconecta y vuelve a suscribirse.
+func (b *Bridge) Start() error {
+	clientOptions := pahomqtt.NewClientOptions().
+		AddBroker(b.options.BrokerURL).
+		SetClientID(b.options.ClientID).
+		SetUsername(b.options.Username).
+		SetPassword(b.options.Password).
+		SetAutoReconnect(true).
+		SetConnectRetry(true).
+		SetOrderMatters(false).
+		SetOnConnectHandler(b.subscribe).
+		SetConnectionLostHandler(func(_ pahomqtt.Client, err error) {
+			log.Printf("Warning: MQTT connection lost: %v", err)
+		})
+
+	b.client = pahomqtt.NewClient(clientOptions)
+	token := b.client.Connect()
+	if token.WaitTimeout(messageTimeout) && token.Error() != nil {
+		return token.Error()
+	}
+
+	return nil
+}
+
+// Stop cierra la conexión con el broker
+func (b *Bridge) Stop() {
+	if b.client != nil {
+		b.client.Disconnect(250)
+	}
+}
+
+// subscribe registra las suscripciones cada vez que se establece la conexión
+func (b *Bridge) subscribe(client pahomqtt.Client) {
+	filters := map[string]byte{
+		b.options.TopicPrefix + "/+/readings/#":  1,
+		b.options.TopicPrefix + "/+/batch/#":     1,
+		b.options.TopicPrefix + "/+/heartbeat/#": 1,
+	}
+
+	token := client.SubscribeMultiple(filters, b.handleMessage)
+	if token.Wait() && token.Error() != nil {
+		log.Printf("Warning: MQTT subscription failed: %v", token.Error())
+		return
+	}
+	log.Printf("MQTT bridge subscribed to %[1]s/+/readings/#, %[1]s/+/batch/# and %[1]s/+/heartbeat/#", b.options.TopicPrefix)
+}
+
+// handleMessage procesa un mensaje recibido en cualquiera de los tópicos suscritos
+func (b *Bridge) handleMessage(_ pahomqtt.Client, message pahomqtt.Message) {
+	ctx, cancel := context.WithTimeout(context.Background(), messageTimeout)
+	defer cancel()
+
+	if err := b.dispatch(ctx, message.Topic(), message.Payload()); err != nil {
+		log.Printf("Warning: MQTT message on %s rejected: %v", message.Topic(), err)
+	}
+}
+
+// dispatch autentica al ESP32 que publicó el mensaje y lo entrega al caso de uso correspondiente
+func (b *Bridge) dispatch(ctx context.Context, topic string, payload []byte) error {
+	numeroSerie, kind, contentType, err := b.parseTopic(topic)
+	if err != nil {
+		return err
+	}
+
+	switch kind {
+	case "readings", "batch":
+		message, err := codec.DecodeReadingBatch(contentType, payload)
+		if err != nil {
+			return err
+		}
+
+		esp32, err := b.authenticator.Execute(ctx, numeroSerie, message.Token)
+		if err != nil {
+			return err
+		}
+
+		if kind == "batch" {
+			_, err = b.ingestReadingsUseCase.ExecuteBatch(ctx, esp32.ID, message.Readings)
+		} else {
+			_, err = b.ingestReadingsUseCase.Execute(ctx, esp32.ID, message.Readings)
+		}
+		return err
+	case "heartbeat":
+		message, err := codec.DecodeHeartbeat(contentType, payload)
+		if err != nil {
+			return err
+		}
+
+		esp32, err := b.authenticator.Execute(ctx, numeroSerie, message.Token)
+		if err != nil {
+			return err
+		}
+
+		return b.heartbeatRecorder.Execute(ctx, esp32.ID)
+	}
+
+	return fmt.Errorf("unsupported topic %s", topic)
+}
+
+// parseTopic obtiene el número de serie, el tipo de mensaje y su formato de un tópico
+// <prefijo>/<numero_serie>/<tipo>[/<formato>], donde formato es cbor o pb
+func (b *Bridge) parseTopic(topic string) (string, string, string, error) {
+	rest := strings.TrimPrefix(topic, b.options.TopicPrefix+"/")
+	parts := strings.Split(rest, "/")
+	if rest == topic || len(parts) < 2 || len(parts) > 3 || parts[0] == "" {
+		return "", "", "", fmt.Errorf("unexpected topic %s", topic)
+	}
+
+	format := ""
+	if len(parts) == 3 {
+		format = parts[2]
+	}
+	contentType, ok := topicFormats[format]
+	if !ok {
+		return "", "", "", fmt.Errorf("unsupported payload format %q in topic %s", format, topic)
+	}
+
+	return parts[0], parts[1], contentType, nil
+}
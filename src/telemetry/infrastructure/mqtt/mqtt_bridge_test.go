@@ -0,0 +1,384 @@
+package mqtt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	pahomqtt "github.com/eclipse/paho.mqtt.golang"
+	mochi "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/hooks/auth"
+	"github.com/mochi-mqtt/server/v2/listeners"
+	esp32Entities "hex_go/src/esp32/domain/entities"
+	"hex_go/src/telemetry/application/services"
+	"hex_go/src/telemetry/domain/entities"
+	"hex_go/src/telemetry/infrastructure/codec"
+)
+
+const (
+	testPrefix   = "stopfire"
+	testSerial   = "SN-0001"
+	testToken    = "device-token"
+	testESP32ID  = 1
+	testSensorID = 7
+	waitTimeout  = 5 * time.Second
+)
+
+// recorder guarda lo que el puente entregó a los casos de uso y avisa cada vez que cambia
+type recorder struct {
+	mu         sync.Mutex
+	readings   []*entities.Reading
+	heartbeats []int
+	changed    chan struct{}
+}
+
+func newRecorder() *recorder {
+	return &recorder{changed: make(chan struct{}, 100)}
+}
+
+func (r *recorder) notify() {
+	select {
+	case r.changed <- struct{}{}:
+	default:
+	}
+}
+
+// counts devuelve cuántas lecturas y heartbeats se registraron
+func (r *recorder) counts() (int, int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.readings), len(r.heartbeats)
+}
+
+// waitFor espera hasta que se registren al menos las lecturas y heartbeats indicados
+func (r *recorder) waitFor(t *testing.T, readings, heartbeats int) {
+	t.Helper()
+	deadline := time.After(waitTimeout)
+	for {
+		gotReadings, gotHeartbeats := r.counts()
+		if gotReadings >= readings && gotHeartbeats >= heartbeats {
+			return
+		}
+		select {
+		case <-r.changed:
+		case <-deadline:
+			t.Fatalf("timed out waiting for %d readings and %d heartbeats, got %d and %d",
+				readings, heartbeats, gotReadings, gotHeartbeats)
+		}
+	}
+}
+
+// Repositorios en memoria para construir el caso de uso de ingesta real
+
+type memoryReadingRepository struct{ rec *recorder }
+
+func (m memoryReadingRepository) CreateMany(ctx context.Context, readings []*entities.Reading) (int, error) {
+	m.rec.mu.Lock()
+	m.rec.readings = append(m.rec.readings, readings...)
+	m.rec.mu.Unlock()
+	m.rec.notify()
+	return len(readings), nil
+}
+
+func (m memoryReadingRepository) FindLatestRecordedAt(ctx context.Context, esp32ID int) (map[int]time.Time, error) {
+	return map[int]time.Time{}, nil
+}
+
+type memorySensorRepository struct{}
+
+func (memorySensorRepository) FindByESP32ID(ctx context.Context, esp32ID int) ([]*entities.Sensor, error) {
+	return []*entities.Sensor{{ID: testSensorID, SensorType: "KY_026"}}, nil
+}
+
+type memorySensorStateRepository struct{}
+
+func (memorySensorStateRepository) UpdateState(ctx context.Context, sensorID int, active bool, at time.Time) error {
+	return nil
+}
+
+func (memorySensorStateRepository) CreateAlarmEpisodes(ctx context.Context, episodes []*entities.AlarmEpisode) error {
+	return nil
+}
+
+type memoryThresholdRepository struct{}
+
+func (memoryThresholdRepository) FindByESP32ID(ctx context.Context, esp32ID int) (map[string]entities.Threshold, error) {
+	return map[string]entities.Threshold{}, nil
+}
+
+type memoryHeartbeatRecorder struct{ rec *recorder }
+
+func (m memoryHeartbeatRecorder) Execute(ctx context.Context, esp32ID int) error {
+	m.rec.mu.Lock()
+	m.rec.heartbeats = append(m.rec.heartbeats, esp32ID)
+	m.rec.mu.Unlock()
+	m.rec.notify()
+	return nil
+}
+
+// tokenAuthenticator acepta solo el número de serie y token de prueba
+type tokenAuthenticator struct{}
+
+func (tokenAuthenticator) Execute(ctx context.Context, numeroSerie, token string) (*esp32Entities.ESP32, error) {
+	if numeroSerie != testSerial || token != testToken {
+		return nil, errors.New("invalid device credentials")
+	}
+	return &esp32Entities.ESP32{ID: testESP32ID, NumeroSerie: numeroSerie}, nil
+}
+
+// startBroker levanta un broker MQTT en un puerto libre de la máquina local
+func startBroker(t *testing.T) (*mochi.Server, string) {
+	t.Helper()
+
+	server := mochi.New(&mochi.Options{Logger: slog.New(slog.NewTextHandler(io.Discard, nil))})
+	if err := server.AddHook(new(auth.AllowHook), nil); err != nil {
+		t.Fatal(err)
+	}
+	listener := listeners.NewTCP(listeners.Config{ID: "tcp", Address: "127.0.0.1:0"})
+	if err := server.AddListener(listener); err != nil {
+		t.Fatal(err)
+	}
+	if err := server.Serve(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { server.Close() })
+
+	return server, "tcp://" + listener.Address()
+}
+
+// startBridge conecta el puente al broker y espera a que quede suscrito
+func startBridge(t *testing.T, server *mochi.Server, brokerURL string, rec *recorder) {
+	t.Helper()
+
+	heartbeatRecorder := memoryHeartbeatRecorder{rec: rec}
+	ingestReadingsUseCase := services.NewIngestReadingsUseCase(
+		memoryReadingRepository{rec: rec},
+		memorySensorRepository{},
+		memorySensorStateRepository{},
+		memoryThresholdRepository{},
+		heartbeatRecorder,
+	)
+
+	bridge := NewBridge(Options{
+		BrokerURL:   brokerURL,
+		ClientID:    "bridge-test",
+		TopicPrefix: testPrefix,
+	}, tokenAuthenticator{}, ingestReadingsUseCase, heartbeatRecorder)
+
+	if err := bridge.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(bridge.Stop)
+
+	// El puente se suscribe de forma asíncrona al conectarse
+	deadline := time.Now().Add(waitTimeout)
+	for _, kind := range []string{"readings", "batch", "heartbeat"} {
+		topic := testPrefix + "/" + testSerial + "/" + kind
+		for len(server.Topics.Subscribers(topic).Subscriptions) == 0 {
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out waiting for the bridge to subscribe to %s", topic)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+}
+
+// newPublisher conecta un cliente que simula a los ESP32
+func newPublisher(t *testing.T, brokerURL string) pahomqtt.Client {
+	t.Helper()
+
+	client := pahomqtt.NewClient(pahomqtt.NewClientOptions().AddBroker(brokerURL).SetClientID("esp32-test"))
+	if token := client.Connect(); !token.WaitTimeout(waitTimeout) || token.Error() != nil {
+		t.Fatalf("publisher connection failed: %v", token.Error())
+	}
+	t.Cleanup(func() { client.Disconnect(100) })
+	return client
+}
+
+func publish(t *testing.T, client pahomqtt.Client, topic string, payload []byte) {
+	t.Helper()
+
+	token := client.Publish(topic, 1, false, payload)
+	if !token.WaitTimeout(waitTimeout) || token.Error() != nil {
+		t.Fatalf("publish to %s failed: %v", topic, token.Error())
+	}
+}
+
+// readingBatch arma un envío con count lecturas del sensor de prueba
+func readingBatch(token string, count int) *codec.ReadingBatch {
+	batch := &codec.ReadingBatch{Token: token}
+	start := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+	for i := 0; i < count; i++ {
+		sensorID := testSensorID
+		alarm := false
+		recordedAt := start.Add(time.Duration(i) * time.Second)
+		seq := uint64(i + 1)
+		batch.Readings = append(batch.Readings, services.ReadingInput{
+			SensorID:   &sensorID,
+			Alarm:      &alarm,
+			RecordedAt: &recordedAt,
+			Seq:        &seq,
+		})
+	}
+	return batch
+}
+
+func encodeBatch(t *testing.T, contentType string, batch *codec.ReadingBatch) []byte {
+	t.Helper()
+
+	payload, err := codec.EncodeReadingBatch(contentType, batch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return payload
+}
+
+func encodeHeartbeat(t *testing.T, contentType string, heartbeat *codec.Heartbeat) []byte {
+	t.Helper()
+
+	payload, err := codec.EncodeHeartbeat(contentType, heartbeat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return payload
+}
+
+func TestBridgeDispatchesDeviceMessages(t *testing.T) {
+	server, brokerURL := startBroker(t)
+	rec := newRecorder()
+	startBridge(t, server, brokerURL, rec)
+	publisher := newPublisher(t, brokerURL)
+
+	topic := func(kind, format string) string {
+		topic := fmt.Sprintf("%s/%s/%s", testPrefix, testSerial, kind)
+		if format != "" {
+			topic += "/" + format
+		}
+		return topic
+	}
+
+	tests := []struct {
+		name           string
+		topic          string
+		payload        []byte
+		wantReadings   int
+		wantHeartbeats int
+	}{
+		// Cada envío de lecturas también registra un heartbeat
+		{"readings JSON", topic("readings", ""), encodeBatch(t, codec.ContentTypeJSON, readingBatch(testToken, 2)), 2, 1},
+		{"readings CBOR", topic("readings", "cbor"), encodeBatch(t, codec.ContentTypeCBOR, readingBatch(testToken, 3)), 3, 1},
+		{"readings Protobuf", topic("readings", "pb"), encodeBatch(t, codec.ContentTypeProtobuf, readingBatch(testToken, 1)), 1, 1},
+		// Un lote supera el límite de las lecturas en tiempo real, así que solo lo acepta ExecuteBatch
+		{"batch JSON", topic("batch", ""), encodeBatch(t, codec.ContentTypeJSON, readingBatch(testToken, services.MaxReadingsPerRequest+1)), services.MaxReadingsPerRequest + 1, 1},
+		{"batch CBOR", topic("batch", "cbor"), encodeBatch(t, codec.ContentTypeCBOR, readingBatch(testToken, services.MaxReadingsPerRequest+1)), services.MaxReadingsPerRequest + 1, 1},
+		{"batch Protobuf", topic("batch", "pb"), encodeBatch(t, codec.ContentTypeProtobuf, readingBatch(testToken, services.MaxReadingsPerRequest+1)), services.MaxReadingsPerRequest + 1, 1},
+		{"heartbeat JSON", topic("heartbeat", ""), encodeHeartbeat(t, codec.ContentTypeJSON, &codec.Heartbeat{Token: testToken}), 0, 1},
+		{"heartbeat CBOR", topic("heartbeat", "cbor"), encodeHeartbeat(t, codec.ContentTypeCBOR, &codec.Heartbeat{Token: testToken}), 0, 1},
+		{"heartbeat Protobuf", topic("heartbeat", "pb"), encodeHeartbeat(t, codec.ContentTypeProtobuf, &codec.Heartbeat{Token: testToken}), 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			readingsBefore, heartbeatsBefore := rec.counts()
+
+			publish(t, publisher, tt.topic, tt.payload)
+			rec.waitFor(t, readingsBefore+tt.wantReadings, heartbeatsBefore+tt.wantHeartbeats)
+
+			readings, heartbeats := rec.counts()
+			if readings-readingsBefore != tt.wantReadings || heartbeats-heartbeatsBefore != tt.wantHeartbeats {
+				t.Fatalf("got %d readings and %d heartbeats, want %d and %d",
+					readings-readingsBefore, heartbeats-heartbeatsBefore, tt.wantReadings, tt.wantHeartbeats)
+			}
+		})
+	}
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	for _, reading := range rec.readings {
+		if reading.ESP32ID != testESP32ID || reading.SensorID != testSensorID {
+			t.Fatalf("reading stored for ESP32 %d sensor %d, want ESP32 %d sensor %d",
+				reading.ESP32ID, reading.SensorID, testESP32ID, testSensorID)
+		}
+	}
+	for _, esp32ID := range rec.heartbeats {
+		if esp32ID != testESP32ID {
+			t.Fatalf("heartbeat recorded for ESP32 %d, want %d", esp32ID, testESP32ID)
+		}
+	}
+}
+
+func TestBridgeRejectsInvalidMessages(t *testing.T) {
+	server, brokerURL := startBroker(t)
+	rec := newRecorder()
+	startBridge(t, server, brokerURL, rec)
+	publisher := newPublisher(t, brokerURL)
+
+	readings := encodeBatch(t, codec.ContentTypeJSON, readingBatch(testToken, 1))
+
+	tests := []struct {
+		name    string
+		topic   string
+		payload []byte
+	}{
+		{"wrong token", testPrefix + "/" + testSerial + "/readings", encodeBatch(t, codec.ContentTypeJSON, readingBatch("wrong-token", 1))},
+		{"missing token", testPrefix + "/" + testSerial + "/heartbeat/pb", nil},
+		{"unknown serial", testPrefix + "/SN-9999/heartbeat", encodeHeartbeat(t, codec.ContentTypeJSON, &codec.Heartbeat{Token: testToken})},
+		{"unsupported format", testPrefix + "/" + testSerial + "/readings/xml", readings},
+		{"extra topic level", testPrefix + "/" + testSerial + "/readings/cbor/extra", readings},
+		{"payload in another format", testPrefix + "/" + testSerial + "/readings/pb", readings},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publish(t, publisher, tt.topic, tt.payload)
+		})
+	}
+
+	// Un heartbeat válido publicado al final confirma que el puente procesó los mensajes anteriores
+	publish(t, publisher, testPrefix+"/"+testSerial+"/heartbeat", encodeHeartbeat(t, codec.ContentTypeJSON, &codec.Heartbeat{Token: testToken}))
+	rec.waitFor(t, 0, 1)
+	time.Sleep(200 * time.Millisecond)
+
+	if readings, heartbeats := rec.counts(); readings != 0 || heartbeats != 1 {
+		t.Fatalf("got %d readings and %d heartbeats, want only the final heartbeat", readings, heartbeats)
+	}
+}
+
+func TestParseTopic(t *testing.T) {
+	bridge := &Bridge{options: Options{TopicPrefix: testPrefix}}
+
+	tests := []struct {
+		topic           string
+		wantSerial      string
+		wantKind        string
+		wantContentType string
+		wantErr         bool
+	}{
+		{"stopfire/SN-1/readings", "SN-1", "readings", codec.ContentTypeJSON, false},
+		{"stopfire/SN-1/batch/cbor", "SN-1", "batch", codec.ContentTypeCBOR, false},
+		{"stopfire/SN-1/heartbeat/pb", "SN-1", "heartbeat", codec.ContentTypeProtobuf, false},
+		{"stopfire/SN-1/readings/xml", "", "", "", true},
+		{"stopfire/SN-1/readings/pb/extra", "", "", "", true},
+		{"stopfire//readings", "", "", "", true},
+		{"stopfire/SN-1", "", "", "", true},
+		{"other/SN-1/readings", "", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.topic, func(t *testing.T) {
+			serial, kind, contentType, err := bridge.parseTopic(tt.topic)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTopic() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if serial != tt.wantSerial || kind != tt.wantKind || contentType != tt.wantContentType {
+				t.Fatalf("parseTopic() = %q, %q, %q, want %q, %q, %q",
+					serial, kind, contentType, tt.wantSerial, tt.wantKind, tt.wantContentType)
+			}
+		})
+	}
+}
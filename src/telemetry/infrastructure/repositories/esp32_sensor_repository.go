@@ -0,0 +1,70 @@
+package repositories
+
+import (
+	"context"
+
+	esp32Repositories "hex_go/src/esp32/domain/repositories"
+	"hex_go/src/telemetry/domain/entities"
+	"hex_go/src/telemetry/domain/repositories"
+)
+
+// ESP32SensorRepository implementa SensorRepository leyendo los sensores instalados, sus tipos
+// y sus calibraciones del módulo de ESP32
+type ESP32SensorRepository struct {
+	deviceSensorRepository      esp32Repositories.DeviceSensorRepository
+	sensorTypeRepository        esp32Repositories.SensorTypeRepository
+	sensorCalibrationRepository esp32Repositories.SensorCalibrationRepository
+}
+
+// NewESP32SensorRepository crea una nueva instancia de ESP32SensorRepository
+func NewESP32SensorRepository(
+	deviceSensorRepo esp32Repositories.DeviceSensorRepository,
+	sensorTypeRepo esp32Repositories.SensorTypeRepository,
+	sensorCalibrationRepo esp32Repositories.SensorCalibrationRepository,
+) repositories.SensorRepository {
+	return &ESP32SensorRepository{
+		deviceSensorRepository:      deviceSensorRepo,
+		sensorTypeRepository:        sensorTypeRepo,
+		sensorCalibrationRepository: sensorCalibrationRepo,
+	}
+}
+
+// FindByESP32ID devuelve los sensores instalados en el ESP32 con su estado de alarma y los datos
+// de su última calibración
+func (r *ESP32SensorRepository) FindByESP32ID(ctx context.Context, esp32ID int) ([]*entities.Sensor, error) {
+	deviceSensors, err := r.deviceSensorRepository.FindByESP32ID(ctx, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+
+	sensorTypes, err := r.sensorTypeRepository.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	calibrations, err := r.sensorCalibrationRepository.FindLatestByESP32ID(ctx, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+
+	sensors := make([]*entities.Sensor, 0, len(deviceSensors))
+	for _, deviceSensor := range deviceSensors {
+		sensor := &entities.Sensor{ID: deviceSensor.ID, SensorType: deviceSensor.SensorType}
+		if deviceSensor.Alarm {
+			sensor.AlarmSince = deviceSensor.FechaActivacion
+		}
+		if calibration, ok := calibrations[deviceSensor.ID]; ok {
+			r0 := calibration.R0
+			sensor.R0 = &r0
+		}
+		for _, sensorType := range sensorTypes {
+			if sensorType.Code == deviceSensor.SensorType {
+				sensor.PPMCurveA = sensorType.PPMCurveA
+				sensor.PPMCurveB = sensorType.PPMCurveB
+				break
+			}
+		}
+		sensors = append(sensors, sensor)
+	}
+
+	return sensors, nil
+}
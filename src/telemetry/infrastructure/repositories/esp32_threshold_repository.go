@@ -0,0 +1,40 @@
+package repositories
+
+import (
+	"context"
+
+	esp32Repositories "hex_go/src/esp32/domain/repositories"
+	"hex_go/src/telemetry/domain/entities"
+	"hex_go/src/telemetry/domain/repositories"
+)
+
+// ESP32ThresholdRepository implementa ThresholdRepository leyendo la configuración remota del módulo de ESP32
+type ESP32ThresholdRepository struct {
+	deviceConfigRepository esp32Repositories.DeviceConfigRepository
+}
+
+// NewESP32ThresholdRepository crea una nueva instancia de ESP32ThresholdRepository
+func NewESP32ThresholdRepository(deviceConfigRepo esp32Repositories.DeviceConfigRepository) repositories.ThresholdRepository {
+	return &ESP32ThresholdRepository{
+		deviceConfigRepository: deviceConfigRepo,
+	}
+}
+
+// FindByESP32ID devuelve los umbrales configurados; un ESP32 sin configuración no tiene umbrales
+func (r *ESP32ThresholdRepository) FindByESP32ID(ctx context.Context, esp32ID int) (map[string]entities.Threshold, error) {
+	thresholds := make(map[string]entities.Threshold)
+
+	config, err := r.deviceConfigRepository.FindByESP32ID(ctx, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		return thresholds, nil
+	}
+
+	for sensorType, threshold := range config.Settings.Thresholds {
+		thresholds[sensorType] = entities.Threshold{Min: threshold.Min, Max: threshold.Max}
+	}
+
+	return thresholds, nil
+}
@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"hex_go/src/telemetry/domain/entities"
+	"hex_go/src/telemetry/domain/repositories"
+)
+
+// readingsPerInsert limita la cantidad de filas de cada INSERT para no superar max_allowed_packet
+const readingsPerInsert = 500
+
+// MySQLReadingRepository implementa ReadingRepository usando MySQL
+type MySQLReadingRepository struct {
+	db *sql.DB
+}
+
+// NewMySQLReadingRepository crea una nueva instancia de MySQLReadingRepository
+func NewMySQLReadingRepository(db *sql.DB) repositories.ReadingRepository {
+	return &MySQLReadingRepository{
+		db: db,
+	}
+}
+
+// CreateMany inserta las lecturas en una transacción. Las que repiten (idESP32, seq)
+// no modifican la fila existente y no se cuentan como insertadas.
+func (r *MySQLReadingRepository) CreateMany(ctx context.Context, readings []*entities.Reading) (int, error) {
+	if len(readings) == 0 {
+		return 0, nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	inserted := 0
+	for start := 0; start < len(readings); start += readingsPerInsert {
+		end := start + readingsPerInsert
+		if end > len(readings) {
+			end = len(readings)
+		}
+		chunk := readings[start:end]
+
+		placeholders := make([]string, 0, len(chunk))
+		args := make([]interface{}, 0, len(chunk)*9)
+		for _, reading := range chunk {
+			placeholders = append(placeholders, "(?, ?, ?, ?, ?, ?, ?, ?, ?)")
+			args = append(args, reading.ESP32ID, reading.SensorID, reading.SensorType, reading.Value, reading.Raw,
+				reading.Alarm, reading.Seq, reading.RecordedAt, reading.ReceivedAt)
+		}
+
+		query := `INSERT INTO sensor_readings (idESP32, idSensor, sensor_type, value, raw_value, alarm, seq, recorded_at, received_at)
+                  VALUES ` + strings.Join(placeholders, ", ") + `
+                  ON DUPLICATE KEY UPDATE idReading = idReading`
+
+		result, err := tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return 0, err
+		}
+
+		// Las filas duplicadas no se modifican y MySQL las informa como 0 filas afectadas
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		inserted += int(affected)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return inserted, nil
+}
+
+// FindLatestRecordedAt devuelve el momento de la última lectura guardada de cada sensor de un ESP32.
+// Las lecturas guardadas antes de que existiera el inventario de sensores no tienen sensor y se ignoran.
+func (r *MySQLReadingRepository) FindLatestRecordedAt(ctx context.Context, esp32ID int) (map[int]time.Time, error) {
+	query := `SELECT idSensor, MAX(recorded_at) FROM sensor_readings
+              WHERE idESP32 = ? AND idSensor IS NOT NULL GROUP BY idSensor`
+
+	rows, err := r.db.QueryContext(ctx, query, esp32ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	latest := make(map[int]time.Time)
+
+	for rows.Next() {
+		var sensorID int
+		var recordedAt time.Time
+
+		if err := rows.Scan(&sensorID, &recordedAt); err != nil {
+			return nil, err
+		}
+
+		latest[sensorID] = recordedAt
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return latest, nil
+}
@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"hex_go/src/telemetry/domain/entities"
+	"hex_go/src/telemetry/domain/repositories"
+)
+
+// MySQLSensorStateRepository implementa SensorStateRepository sobre la tabla de sensores instalados
+type MySQLSensorStateRepository struct {
+	db *sql.DB
+}
+
+// NewMySQLSensorStateRepository crea una nueva instancia de MySQLSensorStateRepository
+func NewMySQLSensorStateRepository(db *sql.DB) repositories.SensorStateRepository {
+	return &MySQLSensorStateRepository{
+		db: db,
+	}
+}
+
+// UpdateState activa o desactiva la alarma del sensor indicado
+func (r *MySQLSensorStateRepository) UpdateState(ctx context.Context, sensorID int, active bool, at time.Time) error {
+	// MySQL evalúa las asignaciones en orden, así que la fecha se compara con el estado anterior
+	query := `UPDATE device_sensors
+              SET fecha_activacion = IF(alarm = 0 AND ?, ?, fecha_activacion), alarm = ?
+              WHERE idSensor = ?`
+
+	_, err := r.db.ExecContext(ctx, query, active, at, active, sensorID)
+	return err
+}
+
+// CreateAlarmEpisodes inserta las rachas de alarma terminadas; la clave única por sensor e
+// inicio hace que un lote reenviado no las duplique
+func (r *MySQLSensorStateRepository) CreateAlarmEpisodes(ctx context.Context, episodes []*entities.AlarmEpisode) error {
+	if len(episodes) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, 0, len(episodes))
+	args := make([]interface{}, 0, len(episodes)*4)
+	for _, episode := range episodes {
+		placeholders = append(placeholders, "(?, ?, ?, ?)")
+		args = append(args, episode.ESP32ID, episode.SensorID, episode.StartedAt, episode.EndedAt)
+	}
+
+	query := `INSERT IGNORE INTO sensor_alarm_episodes (idESP32, idSensor, started_at, ended_at)
+              VALUES ` + strings.Join(placeholders, ", ")
+
+	_, err := r.db.ExecContext(ctx, query, args...)
+	return err
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"hex_go/src/users/domain/entities"
+	"hex_go/src/users/domain/repositories"
+)
+
+// BootstrapAdminUseCase implementa el caso de uso para promover al primer administrador.
+// Todas las rutas de administración, incluido el cambio de rol, requieren un administrador,
+// así que el primero se designa al iniciar el servidor con la variable ADMIN_EMAIL.
+type BootstrapAdminUseCase struct {
+	userRepository repositories.UserRepository
+}
+
+// NewBootstrapAdminUseCase crea una nueva instancia de BootstrapAdminUseCase
+func NewBootstrapAdminUseCase(userRepo repositories.UserRepository) *BootstrapAdminUseCase {
+	return &BootstrapAdminUseCase{
+		userRepository: userRepo,
+	}
+}
+
+// Execute promueve a administrador al usuario registrado con el email indicado.
+// Devuelve true si el rol cambió y false si el usuario ya era administrador.
+func (uc *BootstrapAdminUseCase) Execute(ctx context.Context, email string) (bool, error) {
+	user, err := uc.userRepository.FindByEmail(ctx, email)
+	if err != nil {
+		return false, err
+	}
+	if user == nil {
+		return false, fmt.Errorf("no user registered with email %q", email)
+	}
+	if user.Role == entities.RoleAdmin {
+		return false, nil
+	}
+
+	user.Role = entities.RoleAdmin
+	if err := uc.userRepository.Update(ctx, user); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"hex_go/src/users/domain/entities"
+	"hex_go/src/users/domain/repositories"
+)
+
+// memoryUserRepository guarda los usuarios en memoria, indexados por email
+type memoryUserRepository struct {
+	repositories.UserRepository
+	users map[string]*entities.User
+}
+
+func (r *memoryUserRepository) FindByEmail(ctx context.Context, email string) (*entities.User, error) {
+	user, ok := r.users[email]
+	if !ok {
+		return nil, nil
+	}
+	clone := *user
+	return &clone, nil
+}
+
+func (r *memoryUserRepository) Update(ctx context.Context, user *entities.User) error {
+	clone := *user
+	r.users[user.Email] = &clone
+	return nil
+}
+
+func TestBootstrapAdmin(t *testing.T) {
+	repo := &memoryUserRepository{users: map[string]*entities.User{
+		"owner@example.com": {ID: 1, Email: "owner@example.com", Role: entities.RoleUser},
+	}}
+	uc := NewBootstrapAdminUseCase(repo)
+	ctx := context.Background()
+
+	promoted, err := uc.Execute(ctx, "owner@example.com")
+	if err != nil || !promoted {
+		t.Fatalf("first bootstrap = %v, %v, want promoted", promoted, err)
+	}
+	if role := repo.users["owner@example.com"].Role; role != entities.RoleAdmin {
+		t.Errorf("role = %q, want %q", role, entities.RoleAdmin)
+	}
+
+	promoted, err = uc.Execute(ctx, "owner@example.com")
+	if err != nil || promoted {
+		t.Errorf("second bootstrap = %v, %v, want no change", promoted, err)
+	}
+
+	if _, err := uc.Execute(ctx, "missing@example.com"); err == nil {
+		t.Error("expected an error for an unregistered email")
+	}
+}
@@ -73,6 +73,7 @@ func generateJWT(user *entities.User) (string, error) {
 		"id":       user.ID,
 		"username": user.Username,
 		"email":    user.Email,
+		"role":     user.Role,
 		"exp":      time.Now().Add(time.Hour * 24).Unix(), // Token válido por 24 horas
 	}
 
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"hex_go/src/users/domain/entities"
+	"hex_go/src/users/domain/repositories"
+)
+
+// UpdateUserRoleUseCase implementa el caso de uso para cambiar el rol de un usuario.
+// El nuevo rol se aplica a partir del próximo inicio de sesión, ya que viaja en el token.
+type UpdateUserRoleUseCase struct {
+	userRepository repositories.UserRepository
+}
+
+// NewUpdateUserRoleUseCase crea una nueva instancia de UpdateUserRoleUseCase
+func NewUpdateUserRoleUseCase(userRepo repositories.UserRepository) *UpdateUserRoleUseCase {
+	return &UpdateUserRoleUseCase{
+		userRepository: userRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso
+func (uc *UpdateUserRoleUseCase) Execute(ctx context.Context, userID int, role string) (*entities.User, error) {
+	if !entities.IsValidRole(role) {
+		return nil, errors.New("invalid role")
+	}
+
+	user, err := uc.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+
+	user.Role = role
+	if err := uc.userRepository.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
@@ -4,12 +4,30 @@ import (
 	"time"
 )
 
+// Roles disponibles para un usuario
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+	// RoleInstaller identifica a los técnicos que instalan y comisionan los ESP32
+	RoleInstaller = "installer"
+)
+
+// IsValidRole indica si el rol es uno de los roles disponibles
+func IsValidRole(role string) bool {
+	switch role {
+	case RoleUser, RoleAdmin, RoleInstaller:
+		return true
+	}
+	return false
+}
+
 // User representa la entidad de dominio para un usuario
 type User struct {
 	ID        int       `json:"id"`
 	Username  string    `json:"username"`
 	Password  string    `json:"-"` // No se serializa en JSON
 	Email     string    `json:"email"`
+	Role      string    `json:"role"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
@@ -19,6 +37,7 @@ func NewUser(username, password, email string) *User {
 		Username:  username,
 		Password:  password,
 		Email:     email,
+		Role:      RoleUser,
 		CreatedAt: time.Now(),
 	}
 }
\ No newline at end of file
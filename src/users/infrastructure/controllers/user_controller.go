@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"hex_go/src/users/application/services"
@@ -9,15 +10,17 @@ import (
 
 // UserController maneja las solicitudes HTTP para usuarios
 type UserController struct {
-	createUserUseCase *services.CreateUserUseCase
-	loginUserUseCase  *services.LoginUserUseCase
+	createUserUseCase     *services.CreateUserUseCase
+	loginUserUseCase      *services.LoginUserUseCase
+	updateUserRoleUseCase *services.UpdateUserRoleUseCase
 }
 
 // NewUserController crea una nueva instancia de UserController
-func NewUserController(createUserUseCase *services.CreateUserUseCase, loginUserUseCase *services.LoginUserUseCase) *UserController {
+func NewUserController(createUserUseCase *services.CreateUserUseCase, loginUserUseCase *services.LoginUserUseCase, updateUserRoleUseCase *services.UpdateUserRoleUseCase) *UserController {
 	return &UserController{
-		createUserUseCase: createUserUseCase,
-		loginUserUseCase:  loginUserUseCase,
+		createUserUseCase:     createUserUseCase,
+		loginUserUseCase:      loginUserUseCase,
+		updateUserRoleUseCase: updateUserRoleUseCase,
 	}
 }
 
@@ -34,6 +37,11 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
+// UpdateRoleRequest representa la estructura de la solicitud para cambiar el rol de un usuario
+type UpdateRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
 // Register maneja la solicitud HTTP para registrar un nuevo usuario
 func (c *UserController) Register(ctx *gin.Context) {
 	var req CreateUserRequest
@@ -68,8 +76,31 @@ func (c *UserController) Login(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, response)
 }
 
+// UpdateRole maneja la solicitud HTTP para cambiar el rol de un usuario
+func (c *UserController) UpdateRole(ctx *gin.Context) {
+	userID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	var req UpdateRoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := c.updateUserRoleUseCase.Execute(ctx, userID, req.Role)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, user)
+}
+
 // SetupRoutes configura las rutas para el controlador de usuarios
-func (c *UserController) SetupRoutes(router *gin.Engine) {
+func (c *UserController) SetupRoutes(router *gin.Engine, authMiddleware, adminMiddleware gin.HandlerFunc) {
 	api := router.Group("/api")
 	{
 		users := api.Group("/users")
@@ -82,5 +113,12 @@ func (c *UserController) SetupRoutes(router *gin.Engine) {
 				})
 			})
 		}
+
+		admin := api.Group("/admin/users")
+		// Rutas de administración (requieren autenticación y rol de administrador)
+		admin.Use(authMiddleware, adminMiddleware)
+		{
+			admin.PUT("/:id/role", c.UpdateRole)
+		}
 	}
 }
\ No newline at end of file
@@ -1,11 +1,13 @@
 package infrastructure
 
 import (
+	"context"
 	"database/sql"
 	"log"
 
 	"github.com/gin-gonic/gin"
 	"hex_go/src/config"
+	"hex_go/src/middleware"
 	"hex_go/src/users/application/services"
 	"hex_go/src/users/infrastructure/controllers"
 	"hex_go/src/users/infrastructure/repositories"
@@ -28,12 +30,34 @@ func Init(router *gin.Engine) {
 	// Inicializar casos de uso
 	createUserUseCase := services.NewCreateUserUseCase(userRepo)
 	loginUserUseCase := services.NewLoginUserUseCase(userRepo)
+	updateUserRoleUseCase := services.NewUpdateUserRoleUseCase(userRepo)
+
+	// Promover al primer administrador (opcional, requiere ADMIN_EMAIL)
+	bootstrapAdmin(services.NewBootstrapAdminUseCase(userRepo), config.GetEnv("ADMIN_EMAIL", ""))
 
 	// Inicializar controladores
-	userController := controllers.NewUserController(createUserUseCase, loginUserUseCase)
+	userController := controllers.NewUserController(createUserUseCase, loginUserUseCase, updateUserRoleUseCase)
 
 	// Configurar rutas
-	userController.SetupRoutes(router)
+	userController.SetupRoutes(router, middleware.AuthMiddleware(), middleware.AdminMiddleware())
+}
+
+// bootstrapAdmin promueve a administrador al usuario con el email de ADMIN_EMAIL.
+// El usuario debe registrarse antes por /api/users/register; si aún no existe solo se avisa
+// y basta con reiniciar el servidor después del registro.
+func bootstrapAdmin(uc *services.BootstrapAdminUseCase, email string) {
+	if email == "" {
+		return
+	}
+
+	promoted, err := uc.Execute(context.Background(), email)
+	if err != nil {
+		log.Printf("Warning: could not bootstrap admin from ADMIN_EMAIL: %v", err)
+		return
+	}
+	if promoted {
+		log.Printf("User %s promoted to admin from ADMIN_EMAIL", email)
+	}
 }
 
 // createUsersTable crea la tabla de usuarios si no existe
@@ -44,6 +68,7 @@ func createUsersTable(db *sql.DB) {
 			username VARCHAR(255) NOT NULL UNIQUE,
 			password VARCHAR(255) NOT NULL,
 			email VARCHAR(255) NOT NULL UNIQUE,
+			role VARCHAR(20) NOT NULL DEFAULT 'user',
 			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 		)
 	`
@@ -52,4 +77,7 @@ func createUsersTable(db *sql.DB) {
 	if err != nil {
 		log.Fatalf("Failed to create users table: %v", err)
 	}
+
+	// Agregar la columna de rol a tablas creadas antes de existir los roles
+	config.EnsureColumn(db, "users", "role", "VARCHAR(20) NOT NULL DEFAULT 'user'")
 }
\ No newline at end of file
@@ -23,9 +23,9 @@ func NewMySQLUserRepository(db *sql.DB) repositories.UserRepository {
 
 // Create inserta un nuevo usuario en la base de datos
 func (r *MySQLUserRepository) Create(ctx context.Context, user *entities.User) (*entities.User, error) {
-	query := `INSERT INTO users (username, password, email) VALUES (?, ?, ?)`
+	query := `INSERT INTO users (username, password, email, role) VALUES (?, ?, ?, ?)`
 	
-	result, err := r.db.ExecContext(ctx, query, user.Username, user.Password, user.Email)
+	result, err := r.db.ExecContext(ctx, query, user.Username, user.Password, user.Email, user.Role)
 	if err != nil {
 		return nil, err
 	}
@@ -42,7 +42,7 @@ func (r *MySQLUserRepository) Create(ctx context.Context, user *entities.User) (
 
 // FindByID busca un usuario por su ID
 func (r *MySQLUserRepository) FindByID(ctx context.Context, id int) (*entities.User, error) {
-	query := `SELECT id, username, password, email, created_at FROM users WHERE id = ?`
+	query := `SELECT id, username, password, email, role, created_at FROM users WHERE id = ?`
 	
 	var user entities.User
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
@@ -50,6 +50,7 @@ func (r *MySQLUserRepository) FindByID(ctx context.Context, id int) (*entities.U
 		&user.Username,
 		&user.Password,
 		&user.Email,
+		&user.Role,
 		&user.CreatedAt,
 	)
 	
@@ -65,7 +66,7 @@ func (r *MySQLUserRepository) FindByID(ctx context.Context, id int) (*entities.U
 
 // FindByUsername busca un usuario por su nombre de usuario
 func (r *MySQLUserRepository) FindByUsername(ctx context.Context, username string) (*entities.User, error) {
-	query := `SELECT id, username, password, email, created_at FROM users WHERE username = ?`
+	query := `SELECT id, username, password, email, role, created_at FROM users WHERE username = ?`
 	
 	var user entities.User
 	err := r.db.QueryRowContext(ctx, query, username).Scan(
@@ -73,6 +74,7 @@ func (r *MySQLUserRepository) FindByUsername(ctx context.Context, username strin
 		&user.Username,
 		&user.Password,
 		&user.Email,
+		&user.Role,
 		&user.CreatedAt,
 	)
 	
@@ -88,7 +90,7 @@ func (r *MySQLUserRepository) FindByUsername(ctx context.Context, username strin
 
 // FindByEmail busca un usuario por su email
 func (r *MySQLUserRepository) FindByEmail(ctx context.Context, email string) (*entities.User, error) {
-	query := `SELECT id, username, password, email, created_at FROM users WHERE email = ?`
+	query := `SELECT id, username, password, email, role, created_at FROM users WHERE email = ?`
 	
 	var user entities.User
 	err := r.db.QueryRowContext(ctx, query, email).Scan(
@@ -96,6 +98,7 @@ func (r *MySQLUserRepository) FindByEmail(ctx context.Context, email string) (*e
 		&user.Username,
 		&user.Password,
 		&user.Email,
+		&user.Role,
 		&user.CreatedAt,
 	)
 	
@@ -111,9 +114,9 @@ func (r *MySQLUserRepository) FindByEmail(ctx context.Context, email string) (*e
 
 // Update actualiza un usuario existente
 func (r *MySQLUserRepository) Update(ctx context.Context, user *entities.User) error {
-	query := `UPDATE users SET username = ?, password = ?, email = ? WHERE id = ?`
+	query := `UPDATE users SET username = ?, password = ?, email = ?, role = ? WHERE id = ?`
 	
-	_, err := r.db.ExecContext(ctx, query, user.Username, user.Password, user.Email, user.ID)
+	_, err := r.db.ExecContext(ctx, query, user.Username, user.Password, user.Email, user.Role, user.ID)
 	return err
 }
 